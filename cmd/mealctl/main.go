@@ -0,0 +1,108 @@
+// Command mealctl manages cuisines, dishes, API keys and migrations directly in the database,
+// using the same configuration as the server. Run `mealctl -h` for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/cli"
+	appconfig "food-roulette-api/internal/config"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/tenant"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// subject is recorded as the creator of everything mealctl adds.
+const subject = "mealctl"
+
+// Exit codes; invalid usage exits like the server does on an invalid configuration.
+const (
+	exitOK     = 0
+	exitFailed = 1
+	exitUsage  = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("mealctl", flag.ContinueOnError)
+	configPath := flags.String("config", "", "YAML config file; defaults to $"+appconfig.EnvPrefix+"CONFIG or config.yaml")
+	output := flags.String("output", cli.FormatTable, `output format, "table" or "json"`)
+	household := flags.String("household", "", "household id to work in; the shared catalog when empty")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: mealctl [flags] <command> [arguments]\n\nFlags:\n")
+		flags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nCommands:\n%v", cli.Usage())
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	if err := cli.Validate(*output, flags.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "mealctl: %v\n", err.Error())
+		return exitUsage
+	}
+
+	// command output goes to stdout; only problems are logged
+	log.SetOutput(os.Stderr)
+	log.SetLevel(log.WarnLevel)
+
+	var loadArgs []string
+	if *configPath != "" {
+		loadArgs = []string{"-config", *configPath}
+	}
+	appSettings, _, err := appconfig.Load(loadArgs, os.LookupEnv)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return exitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: subject, Role: auth.RoleAdmin})
+	if *household != "" {
+		householdId, err := primitive.ObjectIDFromHex(*household)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "mealctl: %v is not a valid household id\n", *household)
+			return exitUsage
+		}
+		ctx = tenant.WithHousehold(ctx, householdId)
+	}
+
+	service, err := facade.NewService(ctx, appSettings.Mongo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mealctl: %v\n", err.Error())
+		return exitFailed
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = service.Close(closeCtx)
+	}()
+
+	app := &cli.App{Service: &service, Out: os.Stdout, In: os.Stdin, Format: *output}
+	if err = app.Run(ctx, flags.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "mealctl: %v\n", err.Error())
+		if errors.Is(err, cli.ErrUsage) {
+			return exitUsage
+		}
+		return exitFailed
+	}
+	return exitOK
+}
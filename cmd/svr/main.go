@@ -1,38 +1,271 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"food-roulette-api/internal/auth"
+	appconfig "food-roulette-api/internal/config"
+	"food-roulette-api/internal/events"
 	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/health"
+	"food-roulette-api/internal/lifecycle"
+	"food-roulette-api/internal/metrics"
+	"food-roulette-api/internal/ratelimit"
 	"food-roulette-api/internal/routes"
+	"food-roulette-api/internal/rpc"
+	"food-roulette-api/internal/seed"
 	"food-roulette-api/internal/services"
-	"github.com/NYTimes/gziphandler"
-	config "github.com/calebtracey/config-yaml"
-	"github.com/rs/cors"
+	"food-roulette-api/internal/tracing"
+	"food-roulette-api/internal/webhooks"
 	log "github.com/sirupsen/logrus"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
 )
 
-var (
-	configPath = "config.yaml"
-)
-
-const Port = "6080"
+// bootstrapKeyEnv holds an optional admin API key used to issue the first stored keys.
+const bootstrapKeyEnv = "ADMIN_API_KEY"
 
 func main() {
 	defer panicQuit()
+	log.SetFormatter(&log.JSONFormatter{})
+
+	appSettings, options, err := appconfig.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(os.Stderr, "Usage of %v:\n%v", os.Args[0], appconfig.Usage())
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(lifecycle.ExitUsage)
+	}
+	if options.PrintConfig {
+		if err = appSettings.Print(os.Stdout); err != nil {
+			log.Panicln(err)
+		}
+		return
+	}
+	configureLogging(appSettings.Logging)
 
-	appConfig := config.NewFromFile(configPath)
-	service, err := facade.NewService(appConfig)
+	service, err := facade.NewService(context.Background(), appSettings.Mongo)
+	if err != nil {
+		log.Panicln(err)
+	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), appSettings.Tracing)
 	if err != nil {
 		log.Panicln(err)
 	}
 
+	appMetrics := metrics.New()
+	service.Metrics = appMetrics
+	service.Instrument(appMetrics, tracing.StorageObserver{})
+
+	if bootstrapKey := os.Getenv(bootstrapKeyEnv); bootstrapKey != "" {
+		service.BootstrapKeyHash = auth.HashKey(bootstrapKey)
+	}
+
+	if appSettings.Seed.OnFirstBoot {
+		seedFirstBoot(&service, appSettings.Seed.Locale)
+	}
+
+	verifier, err := auth.NewJWTVerifier(appSettings.Auth)
+	if err != nil {
+		log.Panicln(err)
+	}
+
+	readiness := health.NewReadiness(
+		time.Duration(appSettings.Health.ReadinessTimeout)*time.Millisecond,
+		time.Duration(appSettings.Health.DrainDelay)*time.Second,
+	)
+	readiness.Register("mongodb", service.Pinger.Ping)
+
 	handler := routes.Handler{
-		Service: &service,
+		Service:              &facade.TracedService{Next: &service},
+		Metrics:              appMetrics,
+		AccessLog:            log.StandardLogger(),
+		Readiness:            readiness,
+		GraphQLMaxComplexity: appSettings.GraphQL.MaxComplexity,
+		MaxBodyBytes:         appSettings.Limits.MaxBodyBytes,
+		CORS:                 appSettings.CORS,
+		Compression:          appSettings.Compression,
+		Security:             appSettings.Security,
+		Chat:                 appSettings.Chat,
+		Limiter: &ratelimit.Limiter{
+			Store:  ratelimit.NewMemoryStore(),
+			PerKey: ratelimit.Limit{Rate: appSettings.Limits.PerKey.RequestsPerSecond, Burst: appSettings.Limits.PerKey.Burst},
+			PerIP:  ratelimit.Limit{Rate: appSettings.Limits.PerIP.RequestsPerSecond, Burst: appSettings.Limits.PerIP.Burst},
+		},
+	}
+	if verifier != nil {
+		handler.Verifier = verifier
 	}
 
 	router := handler.InitializeRoutes()
 
-	log.Fatal(services.ListenAndServe(Port, gziphandler.GzipHandler(cors.Default().Handler(router))))
+	server, err := handler.Wrap(router)
+	if err != nil {
+		log.Panicln(err)
+	}
+	timeouts := services.Timeouts{
+		Read:  time.Duration(appSettings.Server.ReadTimeout) * time.Second,
+		Write: time.Duration(appSettings.Server.WriteTimeout) * time.Second,
+		Idle:  time.Duration(appSettings.Server.IdleTimeout) * time.Second,
+	}
+
+	manager := &lifecycle.Manager{
+		Readiness:    readiness,
+		DrainTimeout: time.Duration(appSettings.Shutdown.DrainTimeout) * time.Second,
+		HookTimeout:  time.Duration(appSettings.Shutdown.HookTimeout) * time.Second,
+	}
+
+	var serverTLS *services.TLS
+	if tlsSettings := appSettings.Server.TLS; tlsSettings.Enabled() {
+		certificates, err := services.NewCertReloader(tlsSettings.CertFile, tlsSettings.KeyFile)
+		if err != nil {
+			log.Panicln(err)
+		}
+		watchCtx, stopWatching := context.WithCancel(context.Background())
+		certificates.Watch(watchCtx, time.Duration(tlsSettings.ReloadInterval)*time.Second)
+		manager.OnShutdown("stop certificate reload", func(context.Context) error {
+			stopWatching()
+			return nil
+		})
+		serverTLS = &services.TLS{Certificates: certificates}
+		if tlsSettings.RedirectPort != "" {
+			redirect := services.NewRedirectServer(tlsSettings.RedirectPort, appSettings.Server.Port, timeouts)
+			manager.Serve("http redirect", redirect.ListenAndServe, redirect.Shutdown)
+		}
+	}
+	httpServer := services.NewHTTPServer(appSettings.Server.Port, server, timeouts, serverTLS)
+	manager.Serve("http", httpServer.ListenAndServe, httpServer.Shutdown)
+
+	if grpcSettings := appSettings.GRPC; grpcSettings.Enabled {
+		options := rpc.Options{Limiter: handler.Limiter, Reflection: grpcSettings.Reflection}
+		if serverTLS != nil {
+			options.TLS = serverTLS.Config()
+		}
+		rpcServer := rpc.NewServer(handler.Service, handler.Verifier, options)
+		manager.Serve("grpc", func() error {
+			return rpcServer.ListenAndServe(":" + grpcSettings.Port)
+		}, rpcServer.Shutdown)
+	}
+
+	if eventSettings := appSettings.Events; eventSettings.Enabled {
+		watcher := &events.Watcher{
+			Changes:    service.ChangeStream,
+			Tokens:     service.ResumeTokens,
+			Sinks:      eventSinks(eventSettings.Sinks),
+			Name:       "events",
+			RetryDelay: time.Duration(eventSettings.RetryDelay) * time.Second,
+		}
+		runInBackground(manager, "stop change watcher", watcher.Run)
+	}
+
+	webhookSettings := appSettings.Webhooks
+	dispatcher := &webhooks.Dispatcher{
+		Queue:        service.DeliveryQueue,
+		Client:       webhooks.NewClient(time.Duration(webhookSettings.Timeout) * time.Second),
+		MaxAttempts:  webhookSettings.MaxAttempts,
+		BaseDelay:    time.Duration(webhookSettings.RetryBaseDelay) * time.Second,
+		MaxDelay:     time.Duration(webhookSettings.RetryMaxDelay) * time.Second,
+		PollInterval: time.Duration(webhookSettings.PollInterval) * time.Second,
+		// a claimed delivery must outlive its attempt, or another worker sends it again
+		Lease:   time.Duration(webhookSettings.Timeout)*time.Second + webhooks.DefaultLease,
+		Workers: webhookSettings.Workers,
+	}
+	runInBackground(manager, "stop webhook dispatcher", dispatcher.Run)
+
+	// hooks run once no request can reach the database or emit spans any more; metrics are
+	// scraped, so there is nothing of theirs to flush
+	manager.OnShutdown("flush traces", shutdownTracing)
+	manager.OnShutdown("disconnect mongodb", service.Close)
+
+	if err = manager.Run(); err != nil {
+		log.Error(err.Error())
+		os.Exit(lifecycle.ExitCode(err))
+	}
+}
+
+// configureLogging applies the level and format of the standard logger, which also writes the access log.
+func configureLogging(logging appconfig.LoggingConfig) {
+	// the level was checked by Validate
+	level, _ := log.ParseLevel(logging.Level)
+	log.SetLevel(level)
+	if logging.Format == "text" {
+		log.SetFormatter(&log.TextFormatter{FullTimestamp: true})
+	}
+}
+
+// runInBackground runs worker until the shutdown hook named hook cancels it, and makes the hook
+// wait for worker to return.
+func runInBackground(manager *lifecycle.Manager, hook string, worker func(context.Context)) {
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		worker(ctx)
+	}()
+	manager.OnShutdown(hook, func(ctx context.Context) error {
+		stop()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// eventSinks builds the sinks named in the configuration, which Validate checked.
+func eventSinks(names []string) []events.Sink {
+	var sinks []events.Sink
+	for _, name := range names {
+		switch name {
+		case "log":
+			sinks = append(sinks, events.LogSink{})
+		}
+	}
+	return sinks
+}
+
+// seedFirstBoot loads the starter catalog when the shared catalog is empty. A failure is logged
+// rather than fatal; the catalog can still be filled with `mealctl seed`. Replicas that boot
+// together may all seed; the unique cuisine name index, applied by `mealctl migrate`, keeps
+// them from inserting a cuisine twice, so seeding is skipped while any migration is pending.
+func seedFirstBoot(service facade.ServiceI, locale string) {
+	ctx := context.Background()
+	migrations := service.Migrations(ctx)
+	if migrations.Message.Status != strconv.Itoa(http.StatusOK) {
+		log.Errorf("skipped loading the starter catalog, listing migrations failed: %+v", migrations.Message.ErrorLog)
+		return
+	}
+	var pending []int
+	for _, migration := range migrations.Migrations {
+		if migration.AppliedAt == nil {
+			pending = append(pending, migration.Version)
+		}
+	}
+	if len(pending) > 0 {
+		log.Warnf("skipped loading the starter catalog: migrations %v are pending, run `mealctl migrate` first", pending)
+		return
+	}
+
+	if existing := service.AllCuisines(ctx); existing.Message.Status != strconv.Itoa(http.StatusOK) || len(existing.Cuisines) > 0 {
+		return
+	}
+	// the locale was checked by Validate
+	catalog, _ := seed.Catalog(locale)
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: "seed", Role: auth.RoleAdmin})
+	response := service.SeedCatalog(ctx, catalog)
+	if response.Message.Status != strconv.Itoa(http.StatusOK) {
+		log.Errorf("loading the %v starter catalog failed: %+v", locale, response.Message.ErrorLog)
+		return
+	}
+	log.Infof("loaded the %v starter catalog with %v cuisines", locale, len(response.Created))
 }
 
 func panicQuit() {
@@ -0,0 +1,168 @@
+package apperrors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Sentinel kinds. Match them with errors.Is; every *Error carries exactly one kind.
+var (
+	ErrValidation   = errors.New("validation failed")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnavailable  = errors.New("service unavailable")
+	ErrTooLarge     = errors.New("payload too large")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// Stable machine-readable codes returned to clients. Codes are part of the API contract and
+// must not be renamed; add new ones instead.
+const (
+	CodeValidation    = "validation_failed"
+	CodeUnauthorized  = "unauthorized"
+	CodeForbidden     = "forbidden"
+	CodeNotFound      = "not_found"
+	CodeConflict      = "conflict"
+	CodeUnavailable   = "service_unavailable"
+	CodeInternal      = "internal_error"
+	CodeInvalidID     = "invalid_object_id"
+	CodeCuisineExists = "cuisine_already_exists"
+	CodeDishNotFound  = "dish_not_found"
+	CodeKeyNotFound   = "api_key_not_found"
+	CodeNotMember     = "not_household_member"
+
+	CodeCuisineNotFound = "cuisine_not_found"
+	CodeDishExists      = "dish_already_exists"
+
+	CodeWebhookNotFound  = "webhook_not_found"
+	CodeDeliveryNotFound = "delivery_not_found"
+	CodeWebhookURL       = "webhook_url_not_allowed"
+
+	CodeMethodNotAllowed   = "method_not_allowed"
+	CodeUnsupportedVersion = "unsupported_api_version"
+	CodeQueryTooComplex    = "query_too_complex"
+	CodePayloadTooLarge    = "payload_too_large"
+	CodeRateLimited        = "rate_limited"
+)
+
+// Error is a domain error that is safe to show to clients. Detail is public; Err is the
+// underlying cause and is only logged.
+type Error struct {
+	Kind   error
+	Code   string
+	Detail string
+	Err    error
+}
+
+func New(kind error, code string, detail string) *Error {
+	return &Error{Kind: kind, Code: code, Detail: detail}
+}
+
+// Wrap attaches a kind, code and public detail to an internal cause.
+func Wrap(err error, kind error, code string, detail string) *Error {
+	return &Error{Kind: kind, Code: code, Detail: detail, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Detail + ": " + e.Err.Error()
+	}
+	return e.Detail
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// TypeURI identifies the problem type of code in RFC 7807 responses.
+func TypeURI(code string) string {
+	return "urn:food-roulette-api:problem:" + code
+}
+
+// Status maps an error to its HTTP status, defaulting to 500 for untyped errors.
+func Status(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrRateLimited):
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
+
+// Code returns the stable code of a typed error, or the generic code for its status.
+func Code(err error) string {
+	var appErr *Error
+	if errors.As(err, &appErr) && appErr.Code != "" {
+		return appErr.Code
+	}
+	return CodeForStatus(Status(err))
+}
+
+// CodeForStatus returns the generic code used for untyped errors reported with status.
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return CodeValidation
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	}
+	return CodeInternal
+}
+
+// CodeFor returns the stable code of a typed error, or the generic code for the status it is
+// reported with.
+func CodeFor(err error, status int) string {
+	var appErr *Error
+	if errors.As(err, &appErr) && appErr.Code != "" {
+		return appErr.Code
+	}
+	return CodeForStatus(status)
+}
+
+// Detail returns the client-safe description of err. Untyped server errors are reduced to the
+// status text so internal messages never reach the response.
+func Detail(err error, status int) string {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Detail
+	}
+	if status >= http.StatusInternalServerError {
+		return http.StatusText(status)
+	}
+	return err.Error()
+}
@@ -0,0 +1,79 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestStatusCodeDetail(t *testing.T) {
+	cause := fmt.Errorf("connection(localhost:27017[-3]) incomplete read of message header")
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{
+			name:       "Conflict",
+			err:        New(ErrConflict, CodeCuisineExists, "cuisine Thai already exists"),
+			wantStatus: http.StatusConflict,
+			wantCode:   CodeCuisineExists,
+			wantDetail: "cuisine Thai already exists",
+		},
+		{
+			name:       "Wrapped cause stays private",
+			err:        Wrap(cause, ErrUnavailable, "", "the database is unavailable"),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   CodeUnavailable,
+			wantDetail: "the database is unavailable",
+		},
+		{
+			name:       "Typed error wrapped by fmt",
+			err:        fmt.Errorf("rating: %w", New(ErrNotFound, CodeDishNotFound, "dish not found")),
+			wantStatus: http.StatusNotFound,
+			wantCode:   CodeDishNotFound,
+			wantDetail: "dish not found",
+		},
+		{
+			name:       "Rate limited",
+			err:        New(ErrRateLimited, CodeRateLimited, "too many requests"),
+			wantStatus: http.StatusTooManyRequests,
+			wantCode:   CodeRateLimited,
+			wantDetail: "too many requests",
+		},
+		{
+			name:       "Untyped error is internal",
+			err:        cause,
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   CodeInternal,
+			wantDetail: http.StatusText(http.StatusInternalServerError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := Status(tt.err)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantCode, Code(tt.err))
+			assert.Equal(t, tt.wantDetail, Detail(tt.err, status))
+		})
+	}
+}
+
+func TestCodeFor(t *testing.T) {
+	assert.Equal(t, CodeUnsupportedVersion, CodeFor(New(ErrNotFound, CodeUnsupportedVersion, "no v9"), http.StatusNotFound))
+	assert.Equal(t, CodeMethodNotAllowed, CodeFor(fmt.Errorf("method DELETE is not allowed"), http.StatusMethodNotAllowed))
+	assert.Equal(t, CodeValidation, CodeFor(fmt.Errorf("bad json"), http.StatusBadRequest))
+}
+
+func TestError_Is(t *testing.T) {
+	cause := fmt.Errorf("boom")
+	err := Wrap(cause, ErrUnavailable, "", "unavailable")
+
+	assert.True(t, errors.Is(err, ErrUnavailable))
+	assert.True(t, errors.Is(err, cause))
+	assert.False(t, errors.Is(err, ErrNotFound))
+}
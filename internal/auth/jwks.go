@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval bounds how often a JWKS refetch may start, whether the last one succeeded
+// or failed.
+const minRefreshInterval = 30 * time.Second
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// KeySet is a set of JWKS verification keys indexed by kid, loaded from a file or a URL.
+type KeySet struct {
+	mu              sync.RWMutex
+	keys            map[string]any
+	fetch           func(ctx context.Context) ([]byte, error)
+	refreshInterval time.Duration
+	lastRefresh     time.Time
+	lastAttempt     time.Time
+	// refreshing is closed when the refetch in flight finishes; nil while none is.
+	refreshing chan struct{}
+	refreshErr error
+}
+
+func NewFileKeySet(path string) (*KeySet, error) {
+	ks := &KeySet{
+		fetch: func(context.Context) ([]byte, error) {
+			return os.ReadFile(path)
+		},
+	}
+	if err := ks.refresh(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to load jwks file %v; %v", path, err.Error())
+	}
+	return ks, nil
+}
+
+func NewURLKeySet(url string, client *http.Client, refreshInterval time.Duration) (*KeySet, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	ks := &KeySet{
+		refreshInterval: refreshInterval,
+		fetch: func(ctx context.Context) ([]byte, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return nil, err
+			}
+			res, err := client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			defer res.Body.Close()
+			if res.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("unexpected status %v from %v", res.StatusCode, url)
+			}
+			return ioutil.ReadAll(res.Body)
+		},
+	}
+	if err := ks.refresh(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to load jwks from %v; %v", url, err.Error())
+	}
+	return ks, nil
+}
+
+// Key returns the verification key for kid. URL backed sets refetch when the set is stale or the
+// kid is unknown, so rotated keys are picked up without a restart. Only one refetch runs at a
+// time and known keys are served from the cached set while it does; callers asking for an
+// unknown kid wait for it.
+func (ks *KeySet) Key(ctx context.Context, kid string) (any, error) {
+	ks.mu.Lock()
+	key, ok := ks.keys[kid]
+	stale := ks.refreshInterval > 0 && time.Since(ks.lastRefresh) > ks.refreshInterval
+	var done <-chan struct{}
+	if ks.refreshInterval > 0 && (!ok || stale) {
+		done = ks.startRefresh()
+	}
+	ks.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+	if done != nil {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		ks.mu.RLock()
+		key, ok = ks.keys[kid]
+		err := ks.refreshErr
+		ks.mu.RUnlock()
+		if !ok && err != nil {
+			return nil, err
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("no jwks key found for kid %q", kid)
+	}
+
+	return key, nil
+}
+
+// startRefresh returns a channel closed when the refetch in flight finishes, starting one when
+// none is running and the last attempt is older than minRefreshInterval, or nil when it is not.
+// ks.mu must be held. The refetch outlives the request that started it, so it is not cancelled
+// with the request; the client's timeout bounds it.
+func (ks *KeySet) startRefresh() <-chan struct{} {
+	if ks.refreshing != nil {
+		return ks.refreshing
+	}
+	if time.Since(ks.lastAttempt) < minRefreshInterval {
+		return nil
+	}
+	done := make(chan struct{})
+	ks.refreshing = done
+	ks.lastAttempt = time.Now()
+	go func() {
+		err := ks.refresh(context.Background())
+		ks.mu.Lock()
+		ks.refreshErr = err
+		ks.refreshing = nil
+		ks.mu.Unlock()
+		close(done)
+	}()
+	return done
+}
+
+func (ks *KeySet) refresh(ctx context.Context) error {
+	data, err := ks.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.lastRefresh = time.Now()
+	ks.lastAttempt = ks.lastRefresh
+	ks.mu.Unlock()
+
+	return nil
+}
+
+// errUnsupportedKey marks keys of a type or curve the verifier cannot use. Identity providers
+// publish such keys next to usable ones, so they are skipped rather than failing the set.
+var errUnsupportedKey = errors.New("unsupported jwk")
+
+// ParseJWKS decodes the RSA and EC signing keys of a JWKS document, skipping keys of other types
+// and curves.
+func ParseJWKS(data []byte) (map[string]any, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid jwks document; %v", err.Error())
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if errors.Is(err, errUnsupportedKey) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwk %q; %v", k.Kid, err.Error())
+		}
+		keys[k.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("%w: curve %v", errUnsupportedKey, k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("%w: key type %v", errUnsupportedKey, k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// rotatedJWKS returns jwks with its key published a second time under kid.
+func rotatedJWKS(t *testing.T, jwks []byte, kid string) []byte {
+	t.Helper()
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal(jwks, &set); err != nil {
+		t.Fatalf("failed to decode jwks: %v", err)
+	}
+	rotated := map[string]string{}
+	for k, v := range set.Keys[0] {
+		rotated[k] = v
+	}
+	rotated["kid"] = kid
+	set.Keys = append(set.Keys, rotated)
+	data, _ := json.Marshal(set)
+	return data
+}
+
+func TestKeySet_Key_RefreshesOnceAtATime(t *testing.T) {
+	_, jwks := testKeyPair(t)
+	rotated := rotatedJWKS(t, jwks, "rotated-key")
+	var fetches int32
+	refetching := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&fetches, 1) == 1 {
+			_, _ = w.Write(jwks)
+			return
+		}
+		close(refetching)
+		<-release
+		_, _ = w.Write(rotated)
+	}))
+	defer server.Close()
+
+	keys, err := NewURLKeySet(server.URL, server.Client(), time.Minute)
+	if err != nil {
+		t.Fatalf("failed to load jwks: %v", err)
+	}
+	keys.lastAttempt = time.Time{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key, err := keys.Key(context.Background(), "rotated-key")
+			assert.NoError(t, err)
+			assert.NotNil(t, key)
+		}()
+	}
+
+	<-refetching
+	key, err := keys.Key(context.Background(), "test-key")
+	assert.NoError(t, err, "known keys are served while the refetch is pending")
+	assert.NotNil(t, key)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
+
+func TestKeySet_Key_BacksOffAfterFailures(t *testing.T) {
+	_, jwks := testKeyPair(t)
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&fetches, 1) == 1 {
+			_, _ = w.Write(jwks)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	keys, err := NewURLKeySet(server.URL, server.Client(), time.Minute)
+	if err != nil {
+		t.Fatalf("failed to load jwks: %v", err)
+	}
+	keys.lastAttempt = time.Time{}
+
+	_, err = keys.Key(context.Background(), "unknown-key")
+	assert.EqualError(t, err, "unexpected status 503 from "+server.URL)
+	_, err = keys.Key(context.Background(), "unknown-key")
+	assert.EqualError(t, err, `no jwks key found for kid "unknown-key"`)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
+
+func TestParseJWKS(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		wantKids []string
+		wantErr  string
+	}{
+		{
+			name: "Skips encryption keys and unsupported types and curves",
+			document: `{"keys":[
+				{"kid":"rsa","kty":"RSA","use":"sig","n":"AQAB","e":"AQAB"},
+				{"kid":"enc","kty":"RSA","use":"enc","n":"AQAB","e":"AQAB"},
+				{"kid":"ed25519","kty":"OKP","crv":"Ed25519","x":"AQAB"},
+				{"kid":"secp256k1","kty":"EC","crv":"secp256k1","x":"AQAB","y":"AQAB"},
+				{"kid":"p256","kty":"EC","crv":"P-256","x":"AQAB","y":"AQAB"}
+			]}`,
+			wantKids: []string{"p256", "rsa"},
+		},
+		{
+			name:     "Malformed supported key fails the set",
+			document: `{"keys":[{"kid":"rsa","kty":"RSA","n":"not base64!","e":"AQAB"}]}`,
+			wantErr:  `invalid jwk "rsa"; illegal base64 data at input byte 3`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParseJWKS([]byte(tt.document))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			var kids []string
+			for kid := range keys {
+				kids = append(kids, kid)
+			}
+			assert.ElementsMatch(t, tt.wantKids, kids)
+		})
+	}
+}
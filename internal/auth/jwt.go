@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/config"
+	"github.com/golang-jwt/jwt/v4"
+	"time"
+)
+
+var ErrInvalidToken = errors.New("invalid bearer token")
+
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*Principal, error)
+}
+
+// JWTVerifier validates bearer tokens issued by the identity provider against a JWKS and maps
+// the configured role claim onto service roles.
+type JWTVerifier struct {
+	Keys      *KeySet
+	Issuer    string
+	Audience  string
+	RoleClaim string
+	// RoleMapping translates identity provider role or group names to service roles.
+	// Claim values that are not mapped are accepted when they already name a role.
+	RoleMapping map[string]Role
+}
+
+var signingMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(signingMethods))
+
+	_, err := parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.Keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err.Error())
+	}
+
+	// the parser only checks exp when it is present; a token without one would never expire
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("%w: missing expiry", ErrInvalidToken)
+	}
+	if v.Issuer != "" && !claims.VerifyIssuer(v.Issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if v.Audience != "" && !claims.VerifyAudience(v.Audience, true) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return &Principal{
+		Subject: subject,
+		Role:    v.mapRole(claims[v.roleClaim()]),
+	}, nil
+}
+
+func (v *JWTVerifier) roleClaim() string {
+	if v.RoleClaim == "" {
+		return "roles"
+	}
+	return v.RoleClaim
+}
+
+// mapRole returns the highest service role granted by a string or string array claim.
+func (v *JWTVerifier) mapRole(claim interface{}) Role {
+	var values []string
+	switch c := claim.(type) {
+	case string:
+		values = []string{c}
+	case []interface{}:
+		for _, value := range c {
+			if s, ok := value.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	var granted Role
+	for _, value := range values {
+		role, ok := v.RoleMapping[value]
+		if !ok {
+			parsed, err := ParseRole(value)
+			if err != nil {
+				continue
+			}
+			role = parsed
+		}
+		if roleRank[role] > roleRank[granted] {
+			granted = role
+		}
+	}
+
+	return granted
+}
+
+// NewJWTVerifier builds a verifier from the Auth section of config.yaml. A JWKS file takes
+// precedence over a URL; nil is returned when neither is configured.
+func NewJWTVerifier(authConfig config.AuthConfig) (*JWTVerifier, error) {
+	if !authConfig.JWKS.Enabled() {
+		return nil, nil
+	}
+
+	var keys *KeySet
+	var err error
+	if authConfig.JWKS.File != "" {
+		keys, err = NewFileKeySet(authConfig.JWKS.File)
+	} else {
+		keys, err = NewURLKeySet(authConfig.JWKS.URL, nil, time.Duration(authConfig.JWKS.RefreshInterval)*time.Second)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	roleMapping := make(map[string]Role, len(authConfig.RoleMapping))
+	for claimValue, roleName := range authConfig.RoleMapping {
+		role, roleErr := ParseRole(roleName)
+		if roleErr != nil {
+			return nil, fmt.Errorf("invalid role mapping for %v; %v", claimValue, roleErr.Error())
+		}
+		roleMapping[claimValue] = role
+	}
+
+	return &JWTVerifier{
+		Keys:        keys,
+		Issuer:      authConfig.Issuer,
+		Audience:    authConfig.Audience,
+		RoleClaim:   authConfig.RoleClaim,
+		RoleMapping: roleMapping,
+	}, nil
+}
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testKeyPair(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+	jwks, _ := json.Marshal(map[string]any{
+		"keys": []map[string]string{
+			{
+				"kid": "test-key",
+				"kty": "RSA",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+			},
+		},
+	})
+	return privateKey, jwks
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestJWTVerifier_Verify(t *testing.T) {
+	privateKey, jwks := testKeyPair(t)
+	otherKey, _ := testKeyPair(t)
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(jwksPath, jwks, 0o600); err != nil {
+		t.Fatalf("failed to write jwks: %v", err)
+	}
+	keys, err := NewFileKeySet(jwksPath)
+	if err != nil {
+		t.Fatalf("failed to load jwks: %v", err)
+	}
+	verifier := &JWTVerifier{
+		Keys:        keys,
+		Issuer:      "https://idp.example.com",
+		Audience:    "meal-picker",
+		RoleClaim:   "groups",
+		RoleMapping: map[string]Role{"lunch-admins": RoleAdmin},
+	}
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":    "user-123",
+			"iss":    "https://idp.example.com",
+			"aud":    "meal-picker",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": []string{"everyone", "editor"},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		token         func() string
+		wantPrincipal *Principal
+		wantErr       bool
+	}{
+		{
+			name: "Happy Path",
+			token: func() string {
+				return signToken(t, privateKey, "test-key", validClaims())
+			},
+			wantPrincipal: &Principal{Subject: "user-123", Role: RoleEditor},
+		},
+		{
+			name: "Happy Path: mapped group",
+			token: func() string {
+				claims := validClaims()
+				claims["groups"] = []string{"editor", "lunch-admins"}
+				return signToken(t, privateKey, "test-key", claims)
+			},
+			wantPrincipal: &Principal{Subject: "user-123", Role: RoleAdmin},
+		},
+		{
+			name: "Sad Path: expired",
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+				return signToken(t, privateKey, "test-key", claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Path: no expiry",
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "exp")
+				return signToken(t, privateKey, "test-key", claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Path: wrong audience",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "someone-else"
+				return signToken(t, privateKey, "test-key", claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Path: signed by unknown key",
+			token: func() string {
+				return signToken(t, otherKey, "test-key", validClaims())
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Path: unsigned token",
+			token: func() string {
+				token, _ := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+				return token
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPrincipal, err := verifier.Verify(context.Background(), tt.token())
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidToken))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPrincipal, gotPrincipal)
+		})
+	}
+}
+
+func TestNewURLKeySet(t *testing.T) {
+	privateKey, jwks := testKeyPair(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(jwks)
+	}))
+	defer server.Close()
+
+	keys, err := NewURLKeySet(server.URL, server.Client(), time.Minute)
+	if err != nil {
+		t.Fatalf("failed to load jwks: %v", err)
+	}
+	verifier := &JWTVerifier{Keys: keys}
+
+	token := signToken(t, privateKey, "test-key", jwt.MapClaims{"sub": "svc", "roles": "reader", "exp": time.Now().Add(time.Hour).Unix()})
+	gotPrincipal, err := verifier.Verify(context.Background(), token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Principal{Subject: "svc", Role: RoleReader}, gotPrincipal)
+}
@@ -0,0 +1,73 @@
+// Package chat answers Slack and Mattermost slash commands such as `/lunch pick spicy`, and the
+// button clicks on the messages it posts, through the facade.
+package chat
+
+import (
+	"strings"
+)
+
+// Response types of a reply: ephemeral replies are only shown to the user who typed the command.
+const (
+	InChannel = "in_channel"
+	Ephemeral = "ephemeral"
+)
+
+// Reply is a message in Slack's Block Kit format. Text is the notification and fallback text,
+// and is what Mattermost, which does not render blocks, shows.
+type Reply struct {
+	ResponseType    string  `json:"response_type,omitempty"`
+	Text            string  `json:"text"`
+	Blocks          []Block `json:"blocks,omitempty"`
+	ReplaceOriginal bool    `json:"replace_original,omitempty"`
+}
+
+// Block is a section, with optional button accessory, or a row of buttons in an actions block.
+type Block struct {
+	Type      string    `json:"type"`
+	BlockID   string    `json:"block_id,omitempty"`
+	Text      *Text     `json:"text,omitempty"`
+	Accessory *Button   `json:"accessory,omitempty"`
+	Elements  []*Button `json:"elements,omitempty"`
+}
+
+type Text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type Button struct {
+	Type     string `json:"type"`
+	Text     *Text  `json:"text"`
+	ActionID string `json:"action_id"`
+	Value    string `json:"value,omitempty"`
+	Style    string `json:"style,omitempty"`
+}
+
+// Subject names the principal a chat user acts as, so their preferences and ratings are their own.
+func Subject(teamID string, userID string) string {
+	return "chat:" + teamID + ":" + userID
+}
+
+func section(text string) Block {
+	return Block{Type: "section", Text: &Text{Type: "mrkdwn", Text: text}}
+}
+
+func button(label string, actionID string, value string) *Button {
+	return &Button{Type: "button", Text: &Text{Type: "plain_text", Text: label}, ActionID: actionID, Value: value}
+}
+
+func ephemeral(text string) Reply {
+	return Reply{ResponseType: Ephemeral, Text: text}
+}
+
+// mention formats a user reference that the chat server expands to the user's name.
+func mention(userID string) string {
+	return "<@" + userID + ">"
+}
+
+// escape keeps names from being read as Slack markup.
+func escape(s string) string {
+	return markupEscaper.Replace(s)
+}
+
+var markupEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
@@ -0,0 +1,165 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPollOptions caps the cuisines of a vote started without naming them.
+const maxPollOptions = 5
+
+// Command is a slash command as posted by the chat server.
+type Command struct {
+	// Name is the command that was typed, e.g. "/lunch".
+	Name   string
+	Text   string
+	TeamID string
+	UserID string
+}
+
+// Run answers a command. Problems the user can fix, such as an unknown cuisine, are answered
+// with an ephemeral reply rather than an error.
+func Run(ctx context.Context, service facade.ServiceI, command Command) Reply {
+	subcommand, args := splitCommand(command.Text)
+	switch subcommand {
+	case "pick":
+		return pick(ctx, service, command.UserID, strings.Fields(args), false)
+	case "vote":
+		return startVote(ctx, service, command.UserID, args)
+	case "add":
+		return addCuisine(ctx, service, command.UserID, args)
+	case "", "help":
+		return ephemeral(usage(command.Name))
+	default:
+		return ephemeral(fmt.Sprintf("Unknown command `%v`.\n%v", escape(subcommand), usage(command.Name)))
+	}
+}
+
+func splitCommand(text string) (string, string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	return strings.ToLower(fields[0]), strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), fields[0]))
+}
+
+func usage(name string) string {
+	if name == "" {
+		name = "/lunch"
+	}
+	return fmt.Sprintf("*%[1]v pick [tags]* picks a cuisine and dish, e.g. `%[1]v pick spicy`\n"+
+		"*%[1]v vote [cuisine, cuisine]* starts a vote, between random cuisines when none are named\n"+
+		"*%[1]v add cuisine* adds a cuisine to the catalog", name)
+}
+
+// pick picks a cuisine and dish carrying one of tags. The reply has a button to pick again.
+func pick(ctx context.Context, service facade.ServiceI, userID string, tags []string, reroll bool) Reply {
+	response := service.RandomPick(ctx, models.PickRequest{Tags: tags})
+	if response.Message.Status != strconv.Itoa(http.StatusOK) {
+		return ephemeral("No pick: " + failure(response.Message))
+	}
+
+	choice := "*" + escape(response.Cuisine.Name) + "*"
+	if response.Dish != nil {
+		choice += ": *" + escape(response.Dish.Name) + "*"
+	}
+	text := fmt.Sprintf("%v, how about %v?", mention(userID), choice)
+	if reroll {
+		text = fmt.Sprintf("%v re-rolled. How about %v?", mention(userID), choice)
+	}
+	if len(tags) > 0 {
+		text += "\n_" + escape(strings.Join(tags, ", ")) + "_"
+	}
+
+	// the tags ride along in the button so a re-roll picks from the same cuisines
+	value, _ := json.Marshal(tags)
+	return Reply{
+		ResponseType: InChannel,
+		Text:         "Lunch pick: " + strings.ReplaceAll(choice, "*", ""),
+		Blocks: []Block{
+			section(text),
+			{Type: "actions", BlockID: "pick", Elements: []*Button{button("Re-roll", rerollAction, string(value))}},
+		},
+	}
+}
+
+// startVote polls between the cuisines named in args, separated by commas, or between random
+// cuisines of the catalog when args is empty.
+func startVote(ctx context.Context, service facade.ServiceI, userID string, args string) Reply {
+	response := service.AllCuisines(ctx)
+	if response.Message.Status != strconv.Itoa(http.StatusOK) {
+		return ephemeral("No vote: " + failure(response.Message))
+	}
+
+	var options []pollOption
+	if args == "" {
+		cuisines := response.Cuisines
+		random := rand.New(rand.NewSource(time.Now().UnixNano()))
+		random.Shuffle(len(cuisines), func(i, j int) { cuisines[i], cuisines[j] = cuisines[j], cuisines[i] })
+		for _, cuisine := range cuisines {
+			if len(options) == maxPollOptions {
+				break
+			}
+			options = append(options, pollOption{Cuisine: cuisine.ID.Hex(), Name: cuisine.Name})
+		}
+	} else {
+		byName := make(map[string]*models.Cuisine, len(response.Cuisines))
+		for _, cuisine := range response.Cuisines {
+			byName[strings.ToLower(cuisine.Name)] = cuisine
+		}
+		seen := make(map[string]bool)
+		for _, name := range strings.Split(args, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" || seen[strings.ToLower(name)] {
+				continue
+			}
+			seen[strings.ToLower(name)] = true
+			cuisine, ok := byName[strings.ToLower(name)]
+			if !ok {
+				return ephemeral(fmt.Sprintf("There is no cuisine named *%v*.", escape(name)))
+			}
+			options = append(options, pollOption{Cuisine: cuisine.ID.Hex(), Name: cuisine.Name})
+		}
+	}
+	if len(options) < 2 {
+		return ephemeral("A vote needs at least two cuisines.")
+	}
+	return poll{Starter: userID, Options: options}.reply()
+}
+
+// addCuisine adds the cuisine named args; it needs the editor role, like POST /cuisines.
+func addCuisine(ctx context.Context, service facade.ServiceI, userID string, args string) Reply {
+	if principal, ok := auth.PrincipalFromContext(ctx); !ok || !principal.Role.Allows(auth.RoleEditor) {
+		return ephemeral("Adding cuisines from chat is not allowed.")
+	}
+	if args == "" {
+		return ephemeral("Name the cuisine to add, e.g. `add Thai`.")
+	}
+
+	response := service.AddCuisine(ctx, models.AddCuisineRequest{Name: args})
+	if response.Message.Status != strconv.Itoa(http.StatusOK) {
+		return ephemeral(fmt.Sprintf("Could not add *%v*: %v", escape(args), failure(response.Message)))
+	}
+	return Reply{
+		ResponseType: InChannel,
+		Text:         "Added " + response.Cuisine.Name,
+		Blocks:       []Block{section(fmt.Sprintf("%v added *%v* to the cuisines.", mention(userID), escape(response.Cuisine.Name)))},
+	}
+}
+
+// failure describes why a facade call failed.
+func failure(message models.Message) string {
+	if len(message.ErrorLog) > 0 && message.ErrorLog[0].Trace != "" {
+		return escape(message.ErrorLog[0].Trace)
+	}
+	return "something went wrong, status " + message.Status
+}
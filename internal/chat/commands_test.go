@@ -0,0 +1,165 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	thai := &models.Cuisine{ID: primitive.NewObjectID(), Name: "Thai"}
+	italian := &models.Cuisine{ID: primitive.NewObjectID(), Name: "Italian"}
+	ok := models.Message{Status: strconv.Itoa(http.StatusOK)}
+
+	tests := []struct {
+		name      string
+		text      string
+		role      auth.Role
+		mock      func(service *facade.MockServiceI)
+		wantType  string
+		wantText  string
+		wantBlock string
+	}{
+		{
+			name: "Pick with tags",
+			text: "pick spicy Noodles",
+			mock: func(service *facade.MockServiceI) {
+				service.EXPECT().RandomPick(gomock.Any(), models.PickRequest{Tags: []string{"spicy", "Noodles"}}).
+					Return(models.PickResponse{Cuisine: thai, Dish: &models.Dish{Name: "Pad Thai"}, Message: ok})
+			},
+			wantType:  InChannel,
+			wantText:  "Lunch pick: Thai: Pad Thai",
+			wantBlock: "<@U1>, how about *Thai*: *Pad Thai*?\n_spicy, Noodles_",
+		},
+		{
+			name: "Pick without a match",
+			text: "PICK vegan",
+			mock: func(service *facade.MockServiceI) {
+				service.EXPECT().RandomPick(gomock.Any(), gomock.Any()).Return(models.PickResponse{Message: models.Message{
+					Status:   strconv.Itoa(http.StatusNotFound),
+					ErrorLog: []models.ErrorLog{{Trace: "no cuisines match the pick filter"}},
+				}})
+			},
+			wantType: Ephemeral,
+			wantText: "No pick: no cuisines match the pick filter",
+		},
+		{
+			name: "Vote between named cuisines",
+			text: "vote thai, Italian",
+			mock: func(service *facade.MockServiceI) {
+				service.EXPECT().AllCuisines(gomock.Any()).Return(models.AllCuisinesResponse{Cuisines: []*models.Cuisine{italian, thai}, Message: ok})
+			},
+			wantType:  InChannel,
+			wantText:  "Lunch vote: Thai, Italian",
+			wantBlock: "<@U1> started a lunch vote. Vote for a cuisine, or click it again to take your vote back.",
+		},
+		{
+			name: "Vote for an unknown cuisine",
+			text: "vote Thai, Klingon",
+			mock: func(service *facade.MockServiceI) {
+				service.EXPECT().AllCuisines(gomock.Any()).Return(models.AllCuisinesResponse{Cuisines: []*models.Cuisine{thai}, Message: ok})
+			},
+			wantType: Ephemeral,
+			wantText: "There is no cuisine named *Klingon*.",
+		},
+		{
+			name: "Vote needs two cuisines",
+			text: "vote",
+			mock: func(service *facade.MockServiceI) {
+				service.EXPECT().AllCuisines(gomock.Any()).Return(models.AllCuisinesResponse{Cuisines: []*models.Cuisine{thai}, Message: ok})
+			},
+			wantType: Ephemeral,
+			wantText: "A vote needs at least two cuisines.",
+		},
+		{
+			name: "Add a cuisine",
+			text: "add Middle Eastern",
+			role: auth.RoleEditor,
+			mock: func(service *facade.MockServiceI) {
+				service.EXPECT().AddCuisine(gomock.Any(), models.AddCuisineRequest{Name: "Middle Eastern"}).
+					Return(models.CuisineResponse{Cuisine: &models.Cuisine{Name: "Middle Eastern"}, Message: ok})
+			},
+			wantType:  InChannel,
+			wantText:  "Added Middle Eastern",
+			wantBlock: "<@U1> added *Middle Eastern* to the cuisines.",
+		},
+		{
+			name: "Adding a duplicate",
+			text: "add Thai",
+			role: auth.RoleEditor,
+			mock: func(service *facade.MockServiceI) {
+				service.EXPECT().AddCuisine(gomock.Any(), gomock.Any()).Return(models.CuisineResponse{Message: models.Message{
+					Status:   strconv.Itoa(http.StatusConflict),
+					ErrorLog: []models.ErrorLog{{Code: apperrors.CodeCuisineExists, Trace: "cuisine Thai already exists"}},
+				}})
+			},
+			wantType: Ephemeral,
+			wantText: "Could not add *Thai*: cuisine Thai already exists",
+		},
+		{
+			name:     "Readers may not add",
+			text:     "add Thai",
+			role:     auth.RoleReader,
+			wantType: Ephemeral,
+			wantText: "Adding cuisines from chat is not allowed.",
+		},
+		{
+			name:     "Unknown subcommand",
+			text:     "<order> pizza",
+			wantType: Ephemeral,
+			wantText: "Unknown command `&lt;order&gt;`.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			service := facade.NewMockServiceI(ctrl)
+			if tt.mock != nil {
+				tt.mock(service)
+			}
+			ctx := context.Background()
+			if tt.role != "" {
+				ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: Subject("T1", "U1"), Role: tt.role})
+			}
+
+			reply := Run(ctx, service, Command{Name: "/lunch", Text: tt.text, TeamID: "T1", UserID: "U1"})
+			assert.Equal(t, tt.wantType, reply.ResponseType)
+			if tt.wantType == Ephemeral {
+				assert.Contains(t, reply.Text, tt.wantText)
+				assert.Empty(t, reply.Blocks)
+				return
+			}
+			assert.Equal(t, tt.wantText, reply.Text)
+			assert.Equal(t, tt.wantBlock, reply.Blocks[0].Text.Text)
+		})
+	}
+}
+
+func TestRun_PickHasRerollButton(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := facade.NewMockServiceI(ctrl)
+	service.EXPECT().RandomPick(gomock.Any(), gomock.Any()).Return(models.PickResponse{
+		Cuisine: &models.Cuisine{Name: "Thai"},
+		Message: models.Message{Status: strconv.Itoa(http.StatusOK)},
+	})
+
+	reply := Run(context.Background(), service, Command{Text: "pick spicy", UserID: "U1"})
+	assert.Len(t, reply.Blocks, 2)
+	reroll := reply.Blocks[1].Elements[0]
+	assert.Equal(t, rerollAction, reroll.ActionID)
+
+	var tags []string
+	assert.NoError(t, json.Unmarshal([]byte(reroll.Value), &tags))
+	assert.Equal(t, []string{"spicy"}, tags)
+}
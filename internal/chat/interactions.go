@@ -0,0 +1,203 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/webhooks"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Action IDs of the buttons on the messages Run posts.
+const (
+	rerollAction = "reroll"
+	voteAction   = "vote"
+)
+
+// pollBlockPrefix starts the block ID of a poll's heading, which carries who started it.
+const pollBlockPrefix = "poll:"
+
+// responseClient posts updated messages to the response URL of an interaction. The URL comes
+// from the request, so like webhook deliveries it only dials public addresses and does not
+// follow redirects; tests replace it.
+var responseClient = webhooks.NewClient(5 * time.Second)
+
+// Interaction is a Slack block_actions payload: a click on one of the buttons of a message.
+type Interaction struct {
+	Type string `json:"type"`
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+	Team struct {
+		ID string `json:"id"`
+	} `json:"team"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+	// ResponseURL takes the updated message; the response to the interaction itself is ignored.
+	ResponseURL string `json:"response_url"`
+	// Message is the message the button is on.
+	Message struct {
+		Blocks []Block `json:"blocks"`
+	} `json:"message"`
+}
+
+// Interact answers a button click with a message that replaces the one clicked on: re-roll
+// picks again, and vote adds the user's vote, moves it from another cuisine, or takes it back.
+// The poll's votes are kept in its buttons, so no state is stored.
+func Interact(ctx context.Context, service facade.ServiceI, interaction Interaction) (Reply, error) {
+	if interaction.Type != "block_actions" || len(interaction.Actions) == 0 {
+		return Reply{}, fmt.Errorf("unsupported interaction %q", interaction.Type)
+	}
+
+	action := interaction.Actions[0]
+	switch action.ActionID {
+	case rerollAction:
+		var tags []string
+		if err := json.Unmarshal([]byte(action.Value), &tags); err != nil {
+			return Reply{}, fmt.Errorf("malformed re-roll: %w", err)
+		}
+		reply := pick(ctx, service, interaction.User.ID, tags, true)
+		if reply.ResponseType == Ephemeral {
+			// keep the original pick and tell only the user why the re-roll failed
+			return reply, nil
+		}
+		reply.ReplaceOriginal = true
+		return reply, nil
+	case voteAction:
+		var option pollOption
+		if err := json.Unmarshal([]byte(action.Value), &option); err != nil {
+			return Reply{}, fmt.Errorf("malformed vote: %w", err)
+		}
+		p, err := pollFrom(interaction.Message.Blocks)
+		if err != nil {
+			return Reply{}, err
+		}
+		p.vote(interaction.User.ID, option.Cuisine)
+		reply := p.reply()
+		reply.ReplaceOriginal = true
+		return reply, nil
+	default:
+		return Reply{}, fmt.Errorf("unknown action %q", action.ActionID)
+	}
+}
+
+// Respond posts reply to the response URL of an interaction, which must be an https URL.
+func Respond(ctx context.Context, responseURL string, reply Reply) error {
+	if u, err := url.Parse(responseURL); err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("response url %q is not an https url", responseURL)
+	}
+	body, err := json.Marshal(reply)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, responseURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := responseClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("response url answered %v", resp.Status)
+	}
+	return nil
+}
+
+type poll struct {
+	Starter string
+	Options []pollOption
+}
+
+// pollOption is the value of a cuisine's vote button.
+type pollOption struct {
+	Cuisine string   `json:"c"`
+	Name    string   `json:"n"`
+	Voters  []string `json:"v,omitempty"`
+}
+
+// pollFrom reads a poll back from the blocks of its message.
+func pollFrom(blocks []Block) (poll, error) {
+	var p poll
+	for _, block := range blocks {
+		if strings.HasPrefix(block.BlockID, pollBlockPrefix) {
+			p.Starter = strings.TrimPrefix(block.BlockID, pollBlockPrefix)
+		}
+		if block.Accessory == nil || block.Accessory.ActionID != voteAction {
+			continue
+		}
+		var option pollOption
+		if err := json.Unmarshal([]byte(block.Accessory.Value), &option); err != nil {
+			return poll{}, fmt.Errorf("malformed vote: %w", err)
+		}
+		p.Options = append(p.Options, option)
+	}
+	if len(p.Options) == 0 {
+		return poll{}, errors.New("the message is not a vote")
+	}
+	return p, nil
+}
+
+// vote gives userID's single vote to cuisine, or takes it back when it already went there.
+func (p *poll) vote(userID string, cuisine string) {
+	for i := range p.Options {
+		option := &p.Options[i]
+		had := false
+		voters := option.Voters[:0]
+		for _, voter := range option.Voters {
+			if voter == userID {
+				had = true
+				continue
+			}
+			voters = append(voters, voter)
+		}
+		option.Voters = voters
+		if option.Cuisine == cuisine && !had {
+			option.Voters = append(option.Voters, userID)
+		}
+	}
+}
+
+func (p poll) reply() Reply {
+	heading := section(fmt.Sprintf("%v started a lunch vote. Vote for a cuisine, or click it again to take your vote back.", mention(p.Starter)))
+	heading.BlockID = pollBlockPrefix + p.Starter
+	blocks := []Block{heading}
+
+	names := make([]string, len(p.Options))
+	for i, option := range p.Options {
+		names[i] = option.Name
+		text := fmt.Sprintf("*%v*  `%v`", escape(option.Name), votes(len(option.Voters)))
+		if len(option.Voters) > 0 {
+			mentions := make([]string, len(option.Voters))
+			for j, voter := range option.Voters {
+				mentions[j] = mention(voter)
+			}
+			text += "\n" + strings.Join(mentions, " ")
+		}
+		value, _ := json.Marshal(option)
+		block := section(text)
+		block.BlockID = "option:" + strconv.Itoa(i)
+		block.Accessory = button("Vote", voteAction, string(value))
+		blocks = append(blocks, block)
+	}
+	return Reply{ResponseType: InChannel, Text: "Lunch vote: " + strings.Join(names, ", "), Blocks: blocks}
+}
+
+func votes(n int) string {
+	if n == 1 {
+		return "1 vote"
+	}
+	return strconv.Itoa(n) + " votes"
+}
@@ -0,0 +1,134 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestInteract_Vote(t *testing.T) {
+	p := poll{Starter: "U1", Options: []pollOption{{Cuisine: "c1", Name: "Thai"}, {Cuisine: "c2", Name: "Italian"}}}
+	message := p.reply()
+
+	tests := []struct {
+		name      string
+		user      string
+		cuisine   string
+		wantVotes [][]string
+	}{
+		{name: "First vote", user: "U2", cuisine: "c1", wantVotes: [][]string{{"U2"}, nil}},
+		{name: "Another user", user: "U3", cuisine: "c1", wantVotes: [][]string{{"U2", "U3"}, nil}},
+		{name: "Changing the vote moves it", user: "U2", cuisine: "c2", wantVotes: [][]string{{"U3"}, {"U2"}}},
+		{name: "Voting again takes it back", user: "U2", cuisine: "c2", wantVotes: [][]string{{"U3"}, nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the chat server sends back the message the button is on
+			interaction := interactionOn(t, message, tt.user, voteAction, optionValue(t, message, tt.cuisine))
+
+			reply, err := Interact(context.Background(), nil, interaction)
+			assert.NoError(t, err)
+			assert.True(t, reply.ReplaceOriginal)
+			assert.Equal(t, "Lunch vote: Thai, Italian", reply.Text)
+
+			got, err := pollFrom(reply.Blocks)
+			assert.NoError(t, err)
+			assert.Equal(t, "U1", got.Starter)
+			for i, option := range got.Options {
+				assert.Equal(t, tt.wantVotes[i], option.Voters, option.Name)
+			}
+			message = reply
+		})
+	}
+	assert.Equal(t, "*Thai*  `1 vote`\n<@U3>", message.Blocks[1].Text.Text)
+	assert.Equal(t, "*Italian*  `0 votes`", message.Blocks[2].Text.Text)
+}
+
+func TestInteract_Reroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := facade.NewMockServiceI(ctrl)
+	service.EXPECT().RandomPick(gomock.Any(), models.PickRequest{Tags: []string{"spicy"}}).Return(models.PickResponse{
+		Cuisine: &models.Cuisine{Name: "Korean"},
+		Message: models.Message{Status: strconv.Itoa(http.StatusOK)},
+	})
+
+	reply, err := Interact(context.Background(), service, interactionOn(t, Reply{}, "U2", rerollAction, `["spicy"]`))
+	assert.NoError(t, err)
+	assert.True(t, reply.ReplaceOriginal)
+	assert.Equal(t, InChannel, reply.ResponseType)
+	assert.Equal(t, "<@U2> re-rolled. How about *Korean*?\n_spicy_", reply.Blocks[0].Text.Text)
+}
+
+func TestInteract_UnknownAction(t *testing.T) {
+	_, err := Interact(context.Background(), nil, interactionOn(t, Reply{}, "U2", "order", ""))
+	assert.EqualError(t, err, `unknown action "order"`)
+}
+
+func TestRespond(t *testing.T) {
+	var got Reply
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &got))
+		if got.Text == "rejected" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer receiver.Close()
+
+	err := Respond(context.Background(), receiver.URL, Reply{Text: "Lunch pick: Thai"})
+	assert.ErrorContains(t, err, "public address", "the default client refuses the loopback receiver")
+	err = Respond(context.Background(), "http://hooks.example.com/actions/1", Reply{Text: "Lunch pick: Thai"})
+	assert.EqualError(t, err, `response url "http://hooks.example.com/actions/1" is not an https url`)
+
+	defer func(client *http.Client) { responseClient = client }(responseClient)
+	responseClient = receiver.Client()
+
+	assert.NoError(t, Respond(context.Background(), receiver.URL, Reply{ResponseType: InChannel, Text: "Lunch pick: Thai", ReplaceOriginal: true}))
+	assert.Equal(t, Reply{ResponseType: InChannel, Text: "Lunch pick: Thai", ReplaceOriginal: true}, got)
+
+	assert.EqualError(t, Respond(context.Background(), receiver.URL, Reply{Text: "rejected"}), "response url answered 404 Not Found")
+}
+
+// interactionOn builds the payload of a click on a button of message, decoded as the routes decode it.
+func interactionOn(t *testing.T, message Reply, user string, actionID string, value string) Interaction {
+	payload, err := json.Marshal(map[string]interface{}{
+		"type":         "block_actions",
+		"user":         map[string]string{"id": user, "username": "someone"},
+		"team":         map[string]string{"id": "T1"},
+		"actions":      []map[string]string{{"action_id": actionID, "value": value, "type": "button"}},
+		"response_url": "https://hooks.example.com/actions/1",
+		"message":      map[string]interface{}{"type": "message", "blocks": message.Blocks},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var interaction Interaction
+	if err = json.Unmarshal(payload, &interaction); err != nil {
+		t.Fatal(err)
+	}
+	return interaction
+}
+
+func optionValue(t *testing.T, message Reply, cuisine string) string {
+	for _, block := range message.Blocks {
+		if block.Accessory == nil {
+			continue
+		}
+		var option pollOption
+		if err := json.Unmarshal([]byte(block.Accessory.Value), &option); err == nil && option.Cuisine == cuisine {
+			return block.Accessory.Value
+		}
+	}
+	t.Fatalf("no vote button for %v", cuisine)
+	return ""
+}
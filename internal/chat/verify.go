@@ -0,0 +1,70 @@
+package chat
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Request headers of Slack's signing scheme, see https://api.slack.com/authentication/verifying-requests-from-slack.
+const (
+	SignatureHeader = "X-Slack-Signature"
+	TimestampHeader = "X-Slack-Request-Timestamp"
+)
+
+// signatureTolerance is how far a request's timestamp may be from now, which bounds replays.
+const signatureTolerance = 5 * time.Minute
+
+var (
+	// ErrNotConfigured is returned when neither a signing secret nor a token is set, so no
+	// request can be trusted.
+	ErrNotConfigured    = errors.New("chat integration is not configured")
+	ErrInvalidSignature = errors.New("invalid chat request signature")
+)
+
+// Verify checks that a request with the raw form body came from the chat server. Slack signs
+// requests with signingSecret; Mattermost instead sends the slash command's token in the form.
+func Verify(signingSecret string, token string, header http.Header, body []byte, now time.Time) error {
+	if signingSecret == "" && token == "" {
+		return ErrNotConfigured
+	}
+	if signature := header.Get(SignatureHeader); signature != "" && signingSecret != "" {
+		return verifySignature(signingSecret, signature, header.Get(TimestampHeader), body, now)
+	}
+	if token != "" {
+		form, err := url.ParseQuery(string(body))
+		if err == nil && subtle.ConstantTimeCompare([]byte(form.Get("token")), []byte(token)) == 1 {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func verifySignature(secret string, signature string, timestamp string, body []byte, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > signatureTolerance || age < -signatureTolerance {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Sign returns Slack's signature of body sent at timestamp: "v0=" and the hex HMAC-SHA256 of
+// "v0:<timestamp>:<body>".
+func Sign(secret string, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte("v0:" + timestamp + ":"))
+	h.Write(body)
+	return "v0=" + hex.EncodeToString(h.Sum(nil))
+}
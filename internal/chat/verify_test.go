@@ -0,0 +1,85 @@
+package chat
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte("command=%2Flunch&text=pick+spicy&token=mm-token&user_id=U1")
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	tests := []struct {
+		name          string
+		signingSecret string
+		token         string
+		header        http.Header
+		body          []byte
+		wantErr       error
+	}{
+		{
+			name:          "Slack signature",
+			signingSecret: "slack-secret",
+			header:        http.Header{SignatureHeader: {Sign("slack-secret", timestamp, body)}, TimestampHeader: {timestamp}},
+			body:          body,
+		},
+		{
+			name:          "Slack signature over another body",
+			signingSecret: "slack-secret",
+			header:        http.Header{SignatureHeader: {Sign("slack-secret", timestamp, body)}, TimestampHeader: {timestamp}},
+			body:          []byte("command=%2Flunch&text=add+Thai&user_id=U1"),
+			wantErr:       ErrInvalidSignature,
+		},
+		{
+			name:          "Replayed Slack request",
+			signingSecret: "slack-secret",
+			header:        http.Header{SignatureHeader: {Sign("slack-secret", "1699999000", body)}, TimestampHeader: {"1699999000"}},
+			body:          body,
+			wantErr:       ErrInvalidSignature,
+		},
+		{
+			name:          "Unsigned request without a token",
+			signingSecret: "slack-secret",
+			header:        http.Header{},
+			body:          body,
+			wantErr:       ErrInvalidSignature,
+		},
+		{
+			name:   "Mattermost token",
+			token:  "mm-token",
+			header: http.Header{},
+			body:   body,
+		},
+		{
+			name:    "Wrong Mattermost token",
+			token:   "other-token",
+			header:  http.Header{},
+			body:    body,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "Not configured",
+			header:  http.Header{SignatureHeader: {Sign("", timestamp, body)}, TimestampHeader: {timestamp}},
+			body:    body,
+			wantErr: ErrNotConfigured,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.signingSecret, tt.token, tt.header, tt.body, now)
+			assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// the example of https://api.slack.com/authentication/verifying-requests-from-slack
+	body := "token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&team_domain=testteamnow&channel_id=G8PSS9T3V&channel_name=foobar&user_id=U2CERLKJA&user_name=roadrunner&command=%2Fwebhook-collect&text=&response_url=https%3A%2F%2Fhooks.slack.com%2Fcommands%2FT1DC2JH3J%2F397700885554%2F96rGlfmibIGlgcZRskXaIFfN&trigger_id=398738663015.47445629121.803a0bc887a14d10d2c447fce8b6703c"
+	got := Sign("8f742231b10e8888abcd99yyyzzz85a5", "1531420618", []byte(body))
+	assert.Equal(t, "v0=a2114d57b48eac39b9ad189dd8316235a7b4a8d21a10bd27519666489c69b503", got)
+}
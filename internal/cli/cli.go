@@ -0,0 +1,175 @@
+// Package cli implements mealctl, the admin command line that works on the database through the
+// facade, without the HTTP server.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// ErrUsage marks errors caused by an invalid command line rather than by the command itself.
+var ErrUsage = errors.New("invalid usage")
+
+// App runs one command against Service and writes its result to Out.
+type App struct {
+	Service facade.ServiceI
+	Out     io.Writer
+	// In is read by commands given "-" as their file.
+	In io.Reader
+	// Format is FormatTable or FormatJSON.
+	Format string
+}
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	// run defines its flags on flags and calls parse before doing anything else.
+	run func(a *App, ctx context.Context, flags *flag.FlagSet, args []string) error
+}
+
+var commands = []command{
+	{name: "cuisines list", summary: "list cuisines", run: (*App).listCuisines},
+	{name: "cuisines add", args: "[-tags t1,t2] [-dishes d1,d2] <name>", summary: "add a cuisine, optionally with dishes", run: (*App).addCuisine},
+	{name: "cuisines rename", args: "<id> <name>", summary: "rename a cuisine", run: (*App).renameCuisine},
+	{name: "cuisines delete", args: "<id>", summary: "delete a cuisine and its dishes", run: (*App).deleteCuisine},
+	{name: "dishes list", args: "[-cuisine id]", summary: "list dishes, optionally of one cuisine", run: (*App).listDishes},
+	{name: "dishes add", args: "[-tags t1,t2] <cuisine-id> <name>", summary: "add a dish to a cuisine", run: (*App).addDish},
+	{name: "dishes rename", args: "<id> <name>", summary: "rename a dish", run: (*App).renameDish},
+	{name: "dishes delete", args: "<id>", summary: "delete a dish", run: (*App).deleteDish},
+	{name: "import", args: "<file|->", summary: "add the cuisines of a JSON catalog file that do not exist yet", run: (*App).importCatalog},
+	{name: "export", args: "[file]", summary: "write every cuisine to a JSON catalog file, or standard output", run: (*App).exportCatalog},
+	{name: "seed", args: "[-locale l] [-list]", summary: "add the built-in starter catalog; existing cuisines only gain missing dishes", run: (*App).seed},
+	{name: "migrate", args: "[-status]", summary: "apply pending database migrations, or list them with -status", run: (*App).migrate},
+	{name: "keys list", summary: "list API keys", run: (*App).listKeys},
+	{name: "keys issue", args: "<name> <reader|editor|admin>", summary: "issue an API key; the key is only shown once", run: (*App).issueKey},
+	{name: "keys revoke", args: "<id>", summary: "revoke an API key", run: (*App).revokeKey},
+	{name: "pick", args: "[-tags t1,t2] [-min-rating n]", summary: "pick a random dish", run: (*App).pick},
+}
+
+// Usage lists every command with its arguments.
+func Usage() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %v %v\t%v\n", c.name, c.args, c.summary)
+	}
+	_ = w.Flush()
+	return b.String()
+}
+
+// Validate checks format and that args name a command, so a bad command line is reported before
+// connecting to the database.
+func Validate(format string, args []string) error {
+	if format != FormatTable && format != FormatJSON {
+		return fmt.Errorf("%w: output format must be %q or %q, got %q", ErrUsage, FormatTable, FormatJSON, format)
+	}
+	_, _, err := lookup(args)
+	return err
+}
+
+// lookup finds the command named by the first one or two args and returns the remaining ones.
+func lookup(args []string) (command, []string, error) {
+	if len(args) == 0 {
+		return command{}, nil, fmt.Errorf("%w: no command given", ErrUsage)
+	}
+	for _, c := range commands {
+		words := strings.Fields(c.name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == c.name {
+			return c, args[len(words):], nil
+		}
+	}
+	return command{}, nil, fmt.Errorf("%w: unknown command %q", ErrUsage, strings.Join(args, " "))
+}
+
+// Run executes the command named by args.
+func (a *App) Run(ctx context.Context, args []string) error {
+	if err := Validate(a.Format, args); err != nil {
+		return err
+	}
+	c, args, _ := lookup(args)
+	flags := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	err := c.run(a, ctx, flags, args)
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(a.Out, "usage: %v %v\n", c.name, c.args)
+		flags.SetOutput(a.Out)
+		flags.PrintDefaults()
+		return nil
+	}
+	if errors.Is(err, ErrUsage) {
+		return fmt.Errorf("%w\nusage: %v %v", err, c.name, c.args)
+	}
+	return err
+}
+
+// parse parses the command's flags and checks it got between min and max positional arguments.
+func parse(flags *flag.FlagSet, args []string, min int, max int) error {
+	flags.SetOutput(ioutil.Discard)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrUsage, err.Error())
+	}
+	if flags.NArg() < min || flags.NArg() > max {
+		return fmt.Errorf("%w: wrong number of arguments", ErrUsage)
+	}
+	return nil
+}
+
+// check turns a failed facade response into an error naming its root cause and details.
+func check(message models.Message) error {
+	status, _ := strconv.Atoi(message.Status)
+	if status >= 200 && status < 300 {
+		return nil
+	}
+	if len(message.ErrorLog) == 0 {
+		return fmt.Errorf("failed with status %v", message.Status)
+	}
+	details := make([]string, len(message.ErrorLog))
+	for i, entry := range message.ErrorLog {
+		details[i] = entry.Trace
+		if entry.Field != "" {
+			details[i] = entry.Field + ": " + entry.Trace
+		}
+	}
+	return fmt.Errorf("%v (%v): %v", message.ErrorLog[0].RootCause, message.ErrorLog[0].Code, strings.Join(details, "; "))
+}
+
+// print writes v as indented JSON, or calls table with a writer whose columns are tab separated.
+func (a *App) print(v interface{}, table func(w io.Writer)) error {
+	if a.Format == FormatJSON {
+		encoder := json.NewEncoder(a.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+	w := tabwriter.NewWriter(a.Out, 0, 4, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,310 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var ok = models.Message{Status: strconv.Itoa(http.StatusOK)}
+
+func TestApp_Run(t *testing.T) {
+	thaiId, _ := primitive.ObjectIDFromHex("64b7ae9d00000000000000a1")
+	dishId, _ := primitive.ObjectIDFromHex("64b7ae9d00000000000000b1")
+	thai := &models.Cuisine{ID: thaiId, Name: "Thai", Tags: []string{"spicy"}, Dishes: []models.Dish{
+		{ID: dishId, Name: "Pad Thai", Tags: []string{"noodles"}},
+	}}
+	appliedAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		args       []string
+		format     string
+		in         string
+		expect     func(m *facade.MockServiceI)
+		wantOut    string
+		wantErr    string
+		wantUsage  bool
+		wantOutput func(t *testing.T, out string)
+	}{
+		{
+			name: "List cuisines as a table",
+			args: []string{"cuisines", "list"},
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().AllCuisines(gomock.Any()).Return(models.AllCuisinesResponse{Cuisines: []*models.Cuisine{thai}, Message: ok})
+			},
+			wantOut: "ID                        NAME  TAGS   DISHES\n" +
+				"64b7ae9d00000000000000a1  Thai  spicy  1\n",
+		},
+		{
+			name:   "List dishes as JSON",
+			args:   []string{"dishes", "list", "-cuisine", thaiId.Hex()},
+			format: FormatJSON,
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().AllCuisines(gomock.Any()).Return(models.AllCuisinesResponse{Cuisines: []*models.Cuisine{thai, {ID: primitive.NewObjectID(), Name: "Italian"}}, Message: ok})
+			},
+			wantOut: `[
+  {
+    "_id": "64b7ae9d00000000000000b1",
+    "cuisine": "64b7ae9d00000000000000a1",
+    "name": "Pad Thai",
+    "tags": [
+      "noodles"
+    ],
+    "householdId": "000000000000000000000000"
+  }
+]
+`,
+		},
+		{
+			name: "Add a cuisine with dishes and tags",
+			args: []string{"cuisines", "add", "-tags", "spicy, noodles", "-dishes", "Pad Thai,Green Curry", "Thai"},
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().AddCuisine(gomock.Any(), models.AddCuisineRequest{
+					Name:   "Thai",
+					Tags:   []string{"spicy", "noodles"},
+					Dishes: []models.Dish{{Name: "Pad Thai"}, {Name: "Green Curry"}},
+				}).Return(models.CuisineResponse{Cuisine: thai, Message: ok})
+			},
+			wantOut: "ID                        NAME  TAGS   DISHES\n" +
+				"64b7ae9d00000000000000a1  Thai  spicy  1\n",
+		},
+		{
+			name: "Rename a dish",
+			args: []string{"dishes", "rename", dishId.Hex(), "Pad See Ew"},
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().RenameDish(gomock.Any(), dishId.Hex(), models.RenameRequest{Name: "Pad See Ew"}).
+					Return(models.DishResponse{Dish: &models.Dish{ID: dishId, Cuisine: thaiId, Name: "Pad See Ew"}, Message: ok})
+			},
+			wantOut: "ID                        CUISINE                   NAME        TAGS\n" +
+				"64b7ae9d00000000000000b1  64b7ae9d00000000000000a1  Pad See Ew  \n",
+		},
+		{
+			name: "Facade errors are reported with their code and detail",
+			args: []string{"cuisines", "delete", thaiId.Hex()},
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().DeleteCuisine(gomock.Any(), thaiId.Hex()).Return(models.CuisineResponse{Message: models.Message{
+					Status:   "404",
+					ErrorLog: []models.ErrorLog{{RootCause: "Delete error", Code: "cuisine_not_found", Trace: "cuisine 64b7ae9d00000000000000a1 not found"}},
+				}})
+			},
+			wantErr: "Delete error (cuisine_not_found): cuisine 64b7ae9d00000000000000a1 not found",
+		},
+		{
+			name: "Import from standard input",
+			args: []string{"import", "-"},
+			in:   `{"cuisines": [{"name": "Thai", "dishes": [{"name": "Pad Thai"}]}, {"name": "Italian"}]}`,
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().ImportCatalog(gomock.Any(), models.Catalog{Cuisines: []models.AddCuisineRequest{
+					{Name: "Thai", Dishes: []models.Dish{{Name: "Pad Thai"}}},
+					{Name: "Italian"},
+				}}).Return(models.ImportResponse{Created: []string{"Italian"}, Skipped: []string{"Thai"}, Message: ok})
+			},
+			wantOut: "CUISINE  RESULT\n" +
+				"Italian  created\n" +
+				"Thai     skipped, already exists\n",
+		},
+		{
+			name:    "Import rejects files that are not catalogs",
+			args:    []string{"import", "-"},
+			in:      `[1, 2]`,
+			wantErr: "- is not a catalog file",
+		},
+		{
+			name: "Export writes the catalog",
+			args: []string{"export"},
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().ExportCatalog(gomock.Any()).Return(models.CatalogResponse{
+					Catalog: &models.Catalog{Cuisines: []models.AddCuisineRequest{{Name: "Thai", Tags: []string{"spicy"}}}},
+					Message: ok,
+				})
+			},
+			wantOut: `{
+  "cuisines": [
+    {
+      "name": "Thai",
+      "tags": [
+        "spicy"
+      ]
+    }
+  ]
+}
+`,
+		},
+		{
+			name: "Seed a regional starter catalog",
+			args: []string{"seed", "-locale", "en-GB"},
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().SeedCatalog(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, catalog models.Catalog) models.ImportResponse {
+					assert.Len(t, catalog.Cuisines, 14)
+					return models.ImportResponse{Created: []string{"British"}, Updated: []string{"Indian"}, Skipped: []string{"Thai"}, Message: ok}
+				})
+			},
+			wantOut: "CUISINE  RESULT\n" +
+				"British  created\n" +
+				"Indian   missing dishes added\n" +
+				"Thai     skipped, already exists\n",
+		},
+		{
+			name:      "Seed an unknown locale",
+			args:      []string{"seed", "-locale", "pt"},
+			wantErr:   `no starter catalog for locale "pt"`,
+			wantUsage: true,
+		},
+		{
+			name:    "List starter catalogs",
+			args:    []string{"seed", "-list"},
+			wantOut: "LOCALE\nde\nen\nen-GB\nen-US\nes\nja\n",
+		},
+		{
+			name: "Migrate reports what it applied before failing",
+			args: []string{"migrate"},
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().Migrate(gomock.Any()).Return(models.MigrationsResponse{
+					Migrations: []*models.Migration{{Version: 1, Description: "index cuisines", AppliedAt: &appliedAt}},
+					Message: models.Message{
+						Status:   "500",
+						ErrorLog: []models.ErrorLog{{RootCause: "Migration error", Code: "internal_error", Trace: "migration 2 failed"}},
+					},
+				})
+			},
+			wantOut: "VERSION  DESCRIPTION     APPLIED\n" +
+				"1        index cuisines  2023-05-01T12:00:00Z\n",
+			wantErr: "Migration error (internal_error): migration 2 failed",
+		},
+		{
+			name: "Migration status",
+			args: []string{"migrate", "-status"},
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().Migrations(gomock.Any()).Return(models.MigrationsResponse{
+					Migrations: []*models.Migration{{Version: 1, Description: "index cuisines", AppliedAt: &appliedAt}, {Version: 2, Description: "index keys"}},
+					Message:    ok,
+				})
+			},
+			wantOut: "VERSION  DESCRIPTION     APPLIED\n" +
+				"1        index cuisines  2023-05-01T12:00:00Z\n" +
+				"2        index keys      -\n",
+		},
+		{
+			name:   "Issue a key as JSON",
+			args:   []string{"keys", "issue", "frontend", "editor"},
+			format: FormatJSON,
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().IssueApiKey(gomock.Any(), models.IssueKeyRequest{Name: "frontend", Role: "editor"}).Return(models.ApiKeyResponse{
+					ApiKey:  &models.ApiKey{Name: "frontend", Role: "editor"},
+					Key:     "mp_secret",
+					Message: models.Message{Status: "201"},
+				})
+			},
+			wantOutput: func(t *testing.T, out string) {
+				assert.Contains(t, out, `"key": "mp_secret"`)
+				assert.Contains(t, out, `"role": "editor"`)
+				assert.NotContains(t, out, "Message")
+			},
+		},
+		{
+			name: "Pick with filters",
+			args: []string{"pick", "-tags", "spicy", "-min-rating", "4"},
+			expect: func(m *facade.MockServiceI) {
+				m.EXPECT().RandomPick(gomock.Any(), models.PickRequest{Tags: []string{"spicy"}, MinRating: 4}).
+					Return(models.PickResponse{Cuisine: thai, Dish: &thai.Dishes[0], Message: ok})
+			},
+			wantOut: "CUISINE  DISH      TAGS\n" +
+				"Thai     Pad Thai  noodles\n",
+		},
+		{
+			name:      "Unknown command",
+			args:      []string{"cuisines", "purge"},
+			wantErr:   `unknown command "cuisines purge"`,
+			wantUsage: true,
+		},
+		{
+			name:      "Missing arguments",
+			args:      []string{"cuisines", "rename", thaiId.Hex()},
+			wantErr:   "usage: cuisines rename <id> <name>",
+			wantUsage: true,
+		},
+		{
+			name:      "Unknown output format",
+			args:      []string{"cuisines", "list"},
+			format:    "yaml",
+			wantErr:   `output format must be "table" or "json", got "yaml"`,
+			wantUsage: true,
+		},
+		{
+			name: "Command help",
+			args: []string{"pick", "-h"},
+			wantOutput: func(t *testing.T, out string) {
+				assert.Contains(t, out, "usage: pick [-tags t1,t2] [-min-rating n]")
+				assert.Contains(t, out, "-min-rating")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFacade := facade.NewMockServiceI(ctrl)
+			if tt.expect != nil {
+				tt.expect(mockFacade)
+			}
+			if tt.format == "" {
+				tt.format = FormatTable
+			}
+			out := &bytes.Buffer{}
+			app := &App{Service: mockFacade, Out: out, In: strings.NewReader(tt.in), Format: tt.format}
+
+			err := app.Run(context.Background(), tt.args)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Equal(t, tt.wantUsage, errors.Is(err, ErrUsage))
+			}
+			if tt.wantOutput != nil {
+				tt.wantOutput(t, out.String())
+			} else {
+				assert.Equal(t, tt.wantOut, out.String())
+			}
+		})
+	}
+}
+
+func TestApp_Run_ExportToFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	catalog := &models.Catalog{Cuisines: []models.AddCuisineRequest{{Name: "Thai", Dishes: []models.Dish{{Name: "Pad Thai"}}}}}
+	mockFacade.EXPECT().ExportCatalog(gomock.Any()).Return(models.CatalogResponse{Catalog: catalog, Message: ok})
+	mockFacade.EXPECT().ImportCatalog(gomock.Any(), *catalog).Return(models.ImportResponse{Skipped: []string{"Thai"}, Message: ok})
+
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	out := &bytes.Buffer{}
+	app := &App{Service: mockFacade, Out: out, Format: FormatTable}
+	if err := app.Run(context.Background(), []string{"export", path}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, out.String())
+	data, err := ioutil.ReadFile(path)
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(data), `"name": "Pad Thai"`)
+	}
+
+	// an exported file imports unchanged
+	assert.NoError(t, app.Run(context.Background(), []string{"import", path}))
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+}
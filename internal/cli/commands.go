@@ -0,0 +1,346 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/seed"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+func (a *App) listCuisines(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	if err := parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+	response := a.Service.AllCuisines(ctx)
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	return a.print(response.Cuisines, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tTAGS\tDISHES")
+		for _, cuisine := range response.Cuisines {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", cuisine.ID.Hex(), cuisine.Name, strings.Join(cuisine.Tags, ","), len(cuisine.Dishes))
+		}
+	})
+}
+
+func (a *App) addCuisine(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	tags := flags.String("tags", "", "comma-separated tags")
+	dishes := flags.String("dishes", "", "comma-separated dish names")
+	if err := parse(flags, args, 1, 1); err != nil {
+		return err
+	}
+	request := models.AddCuisineRequest{Name: flags.Arg(0), Tags: splitList(*tags)}
+	for _, name := range splitList(*dishes) {
+		request.Dishes = append(request.Dishes, models.Dish{Name: name})
+	}
+	response := a.Service.AddCuisine(ctx, request)
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	return a.printCuisine(response.Cuisine)
+}
+
+func (a *App) renameCuisine(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	if err := parse(flags, args, 2, 2); err != nil {
+		return err
+	}
+	response := a.Service.RenameCuisine(ctx, flags.Arg(0), models.RenameRequest{Name: flags.Arg(1)})
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	return a.printCuisine(response.Cuisine)
+}
+
+func (a *App) deleteCuisine(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	if err := parse(flags, args, 1, 1); err != nil {
+		return err
+	}
+	response := a.Service.DeleteCuisine(ctx, flags.Arg(0))
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	return a.printCuisine(response.Cuisine)
+}
+
+func (a *App) printCuisine(cuisine *models.Cuisine) error {
+	return a.print(cuisine, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tTAGS\tDISHES")
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", cuisine.ID.Hex(), cuisine.Name, strings.Join(cuisine.Tags, ","), len(cuisine.Dishes))
+	})
+}
+
+func (a *App) listDishes(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	cuisineId := flags.String("cuisine", "", "only list the dishes of this cuisine id")
+	if err := parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+	response := a.Service.AllCuisines(ctx)
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	dishes := []models.Dish{}
+	names := map[primitive.ObjectID]string{}
+	for _, cuisine := range response.Cuisines {
+		if *cuisineId != "" && cuisine.ID.Hex() != *cuisineId {
+			continue
+		}
+		names[cuisine.ID] = cuisine.Name
+		for _, dish := range cuisine.Dishes {
+			dish.Cuisine = cuisine.ID
+			dishes = append(dishes, dish)
+		}
+	}
+	return a.print(dishes, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tCUISINE\tNAME\tTAGS")
+		for _, dish := range dishes {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", dish.ID.Hex(), names[dish.Cuisine], dish.Name, strings.Join(dish.Tags, ","))
+		}
+	})
+}
+
+func (a *App) addDish(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	tags := flags.String("tags", "", "comma-separated tags")
+	if err := parse(flags, args, 2, 2); err != nil {
+		return err
+	}
+	cuisineId, err := primitive.ObjectIDFromHex(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v is not a valid cuisine id", flags.Arg(0))
+	}
+	response := a.Service.AddDishes(ctx, models.AddDishesRequest{
+		Cuisine: cuisineId,
+		Dishes:  []models.Dish{{Name: flags.Arg(1), Tags: splitList(*tags)}},
+	})
+	if err = check(response.Message); err != nil {
+		return err
+	}
+	return a.printDish(&response.Dishes[0])
+}
+
+func (a *App) renameDish(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	if err := parse(flags, args, 2, 2); err != nil {
+		return err
+	}
+	response := a.Service.RenameDish(ctx, flags.Arg(0), models.RenameRequest{Name: flags.Arg(1)})
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	return a.printDish(response.Dish)
+}
+
+func (a *App) deleteDish(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	if err := parse(flags, args, 1, 1); err != nil {
+		return err
+	}
+	response := a.Service.DeleteDish(ctx, flags.Arg(0))
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	return a.printDish(response.Dish)
+}
+
+func (a *App) printDish(dish *models.Dish) error {
+	return a.print(dish, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tCUISINE\tNAME\tTAGS")
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", dish.ID.Hex(), dish.Cuisine.Hex(), dish.Name, strings.Join(dish.Tags, ","))
+	})
+}
+
+func (a *App) importCatalog(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	if err := parse(flags, args, 1, 1); err != nil {
+		return err
+	}
+	var data []byte
+	var err error
+	if path := flags.Arg(0); path == "-" {
+		data, err = ioutil.ReadAll(a.In)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+	var catalog models.Catalog
+	if err = json.Unmarshal(data, &catalog); err != nil {
+		return fmt.Errorf("%v is not a catalog file: %v", flags.Arg(0), err.Error())
+	}
+
+	response := a.Service.ImportCatalog(ctx, catalog)
+	if err = check(response.Message); err != nil {
+		return err
+	}
+	return a.printImport(response)
+}
+
+func (a *App) seed(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	locale := flags.String("locale", seed.DefaultLocale, "starter catalog to load, e.g. en-US")
+	list := flags.Bool("list", false, "list the bundled starter catalogs without loading one")
+	if err := parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+	if *list {
+		locales := seed.Locales()
+		return a.print(locales, func(w io.Writer) {
+			fmt.Fprintln(w, "LOCALE")
+			for _, l := range locales {
+				fmt.Fprintln(w, l)
+			}
+		})
+	}
+	catalog, err := seed.Catalog(*locale)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUsage, err.Error())
+	}
+
+	response := a.Service.SeedCatalog(ctx, catalog)
+	if err = check(response.Message); err != nil {
+		return err
+	}
+	return a.printImport(response)
+}
+
+func (a *App) printImport(response models.ImportResponse) error {
+	result := struct {
+		Created []string `json:"created"`
+		Updated []string `json:"updated,omitempty"`
+		Skipped []string `json:"skipped"`
+	}{Created: response.Created, Updated: response.Updated, Skipped: response.Skipped}
+	return a.print(result, func(w io.Writer) {
+		fmt.Fprintln(w, "CUISINE\tRESULT")
+		for _, name := range response.Created {
+			fmt.Fprintf(w, "%v\tcreated\n", name)
+		}
+		for _, name := range response.Updated {
+			fmt.Fprintf(w, "%v\tmissing dishes added\n", name)
+		}
+		for _, name := range response.Skipped {
+			fmt.Fprintf(w, "%v\tskipped, already exists\n", name)
+		}
+	})
+}
+
+// exportCatalog always writes JSON, the format import reads.
+func (a *App) exportCatalog(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	if err := parse(flags, args, 0, 1); err != nil {
+		return err
+	}
+	response := a.Service.ExportCatalog(ctx)
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(response.Catalog, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path := flags.Arg(0); path != "" && path != "-" {
+		return ioutil.WriteFile(path, data, 0o644)
+	}
+	_, err = a.Out.Write(data)
+	return err
+}
+
+func (a *App) migrate(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	status := flags.Bool("status", false, "list migrations without applying any")
+	if err := parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+	var response models.MigrationsResponse
+	if *status {
+		response = a.Service.Migrations(ctx)
+	} else {
+		response = a.Service.Migrate(ctx)
+	}
+	// migrations applied before a failure are still reported
+	err := a.print(response.Migrations, func(w io.Writer) {
+		fmt.Fprintln(w, "VERSION\tDESCRIPTION\tAPPLIED")
+		for _, m := range response.Migrations {
+			fmt.Fprintf(w, "%v\t%v\t%v\n", m.Version, m.Description, formatTime(m.AppliedAt))
+		}
+	})
+	if failed := check(response.Message); failed != nil {
+		return failed
+	}
+	return err
+}
+
+func (a *App) listKeys(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	if err := parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+	response := a.Service.AllApiKeys(ctx)
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	return a.print(response.ApiKeys, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tPREFIX\tROLE\tCREATED\tREVOKED")
+		for _, key := range response.ApiKeys {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", key.ID.Hex(), key.Name, key.Prefix, key.Role, formatTime(&key.CreatedAt), formatTime(key.RevokedAt))
+		}
+	})
+}
+
+func (a *App) issueKey(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	if err := parse(flags, args, 2, 2); err != nil {
+		return err
+	}
+	response := a.Service.IssueApiKey(ctx, models.IssueKeyRequest{Name: flags.Arg(0), Role: flags.Arg(1)})
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	result := struct {
+		*models.ApiKey
+		Key string `json:"key"`
+	}{ApiKey: response.ApiKey, Key: response.Key}
+	return a.print(result, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tROLE\tKEY")
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", response.ApiKey.ID.Hex(), response.ApiKey.Name, response.ApiKey.Role, response.Key)
+	})
+}
+
+func (a *App) revokeKey(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	if err := parse(flags, args, 1, 1); err != nil {
+		return err
+	}
+	response := a.Service.RevokeApiKey(ctx, flags.Arg(0))
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	return a.print(response.ApiKey, func(w io.Writer) {
+		fmt.Fprintf(w, "revoked %v\n", response.ApiKey.ID.Hex())
+	})
+}
+
+func (a *App) pick(ctx context.Context, flags *flag.FlagSet, args []string) error {
+	tags := flags.String("tags", "", "comma-separated tags the dish or its cuisine must have")
+	minRating := flags.Float64("min-rating", 0, "lowest average rating of the dish, from 1 to 5")
+	if err := parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+	response := a.Service.RandomPick(ctx, models.PickRequest{Tags: splitList(*tags), MinRating: *minRating})
+	if err := check(response.Message); err != nil {
+		return err
+	}
+	result := struct {
+		Cuisine *models.Cuisine `json:"cuisine"`
+		Dish    *models.Dish    `json:"dish"`
+	}{Cuisine: response.Cuisine, Dish: response.Dish}
+	return a.print(result, func(w io.Writer) {
+		fmt.Fprintln(w, "CUISINE\tDISH\tTAGS")
+		fmt.Fprintf(w, "%v\t%v\t%v\n", response.Cuisine.Name, response.Dish.Name, strings.Join(response.Dish.Tags, ","))
+	})
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,309 @@
+// Package config loads the typed application settings. Values are merged from Defaults, the
+// YAML file, MEAL_PICKER_* environment variables and command-line flags, in that order.
+package config
+
+import (
+	"fmt"
+	"food-roulette-api/internal/seed"
+	"gopkg.in/yaml.v3"
+	"net"
+	"net/url"
+	"os"
+)
+
+// AppConfig holds every setting of the service. Each scalar field can be overridden by an
+// environment variable and a flag named after its YAML path; see Load.
+type AppConfig struct {
+	Env         string            `yaml:"Env"`
+	AppName     string            `yaml:"AppName"`
+	Server      ServerConfig      `yaml:"Server"`
+	Mongo       MongoConfig       `yaml:"Mongo"`
+	Logging     LoggingConfig     `yaml:"Logging"`
+	CORS        CORSConfig        `yaml:"CORS"`
+	Compression CompressionConfig `yaml:"Compression"`
+	Security    SecurityConfig    `yaml:"Security"`
+	Auth        AuthConfig        `yaml:"Auth"`
+	Tracing     TracingConfig     `yaml:"Tracing"`
+	Health      HealthConfig      `yaml:"Health"`
+	Shutdown    ShutdownConfig    `yaml:"Shutdown"`
+	GraphQL     GraphQLConfig     `yaml:"GraphQL" env:"GRAPHQL"`
+	GRPC        GRPCConfig        `yaml:"GRPC"`
+	Limits      LimitsConfig      `yaml:"Limits"`
+	Seed        SeedConfig        `yaml:"Seed"`
+	Events      EventsConfig      `yaml:"Events"`
+	Webhooks    WebhooksConfig    `yaml:"Webhooks"`
+	Chat        ChatConfig        `yaml:"Chat"`
+}
+
+type ServerConfig struct {
+	Port string `yaml:"Port"`
+	// ReadTimeout, WriteTimeout and IdleTimeout are numbers of seconds.
+	ReadTimeout  int       `yaml:"ReadTimeout"`
+	WriteTimeout int       `yaml:"WriteTimeout"`
+	IdleTimeout  int       `yaml:"IdleTimeout"`
+	TLS          TLSConfig `yaml:"TLS"`
+}
+
+type TLSConfig struct {
+	// CertFile and KeyFile are PEM files; setting both serves HTTPS and HTTP/2 on Server.Port.
+	CertFile string `yaml:"CertFile"`
+	KeyFile  string `yaml:"KeyFile"`
+	// ReloadInterval is the number of seconds between checks of the files for changes; zero
+	// reloads them only on SIGHUP.
+	ReloadInterval int `yaml:"ReloadInterval"`
+	// RedirectPort, when set, redirects plain HTTP on that port to HTTPS.
+	RedirectPort string `yaml:"RedirectPort"`
+}
+
+type MongoConfig struct {
+	// Scheme is "mongodb", or "mongodb+srv" for DNS seed lists, which take no Port.
+	Scheme   string `yaml:"Scheme"`
+	Server   string `yaml:"Server"`
+	Port     string `yaml:"Port"`
+	Database string `yaml:"Database"`
+	Username string `yaml:"Username"`
+	Password string `yaml:"Password" secret:"true"`
+	// ConnectTimeout is the number of seconds to wait for the initial connection.
+	ConnectTimeout int `yaml:"ConnectTimeout"`
+}
+
+type LoggingConfig struct {
+	// Level is a logrus level name such as "debug" or "info".
+	Level string `yaml:"Level"`
+	// Format is "json" or "text".
+	Format string `yaml:"Format"`
+}
+
+type CORSConfig struct {
+	// AllowedOrigins lists the origins browsers may call from; "*" allows any.
+	AllowedOrigins []string `yaml:"AllowedOrigins"`
+	AllowedMethods []string `yaml:"AllowedMethods"`
+	AllowedHeaders []string `yaml:"AllowedHeaders"`
+	// ExposedHeaders are the response headers scripts on allowed origins may read.
+	ExposedHeaders   []string `yaml:"ExposedHeaders"`
+	AllowCredentials bool     `yaml:"AllowCredentials"`
+	// MaxAge is the number of seconds browsers may cache a preflight response.
+	MaxAge int `yaml:"MaxAge"`
+}
+
+type CompressionConfig struct {
+	// Level is the gzip level from 1 (fastest) to 9 (smallest); zero disables compression.
+	Level int `yaml:"Level"`
+	// MinSize is the number of bytes below which responses are sent uncompressed.
+	MinSize int `yaml:"MinSize"`
+	// ContentTypes are the media types that are compressed.
+	ContentTypes []string `yaml:"ContentTypes"`
+}
+
+type SecurityConfig struct {
+	// HSTSMaxAge is the number of seconds browsers should only use HTTPS; zero omits the header.
+	HSTSMaxAge            int  `yaml:"HSTSMaxAge"`
+	HSTSIncludeSubdomains bool `yaml:"HSTSIncludeSubdomains"`
+}
+
+type AuthConfig struct {
+	JWKS      JWKSConfig `yaml:"JWKS"`
+	Issuer    string     `yaml:"Issuer"`
+	Audience  string     `yaml:"Audience"`
+	RoleClaim string     `yaml:"RoleClaim"`
+	// RoleMapping is only read from the YAML file.
+	RoleMapping map[string]string `yaml:"RoleMapping"`
+}
+
+type JWKSConfig struct {
+	File string `yaml:"File"`
+	URL  string `yaml:"URL"`
+	// RefreshInterval is the number of seconds between JWKS refreshes when URL is set.
+	RefreshInterval int `yaml:"RefreshInterval"`
+}
+
+type TracingConfig struct {
+	// Exporter is one of "otlp", "stdout" or "none".
+	Exporter    string  `yaml:"Exporter"`
+	Endpoint    string  `yaml:"Endpoint"`
+	Insecure    bool    `yaml:"Insecure"`
+	ServiceName string  `yaml:"ServiceName"`
+	SampleRatio float64 `yaml:"SampleRatio"`
+}
+
+type HealthConfig struct {
+	// ReadinessTimeout is the number of milliseconds each readiness dependency check may take.
+	ReadinessTimeout int `yaml:"ReadinessTimeout"`
+	// DrainDelay is the number of seconds /readyz reports not-ready before the server stops accepting connections.
+	DrainDelay int `yaml:"DrainDelay"`
+}
+
+type ShutdownConfig struct {
+	// DrainTimeout is the number of seconds in-flight requests and calls get to finish once the
+	// listeners close; whatever is still open afterwards is cut off.
+	DrainTimeout int `yaml:"DrainTimeout"`
+	// HookTimeout is the number of seconds each shutdown hook, such as flushing traces, may take.
+	HookTimeout int `yaml:"HookTimeout"`
+}
+
+type GraphQLConfig struct {
+	// MaxComplexity rejects queries scoring above it; see graph.DefaultMaxComplexity.
+	MaxComplexity int `yaml:"MaxComplexity"`
+}
+
+type GRPCConfig struct {
+	// Enabled serves the picker over gRPC next to the HTTP API, over TLS when Server.TLS is
+	// configured and under the same rate limits.
+	Enabled bool `yaml:"Enabled"`
+	// Port is the gRPC listener's port.
+	Port string `yaml:"Port"`
+	// Reflection registers the reflection service, which lists every method to callers without
+	// credentials. Leave it off outside development.
+	Reflection bool `yaml:"Reflection"`
+}
+
+type LimitsConfig struct {
+	// MaxBodyBytes caps request bodies; zero leaves them unlimited.
+	MaxBodyBytes int64 `yaml:"MaxBodyBytes"`
+	// PerKey applies to authenticated callers and PerIP to anonymous ones and to requests whose
+	// credentials were rejected. An IP that has used up PerIP is refused before its credentials
+	// are checked.
+	PerKey RateLimitConfig `yaml:"PerKey"`
+	PerIP  RateLimitConfig `yaml:"PerIP"`
+}
+
+type RateLimitConfig struct {
+	// RequestsPerSecond refills the bucket; zero disables the limit.
+	RequestsPerSecond float64 `yaml:"RequestsPerSecond"`
+	Burst             int     `yaml:"Burst"`
+}
+
+type SeedConfig struct {
+	// OnFirstBoot loads the starter catalog at startup while the shared catalog is still empty.
+	// Run `mealctl migrate` before enabling it: replicas booting together only avoid seeding
+	// the same cuisines twice through the unique cuisine name index, and the server never runs
+	// migrations itself. Seeding is skipped with a warning while any migration is pending.
+	OnFirstBoot bool `yaml:"OnFirstBoot"`
+	// Locale picks the starter catalog, e.g. "en" or "en-US"; see seed.Locales.
+	Locale string `yaml:"Locale"`
+}
+
+type EventsConfig struct {
+	// Enabled publishes an event for every change to cuisines and dishes. Change streams need
+	// Mongo to run as a replica set.
+	Enabled bool `yaml:"Enabled"`
+	// Sinks lists where events are delivered; "log" writes them to the application log.
+	Sinks []string `yaml:"Sinks"`
+	// RetryDelay is the number of seconds before a failed change stream is opened again.
+	RetryDelay int `yaml:"RetryDelay"`
+}
+
+type WebhooksConfig struct {
+	// MaxAttempts counts the first attempt; a delivery still failing after it is given up.
+	MaxAttempts int `yaml:"MaxAttempts"`
+	// RetryBaseDelay, doubled after every failed attempt up to RetryMaxDelay, spaces the
+	// retries. Both, like Timeout and PollInterval, are numbers of seconds.
+	RetryBaseDelay int `yaml:"RetryBaseDelay"`
+	RetryMaxDelay  int `yaml:"RetryMaxDelay"`
+	// Timeout bounds a single attempt.
+	Timeout int `yaml:"Timeout"`
+	// PollInterval is how often an idle dispatcher checks the queue for due deliveries.
+	PollInterval int `yaml:"PollInterval"`
+	// Workers is how many deliveries are sent at once.
+	Workers int `yaml:"Workers"`
+}
+
+type ChatConfig struct {
+	// SigningSecret verifies Slack requests and Token Mattermost ones; the chat endpoints refuse
+	// every request while both are empty.
+	SigningSecret string `yaml:"SigningSecret" secret:"true"`
+	Token         string `yaml:"Token" secret:"true"`
+	// Household is the ID of the household chat commands use; empty uses the default tenant.
+	Household string `yaml:"Household"`
+	// Role is given to every chat user; adding cuisines needs "editor".
+	Role string `yaml:"Role"`
+}
+
+// Defaults are the settings used for anything the file, environment and flags leave unset.
+func Defaults() AppConfig {
+	return AppConfig{
+		Env:     "local",
+		AppName: "food-roulette",
+		Server: ServerConfig{
+			Port:         "6080",
+			ReadTimeout:  15,
+			WriteTimeout: 15,
+			IdleTimeout:  60,
+			TLS:          TLSConfig{ReloadInterval: 60},
+		},
+		Mongo: MongoConfig{
+			Scheme:         "mongodb",
+			Server:         "localhost",
+			Port:           "27017",
+			Database:       "cuisine-database",
+			ConnectTimeout: 10,
+		},
+		Logging: LoggingConfig{Level: "info", Format: "json"},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+			AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-API-Key", "X-Household-ID", "X-Request-ID", "API-Version", "traceparent"},
+			ExposedHeaders: []string{"X-Request-ID", "API-Version", "Deprecation", "Sunset", "Link", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"},
+			MaxAge:         600,
+		},
+		Compression: CompressionConfig{
+			Level:        5,
+			MinSize:      1400,
+			ContentTypes: []string{"application/json", "application/problem+json", "text/html", "text/plain"},
+		},
+		Security: SecurityConfig{HSTSMaxAge: 31536000, HSTSIncludeSubdomains: true},
+		Auth: AuthConfig{
+			JWKS:      JWKSConfig{RefreshInterval: 300},
+			RoleClaim: "roles",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			ServiceName: "food-roulette-api",
+			SampleRatio: 1,
+		},
+		Health:   HealthConfig{ReadinessTimeout: 2000, DrainDelay: 5},
+		Shutdown: ShutdownConfig{DrainTimeout: 20, HookTimeout: 5},
+		GraphQL:  GraphQLConfig{MaxComplexity: 10000},
+		GRPC:     GRPCConfig{Port: "6090"},
+		Limits:   LimitsConfig{MaxBodyBytes: 1 << 20},
+		Seed:     SeedConfig{Locale: seed.DefaultLocale},
+		Events:   EventsConfig{Sinks: []string{"log"}, RetryDelay: 5},
+		Webhooks: WebhooksConfig{MaxAttempts: 8, RetryBaseDelay: 10, RetryMaxDelay: 3600, Timeout: 10, PollInterval: 1, Workers: 4},
+		Chat:     ChatConfig{Role: "editor"},
+	}
+}
+
+func readFile(path string, appConfig *AppConfig) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file %v; %v", path, err.Error())
+	}
+	if err = yaml.Unmarshal(data, appConfig); err != nil {
+		return fmt.Errorf("error decoding config data; err: %v", err.Error())
+	}
+	return nil
+}
+
+// Enabled reports whether bearer token authentication is configured.
+func (c JWKSConfig) Enabled() bool {
+	return c.File != "" || c.URL != ""
+}
+
+// Enabled reports whether HTTPS is configured.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// URI is the connection string for the configured deployment.
+func (c MongoConfig) URI() string {
+	uri := url.URL{Scheme: c.Scheme, Host: c.Server}
+	if c.Scheme != "mongodb+srv" && c.Port != "" {
+		uri.Host = net.JoinHostPort(c.Server, c.Port)
+	}
+	if c.Username != "" {
+		uri.User = url.UserPassword(c.Username, c.Password)
+	}
+	return uri.String()
+}
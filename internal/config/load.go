@@ -0,0 +1,205 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EnvPrefix starts the name of every environment variable read by Load.
+const EnvPrefix = "MEAL_PICKER_"
+
+// Options are the flags that control loading rather than set a value.
+type Options struct {
+	// Path is the YAML file; a missing file is an error only when the path was given explicitly.
+	Path string
+	// PrintConfig asks for the merged, redacted configuration to be printed instead of serving.
+	PrintConfig bool
+}
+
+// field is one overridable scalar setting, addressed by its YAML path.
+type field struct {
+	path   string
+	env    string
+	flag   string
+	secret bool
+	value  reflect.Value
+}
+
+// Load merges Defaults, the YAML file, the environment and args, then validates the result.
+// Every scalar setting can be set with MEAL_PICKER_<SECTION>_<NAME>, e.g. MEAL_PICKER_SERVER_PORT,
+// and with -<section>.<name>, e.g. -server.port. Lists take comma-separated values; maps are
+// only read from the file.
+func Load(args []string, lookupEnv func(string) (string, bool)) (*AppConfig, Options, error) {
+	appConfig := Defaults()
+	options := Options{Path: "config.yaml"}
+
+	flags, fields := newFlagSet(&appConfig, &options)
+	if err := flags.Parse(args); err != nil {
+		return nil, options, fmt.Errorf("invalid command line: %w", err)
+	}
+	explicitPath := false
+	flags.Visit(func(f *flag.Flag) {
+		explicitPath = explicitPath || f.Name == "config"
+	})
+	if path, found := lookupEnv(EnvPrefix + "CONFIG"); found && !explicitPath {
+		options.Path, explicitPath = path, true
+	}
+
+	if err := readFile(options.Path, &appConfig); err != nil {
+		if explicitPath || !isNotExist(options.Path) {
+			return nil, options, err
+		}
+	}
+
+	var problems []string
+	for _, f := range fields {
+		if value, found := lookupEnv(f.env); found {
+			if err := set(f.value, value); err != nil {
+				problems = append(problems, fmt.Sprintf("%v: %v", f.env, err.Error()))
+			}
+		}
+	}
+	flags.Visit(func(flagged *flag.Flag) {
+		for _, f := range fields {
+			if f.flag == flagged.Name {
+				if err := set(f.value, flagged.Value.String()); err != nil {
+					problems = append(problems, fmt.Sprintf("-%v: %v", f.flag, err.Error()))
+				}
+			}
+		}
+	})
+	var invalid ValidationError
+	if errors.As(appConfig.Validate(), &invalid) {
+		problems = append(problems, invalid...)
+	}
+	if len(problems) > 0 {
+		return nil, options, ValidationError(problems)
+	}
+	return &appConfig, options, nil
+}
+
+// Usage lists every flag and the environment variable it mirrors.
+func Usage() string {
+	appConfig := Defaults()
+	flags, _ := newFlagSet(&appConfig, &Options{})
+	var b strings.Builder
+	flags.SetOutput(&b)
+	flags.PrintDefaults()
+	return b.String()
+}
+
+func newFlagSet(appConfig *AppConfig, options *Options) (*flag.FlagSet, []field) {
+	flags := flag.NewFlagSet("svr", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	flags.StringVar(&options.Path, "config", options.Path, "path to the YAML configuration file (env "+EnvPrefix+"CONFIG)")
+	flags.BoolVar(&options.PrintConfig, "print-config", false, "print the merged configuration with secrets redacted and exit")
+	fields := fieldsOf(appConfig)
+	for _, f := range fields {
+		// values are applied from Visit after the file and environment, so no default is set here
+		flags.String(f.flag, "", fmt.Sprintf("%v (env %v)", f.path, f.env))
+	}
+	return flags, fields
+}
+
+// fieldsOf walks the scalar and list settings of appConfig. Names come from the yaml tag, split
+// into words, unless an env tag spells them out.
+func fieldsOf(appConfig *AppConfig) []field {
+	var fields []field
+	var walk func(v reflect.Value, path []string, envNames []string, flagNames []string)
+	walk = func(v reflect.Value, path []string, envNames []string, flagNames []string) {
+		for i := 0; i < v.NumField(); i++ {
+			structField := v.Type().Field(i)
+			name := structField.Tag.Get("yaml")
+			envName := structField.Tag.Get("env")
+			if envName == "" {
+				envName = screamingSnake(name)
+			}
+			fieldPath := append(append([]string{}, path...), name)
+			fieldEnv := append(append([]string{}, envNames...), envName)
+			fieldFlag := append(append([]string{}, flagNames...), strings.ReplaceAll(strings.ToLower(envName), "_", "-"))
+
+			switch structField.Type.Kind() {
+			case reflect.Struct:
+				walk(v.Field(i), fieldPath, fieldEnv, fieldFlag)
+			case reflect.Map:
+				continue
+			default:
+				fields = append(fields, field{
+					path:   strings.Join(fieldPath, "."),
+					env:    EnvPrefix + strings.Join(fieldEnv, "_"),
+					flag:   strings.Join(fieldFlag, "."),
+					secret: structField.Tag.Get("secret") == "true",
+					value:  v.Field(i),
+				})
+			}
+		}
+	}
+	walk(reflect.ValueOf(appConfig).Elem(), nil, nil, nil)
+	return fields
+}
+
+func isNotExist(path string) bool {
+	_, err := os.Stat(path)
+	return os.IsNotExist(err)
+}
+
+func set(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("must be true or false, got %q", value)
+		}
+		v.SetBool(parsed)
+	case reflect.Int, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("must be a whole number, got %q", value)
+		}
+		v.SetInt(parsed)
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("must be a number, got %q", value)
+		}
+		v.SetFloat(parsed)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported setting type %v", v.Kind())
+	}
+	return nil
+}
+
+// screamingSnake turns a YAML name into an environment variable word, e.g. ReadTimeout becomes
+// READ_TIMEOUT and JWKS stays JWKS.
+func screamingSnake(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previousLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])
+			if previousLower || nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
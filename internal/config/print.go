@@ -0,0 +1,28 @@
+package config
+
+import (
+	"gopkg.in/yaml.v3"
+	"io"
+)
+
+const redacted = "REDACTED"
+
+// Redacted returns a copy of c with every secret that is set replaced by a placeholder.
+func (c AppConfig) Redacted() AppConfig {
+	for _, f := range fieldsOf(&c) {
+		if f.secret && f.value.String() != "" {
+			f.value.SetString(redacted)
+		}
+	}
+	return c
+}
+
+// Print writes the redacted configuration as YAML, in the layout of the configuration file.
+func (c AppConfig) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"fmt"
+	"food-roulette-api/internal/seed"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ValidationError lists every invalid setting so they can all be fixed in one go.
+type ValidationError []string
+
+func (e ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e, "\n  - ")
+}
+
+// Validate reports every invalid setting, or nil when the configuration can be served.
+func (c *AppConfig) Validate() error {
+	var problems []string
+	check := func(ok bool, path string, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, path+": "+fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(validPort(c.Server.Port), "Server.Port", "must be a port number between 1 and 65535, got %q", c.Server.Port)
+	check(c.Server.ReadTimeout > 0, "Server.ReadTimeout", "must be a positive number of seconds, got %v", c.Server.ReadTimeout)
+	check(c.Server.WriteTimeout > 0, "Server.WriteTimeout", "must be a positive number of seconds, got %v", c.Server.WriteTimeout)
+	check(c.Server.IdleTimeout >= 0, "Server.IdleTimeout", "must not be negative, got %v", c.Server.IdleTimeout)
+	if tlsConfig := c.Server.TLS; tlsConfig.Enabled() {
+		check(tlsConfig.CertFile != "" && tlsConfig.KeyFile != "", "Server.TLS", "set both CertFile and KeyFile")
+		check(tlsConfig.ReloadInterval >= 0, "Server.TLS.ReloadInterval", "must not be negative, got %v", tlsConfig.ReloadInterval)
+		if tlsConfig.RedirectPort != "" {
+			check(validPort(tlsConfig.RedirectPort), "Server.TLS.RedirectPort", "must be a port number between 1 and 65535, got %q", tlsConfig.RedirectPort)
+			check(tlsConfig.RedirectPort != c.Server.Port, "Server.TLS.RedirectPort", "must differ from Server.Port %v", c.Server.Port)
+		}
+	} else {
+		check(c.Server.TLS.RedirectPort == "", "Server.TLS.RedirectPort", "requires CertFile and KeyFile")
+	}
+
+	check(c.Mongo.Scheme == "mongodb" || c.Mongo.Scheme == "mongodb+srv", "Mongo.Scheme", `must be "mongodb" or "mongodb+srv", got %q`, c.Mongo.Scheme)
+	check(c.Mongo.Server != "", "Mongo.Server", "is required")
+	check(c.Mongo.Scheme == "mongodb+srv" || validPort(c.Mongo.Port), "Mongo.Port", "must be a port number between 1 and 65535, got %q", c.Mongo.Port)
+	check(c.Mongo.Database != "", "Mongo.Database", "is required")
+	check(c.Mongo.Username == "" || c.Mongo.Password != "", "Mongo.Password", "is required when Mongo.Username is set")
+	check(c.Mongo.ConnectTimeout > 0, "Mongo.ConnectTimeout", "must be a positive number of seconds, got %v", c.Mongo.ConnectTimeout)
+
+	_, levelErr := logrus.ParseLevel(c.Logging.Level)
+	check(levelErr == nil, "Logging.Level", "must be one of panic, fatal, error, warn, info, debug or trace, got %q", c.Logging.Level)
+	check(c.Logging.Format == "json" || c.Logging.Format == "text", "Logging.Format", `must be "json" or "text", got %q`, c.Logging.Format)
+
+	check(len(c.CORS.AllowedOrigins) > 0, "CORS.AllowedOrigins", `must list at least one origin, or "*"`)
+	anyOrigin := false
+	for _, origin := range c.CORS.AllowedOrigins {
+		check(validOrigin(origin), "CORS.AllowedOrigins", "%q is not \"*\" or a scheme://host[:port] origin", origin)
+		anyOrigin = anyOrigin || origin == "*"
+	}
+
+	check(len(c.CORS.AllowedMethods) > 0, "CORS.AllowedMethods", "must list at least one method")
+	for _, method := range c.CORS.AllowedMethods {
+		check(method == strings.ToUpper(method) && strings.TrimSpace(method) != "", "CORS.AllowedMethods", "%q is not an upper-case HTTP method", method)
+	}
+	check(!c.CORS.AllowCredentials || !anyOrigin, "CORS.AllowCredentials", `cannot be combined with the "*" origin`)
+	check(c.CORS.MaxAge >= 0, "CORS.MaxAge", "must not be negative, got %v", c.CORS.MaxAge)
+
+	check(c.Compression.Level >= 0 && c.Compression.Level <= 9, "Compression.Level", "must be between 0 and 9, got %v", c.Compression.Level)
+	check(c.Compression.MinSize >= 0, "Compression.MinSize", "must not be negative, got %v", c.Compression.MinSize)
+	check(c.Compression.Level == 0 || len(c.Compression.ContentTypes) > 0, "Compression.ContentTypes", "must list at least one media type when compression is enabled")
+	for _, contentType := range c.Compression.ContentTypes {
+		_, _, err := mime.ParseMediaType(contentType)
+		check(err == nil, "Compression.ContentTypes", "%q is not a media type", contentType)
+	}
+
+	check(c.Security.HSTSMaxAge >= 0, "Security.HSTSMaxAge", "must not be negative, got %v", c.Security.HSTSMaxAge)
+
+	if c.Auth.JWKS.Enabled() {
+		check(c.Auth.JWKS.File == "" || c.Auth.JWKS.URL == "", "Auth.JWKS", "set File or URL, not both")
+		check(c.Auth.JWKS.URL == "" || validURL(c.Auth.JWKS.URL), "Auth.JWKS.URL", "must be an http(s) URL, got %q", c.Auth.JWKS.URL)
+		check(c.Auth.JWKS.URL == "" || c.Auth.JWKS.RefreshInterval > 0, "Auth.JWKS.RefreshInterval", "must be a positive number of seconds, got %v", c.Auth.JWKS.RefreshInterval)
+		check(c.Auth.RoleClaim != "", "Auth.RoleClaim", "is required when JWKS is configured")
+	}
+	for claim, role := range c.Auth.RoleMapping {
+		check(role == "reader" || role == "editor" || role == "admin", "Auth.RoleMapping."+claim, "must be reader, editor or admin, got %q", role)
+	}
+
+	check(c.Tracing.Exporter == "otlp" || c.Tracing.Exporter == "stdout" || c.Tracing.Exporter == "none", "Tracing.Exporter", `must be "otlp", "stdout" or "none", got %q`, c.Tracing.Exporter)
+	check(c.Tracing.Exporter != "otlp" || c.Tracing.Endpoint != "", "Tracing.Endpoint", "is required for the otlp exporter")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "Tracing.SampleRatio", "must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+
+	check(c.Health.ReadinessTimeout > 0, "Health.ReadinessTimeout", "must be a positive number of milliseconds, got %v", c.Health.ReadinessTimeout)
+	check(c.Health.DrainDelay >= 0, "Health.DrainDelay", "must not be negative, got %v", c.Health.DrainDelay)
+
+	check(c.Shutdown.DrainTimeout > 0, "Shutdown.DrainTimeout", "must be a positive number of seconds, got %v", c.Shutdown.DrainTimeout)
+	check(c.Shutdown.HookTimeout > 0, "Shutdown.HookTimeout", "must be a positive number of seconds, got %v", c.Shutdown.HookTimeout)
+
+	check(c.GraphQL.MaxComplexity >= 0, "GraphQL.MaxComplexity", "must not be negative, got %v", c.GraphQL.MaxComplexity)
+
+	if c.GRPC.Enabled {
+		check(validPort(c.GRPC.Port), "GRPC.Port", "must be a port number between 1 and 65535, got %q", c.GRPC.Port)
+		check(c.GRPC.Port != c.Server.Port, "GRPC.Port", "must differ from Server.Port %v", c.Server.Port)
+	}
+
+	check(c.Limits.MaxBodyBytes >= 0, "Limits.MaxBodyBytes", "must not be negative, got %v", c.Limits.MaxBodyBytes)
+	for path, limit := range map[string]RateLimitConfig{"Limits.PerKey": c.Limits.PerKey, "Limits.PerIP": c.Limits.PerIP} {
+		check(limit.RequestsPerSecond >= 0, path+".RequestsPerSecond", "must not be negative, got %v", limit.RequestsPerSecond)
+		check(limit.RequestsPerSecond == 0 || limit.Burst > 0, path+".Burst", "must be positive when RequestsPerSecond is set, got %v", limit.Burst)
+	}
+
+	if c.Seed.OnFirstBoot {
+		_, seedErr := seed.Catalog(c.Seed.Locale)
+		check(seedErr == nil, "Seed.Locale", "must be one of %v, got %q", strings.Join(seed.Locales(), ", "), c.Seed.Locale)
+	}
+
+	if c.Events.Enabled {
+		check(len(c.Events.Sinks) > 0, "Events.Sinks", "must list at least one sink")
+		for _, sink := range c.Events.Sinks {
+			check(sink == "log", "Events.Sinks", `%q is not a sink; the only sink is "log"`, sink)
+		}
+		check(c.Events.RetryDelay > 0, "Events.RetryDelay", "must be a positive number of seconds, got %v", c.Events.RetryDelay)
+	}
+
+	check(c.Webhooks.MaxAttempts > 0, "Webhooks.MaxAttempts", "must be positive, got %v", c.Webhooks.MaxAttempts)
+	check(c.Webhooks.RetryBaseDelay > 0, "Webhooks.RetryBaseDelay", "must be a positive number of seconds, got %v", c.Webhooks.RetryBaseDelay)
+	check(c.Webhooks.RetryMaxDelay >= c.Webhooks.RetryBaseDelay, "Webhooks.RetryMaxDelay", "must not be less than Webhooks.RetryBaseDelay %v, got %v", c.Webhooks.RetryBaseDelay, c.Webhooks.RetryMaxDelay)
+	check(c.Webhooks.Timeout > 0, "Webhooks.Timeout", "must be a positive number of seconds, got %v", c.Webhooks.Timeout)
+	check(c.Webhooks.PollInterval > 0, "Webhooks.PollInterval", "must be a positive number of seconds, got %v", c.Webhooks.PollInterval)
+	check(c.Webhooks.Workers > 0, "Webhooks.Workers", "must be positive, got %v", c.Webhooks.Workers)
+
+	check(c.Chat.Household == "" || primitive.IsValidObjectID(c.Chat.Household), "Chat.Household", "must be a household ID, got %q", c.Chat.Household)
+	check(c.Chat.Role == "reader" || c.Chat.Role == "editor" || c.Chat.Role == "admin", "Chat.Role", "must be reader, editor or admin, got %q", c.Chat.Role)
+
+	if len(problems) > 0 {
+		return ValidationError(problems)
+	}
+	return nil
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+func validOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && (u.Path == "" || u.Path == "/")
+}
+
+func validURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
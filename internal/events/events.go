@@ -0,0 +1,89 @@
+// Package events turns writes to cuisines and dishes into typed domain events and delivers them
+// to sinks, so other services can react to catalog changes.
+package events
+
+import (
+	"encoding/hex"
+	"fmt"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// Type names what happened to which kind of document.
+type Type string
+
+const (
+	CuisineCreated Type = "CuisineCreated"
+	CuisineUpdated Type = "CuisineUpdated"
+	CuisineDeleted Type = "CuisineDeleted"
+	DishCreated    Type = "DishCreated"
+	DishUpdated    Type = "DishUpdated"
+	DishDeleted    Type = "DishDeleted"
+)
+
+// Event is one change to a cuisine or dish.
+type Event struct {
+	// ID is unique per change and the same when a change is delivered again, so sinks can drop
+	// duplicates.
+	ID         string    `json:"id"`
+	Type       Type      `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+	// HouseholdID is zero for the shared catalog and for deletions, whose document is gone.
+	HouseholdID primitive.ObjectID `json:"householdId,omitempty"`
+	CuisineID   primitive.ObjectID `json:"cuisineId,omitempty"`
+	DishID      primitive.ObjectID `json:"dishId,omitempty"`
+	// Cuisine or Dish is the document after the change; both are nil for deletions.
+	Cuisine *models.Cuisine `json:"cuisine,omitempty"`
+	Dish    *models.Dish    `json:"dish,omitempty"`
+}
+
+var types = map[string]map[string]Type{
+	"cuisines": {"insert": CuisineCreated, "update": CuisineUpdated, "replace": CuisineUpdated, "delete": CuisineDeleted},
+	"dishes":   {"insert": DishCreated, "update": DishUpdated, "replace": DishUpdated, "delete": DishDeleted},
+}
+
+// FromChange converts a change to the cuisines or dishes collection. It only depends on the
+// change itself, so any storage that reports its writes as changes produces the same events.
+func FromChange(change mongodb.Change) (Event, error) {
+	eventType, ok := types[change.Collection][change.Operation]
+	if !ok {
+		return Event{}, fmt.Errorf("no event for %v on %v", change.Operation, change.Collection)
+	}
+	event := Event{ID: eventID(change.Token), Type: eventType, OccurredAt: change.Time}
+
+	if change.Collection == "cuisines" {
+		event.CuisineID = change.DocumentID
+		if change.Document != nil {
+			var cuisine models.Cuisine
+			if err := bson.Unmarshal(change.Document, &cuisine); err != nil {
+				return Event{}, fmt.Errorf("cuisine %v: %v", change.DocumentID.Hex(), err.Error())
+			}
+			event.Cuisine = &cuisine
+			event.HouseholdID = cuisine.HouseholdID
+		}
+		return event, nil
+	}
+
+	event.DishID = change.DocumentID
+	if change.Document != nil {
+		var dish models.Dish
+		if err := bson.Unmarshal(change.Document, &dish); err != nil {
+			return Event{}, fmt.Errorf("dish %v: %v", change.DocumentID.Hex(), err.Error())
+		}
+		event.Dish = &dish
+		event.HouseholdID = dish.HouseholdID
+		event.CuisineID = dish.Cuisine
+	}
+	return event, nil
+}
+
+// eventID is the opaque position of the change in the stream.
+func eventID(token bson.Raw) string {
+	if data, ok := token.Lookup("_data").StringValueOK(); ok {
+		return data
+	}
+	return hex.EncodeToString(token)
+}
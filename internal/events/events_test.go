@@ -0,0 +1,85 @@
+package events
+
+import (
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+func TestFromChange(t *testing.T) {
+	cuisineId, dishId, householdId := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	token, _ := bson.Marshal(bson.M{"_data": "8264B7AE9D000000012B"})
+	at := time.Date(2023, 7, 19, 10, 0, 0, 0, time.UTC)
+	document := func(v interface{}) bson.Raw {
+		data, err := bson.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name    string
+		change  mongodb.Change
+		want    Event
+		wantErr string
+	}{
+		{
+			name: "Cuisine inserted",
+			change: mongodb.Change{
+				Token: token, Collection: "cuisines", Operation: "insert", DocumentID: cuisineId, Time: at,
+				Document: document(models.Cuisine{ID: cuisineId, Name: "Thai", HouseholdID: householdId}),
+			},
+			want: Event{
+				ID: "8264B7AE9D000000012B", Type: CuisineCreated, OccurredAt: at, HouseholdID: householdId, CuisineID: cuisineId,
+				Cuisine: &models.Cuisine{ID: cuisineId, Name: "Thai", HouseholdID: householdId},
+			},
+		},
+		{
+			name: "Dish replaced",
+			change: mongodb.Change{
+				Token: token, Collection: "dishes", Operation: "replace", DocumentID: dishId, Time: at,
+				Document: document(models.Dish{ID: dishId, Cuisine: cuisineId, Name: "Pad Thai"}),
+			},
+			want: Event{
+				ID: "8264B7AE9D000000012B", Type: DishUpdated, OccurredAt: at, CuisineID: cuisineId, DishID: dishId,
+				Dish: &models.Dish{ID: dishId, Cuisine: cuisineId, Name: "Pad Thai"},
+			},
+		},
+		{
+			name:   "Dish deleted",
+			change: mongodb.Change{Token: token, Collection: "dishes", Operation: "delete", DocumentID: dishId, Time: at},
+			want:   Event{ID: "8264B7AE9D000000012B", Type: DishDeleted, OccurredAt: at, DishID: dishId},
+		},
+		{
+			name:    "Other collections have no events",
+			change:  mongodb.Change{Token: token, Collection: "ratings", Operation: "insert"},
+			wantErr: "no event for insert on ratings",
+		},
+		{
+			name: "Malformed documents are rejected",
+			change: mongodb.Change{
+				Token: token, Collection: "cuisines", Operation: "update", DocumentID: cuisineId,
+				Document: document(bson.M{"name": 42}),
+			},
+			wantErr: "cuisine " + cuisineId.Hex(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromChange(tt.change)
+			if tt.wantErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package events
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+)
+
+// Sink receives events. Events of one collection arrive in the order they happened, but the
+// collections are watched concurrently, so Publish must be safe for concurrent use. An error
+// stops delivery until the watcher retries; the event and those after it are then delivered
+// again, so Publish should tolerate duplicates.
+type Sink interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(ctx context.Context, event Event) error
+
+func (f SinkFunc) Publish(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// LogSink writes each event to the application log.
+type LogSink struct{}
+
+func (LogSink) Publish(_ context.Context, event Event) error {
+	log.WithFields(log.Fields{
+		"event":     event.Type,
+		"id":        event.ID,
+		"cuisine":   event.CuisineID.Hex(),
+		"dish":      event.DishID.Hex(),
+		"household": event.HouseholdID.Hex(),
+	}).Info("catalog changed")
+	return nil
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"food-roulette-api/internal/services/mongodb"
+	log "github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// Collections are the collections a Watcher follows.
+var Collections = []string{"cuisines", "dishes"}
+
+// DefaultRetryDelay is used when Watcher.RetryDelay is zero.
+const DefaultRetryDelay = 5 * time.Second
+
+// Watcher follows the change streams of Collections and publishes an event for every change to
+// each of Sinks. The resume token of a change is saved once every sink accepted its event, so
+// after a restart or a failed delivery the watcher continues with the first change not yet
+// delivered everywhere.
+type Watcher struct {
+	Changes mongodb.ChangeStreamI
+	Tokens  mongodb.ResumeTokenStoreI
+	Sinks   []Sink
+	// Name prefixes the saved resume tokens, so watchers with different sinks keep their own
+	// positions.
+	Name string
+	// RetryDelay is the pause before a stream that failed is opened again.
+	RetryDelay time.Duration
+}
+
+// Run watches until ctx is done.
+func (w *Watcher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, collection := range Collections {
+		wg.Add(1)
+		go func(collection string) {
+			defer wg.Done()
+			w.watch(ctx, collection)
+		}(collection)
+	}
+	wg.Wait()
+}
+
+func (w *Watcher) watch(ctx context.Context, collection string) {
+	name := w.Name + "." + collection
+	retryDelay := w.RetryDelay
+	if retryDelay == 0 {
+		retryDelay = DefaultRetryDelay
+	}
+	fromNow := false
+	for {
+		token, err := w.Tokens.ResumeToken(ctx, name)
+		if fromNow {
+			token = nil
+		}
+		if err == nil {
+			err = w.Changes.WatchChanges(ctx, collection, token, func(change mongodb.Change) error {
+				fromNow = false
+				return w.deliver(ctx, name, change)
+			})
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case errors.Is(err, mongodb.ErrHistoryLost):
+			log.Errorf("events: %v resume token is no longer in the oplog; changes since are lost, watching from now", name)
+			fromNow = true
+		case err == nil:
+			// the collection was dropped or renamed; its old position cannot be resumed
+			fromNow = true
+		default:
+			log.Errorf("events: watching %v failed, retrying in %v: %v", collection, retryDelay, err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+func (w *Watcher) deliver(ctx context.Context, name string, change mongodb.Change) error {
+	event, err := FromChange(change)
+	if err != nil {
+		// retrying cannot fix the document, so it is skipped rather than blocking the stream
+		log.Errorf("events: skipping change to %v: %v", change.Collection, err.Error())
+		return w.Tokens.SaveResumeToken(ctx, name, change.Token)
+	}
+	for _, sink := range w.Sinks {
+		if err = sink.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return w.Tokens.SaveResumeToken(ctx, name, change.Token)
+}
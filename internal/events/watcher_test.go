@@ -0,0 +1,137 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"food-roulette-api/internal/services/mongodb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatcher_Watch(t *testing.T) {
+	saved := rawToken(t, "saved")
+	changes := []mongodb.Change{
+		{Token: rawToken(t, "first"), Collection: "dishes", Operation: "insert", DocumentID: primitive.NewObjectID()},
+		{Token: rawToken(t, "second"), Collection: "dishes", Operation: "delete", DocumentID: primitive.NewObjectID()},
+	}
+
+	tests := []struct {
+		name string
+		// streams are what each successive WatchChanges call does
+		streams     []func(handle func(mongodb.Change) error) error
+		publishErrs []error
+		// wantResume are the tokens each WatchChanges call resumes after
+		wantResume    []bson.Raw
+		wantPublished []string
+		wantSaved     bson.Raw
+	}{
+		{
+			name: "Resumes after the saved token and saves each delivered change",
+			streams: []func(handle func(mongodb.Change) error) error{
+				replay(changes...),
+			},
+			wantResume:    []bson.Raw{saved},
+			wantPublished: []string{"first", "second"},
+			wantSaved:     changes[1].Token,
+		},
+		{
+			name: "A failed delivery is retried from the last saved change",
+			streams: []func(handle func(mongodb.Change) error) error{
+				replay(changes...),
+				replay(changes[1]),
+			},
+			publishErrs:   []error{nil, errors.New("sink unavailable")},
+			wantResume:    []bson.Raw{saved, changes[0].Token},
+			wantPublished: []string{"first", "second", "second"},
+			wantSaved:     changes[1].Token,
+		},
+		{
+			name: "Watches from now once the history is lost",
+			streams: []func(handle func(mongodb.Change) error) error{
+				func(handle func(mongodb.Change) error) error { return mongodb.ErrHistoryLost },
+				replay(changes[0]),
+			},
+			wantResume:    []bson.Raw{saved, nil},
+			wantPublished: []string{"first"},
+			wantSaved:     changes[0].Token,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var mu sync.Mutex
+			token := saved
+			tokens := mongodb.NewMockResumeTokenStoreI(ctrl)
+			tokens.EXPECT().ResumeToken(gomock.Any(), "events.dishes").DoAndReturn(func(context.Context, string) (bson.Raw, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				return token, nil
+			}).AnyTimes()
+			tokens.EXPECT().SaveResumeToken(gomock.Any(), "events.dishes", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, saved bson.Raw) error {
+				mu.Lock()
+				defer mu.Unlock()
+				token = saved
+				return nil
+			}).AnyTimes()
+
+			var resumed []bson.Raw
+			stream := mongodb.NewMockChangeStreamI(ctrl)
+			stream.EXPECT().WatchChanges(gomock.Any(), "dishes", gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, _ string, resumeAfter bson.Raw, handle func(mongodb.Change) error) error {
+					resumed = append(resumed, resumeAfter)
+					if len(resumed) > len(tt.streams) {
+						// nothing left to replay; wait like an idle stream
+						cancel()
+						<-ctx.Done()
+						return ctx.Err()
+					}
+					return tt.streams[len(resumed)-1](handle)
+				}).MinTimes(len(tt.streams))
+
+			var published []string
+			sink := SinkFunc(func(_ context.Context, event Event) error {
+				published = append(published, event.ID)
+				if n := len(published) - 1; n < len(tt.publishErrs) {
+					return tt.publishErrs[n]
+				}
+				return nil
+			})
+
+			w := &Watcher{Changes: stream, Tokens: tokens, Sinks: []Sink{sink}, Name: "events", RetryDelay: time.Millisecond}
+			w.watch(ctx, "dishes")
+
+			assert.Equal(t, tt.wantResume, resumed[:len(tt.streams)])
+			assert.Equal(t, tt.wantPublished, published)
+			assert.Equal(t, tt.wantSaved, token)
+		})
+	}
+}
+
+func replay(changes ...mongodb.Change) func(handle func(mongodb.Change) error) error {
+	return func(handle func(mongodb.Change) error) error {
+		for _, change := range changes {
+			if err := handle(change); err != nil {
+				return err
+			}
+		}
+		// the stream failing after the last change makes the watcher reopen it
+		return errors.New("connection reset")
+	}
+}
+
+func rawToken(t *testing.T, data string) bson.Raw {
+	token, err := bson.Marshal(bson.M{"_data": data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
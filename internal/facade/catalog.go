@@ -0,0 +1,288 @@
+package facade
+
+import (
+	"context"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func (s *Service) AddDishes(ctx context.Context, request models.AddDishesRequest) (response models.AllDishesResponse) {
+	if err := validation.Struct(request); err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	results, err := s.CatalogService.AddDishesToCuisine(ctx, request)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Insertion error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Dishes = results
+	response.Message.Status = strconv.Itoa(http.StatusCreated)
+	response.Message.Count = len(results)
+
+	return response
+}
+
+func (s *Service) RenameCuisine(ctx context.Context, cuisineId string, request models.RenameRequest) (response models.CuisineResponse) {
+	id, err := parseID(cuisineId, "cuisine")
+	if err == nil {
+		err = validation.Struct(request)
+	}
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	result, err := s.CatalogService.RenameCuisine(ctx, id, request.Name)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Update error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Cuisine = result
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+// DeleteCuisine removes a cuisine with all of its dishes and their ratings.
+func (s *Service) DeleteCuisine(ctx context.Context, cuisineId string) (response models.CuisineResponse) {
+	id, err := parseID(cuisineId, "cuisine")
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	result, err := s.CatalogService.DeleteCuisine(ctx, id)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Delete error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Cuisine = result
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+func (s *Service) RenameDish(ctx context.Context, dishId string, request models.RenameRequest) (response models.DishResponse) {
+	id, err := parseID(dishId, "dish")
+	if err == nil {
+		err = validation.Struct(request)
+	}
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	result, err := s.CatalogService.RenameDish(ctx, id, request.Name)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Update error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Dish = result
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+func (s *Service) DeleteDish(ctx context.Context, dishId string) (response models.DishResponse) {
+	id, err := parseID(dishId, "dish")
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	result, err := s.CatalogService.DeleteDish(ctx, id)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Delete error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Dish = result
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+// ExportCatalog returns every cuisine of the tenant without ids, sorted by name, ready for
+// ImportCatalog.
+func (s *Service) ExportCatalog(ctx context.Context) (response models.CatalogResponse) {
+	results, err := s.MongoService.GetAllCuisines(ctx)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "FindAll error", http.StatusInternalServerError)
+		return response
+	}
+
+	catalog := &models.Catalog{Cuisines: make([]models.AddCuisineRequest, len(results))}
+	for i, cuisine := range results {
+		dishes := make([]models.Dish, len(cuisine.Dishes))
+		for j, dish := range cuisine.Dishes {
+			dishes[j] = models.Dish{Name: dish.Name, Tags: dish.Tags}
+		}
+		catalog.Cuisines[i] = models.AddCuisineRequest{Name: cuisine.Name, Dishes: dishes, Tags: cuisine.Tags}
+	}
+	sort.Slice(catalog.Cuisines, func(i, j int) bool {
+		return strings.ToLower(catalog.Cuisines[i].Name) < strings.ToLower(catalog.Cuisines[j].Name)
+	})
+
+	response.Catalog = catalog
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+	response.Message.Count = len(catalog.Cuisines)
+
+	return response
+}
+
+// ImportCatalog adds the cuisines of catalog that do not exist yet and skips the others. Nothing
+// is written unless the whole catalog is valid.
+func (s *Service) ImportCatalog(ctx context.Context, catalog models.Catalog) (response models.ImportResponse) {
+	if err := validation.Struct(catalog); err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	existing, err := s.MongoService.GetAllCuisines(ctx)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "FindAll error", http.StatusInternalServerError)
+		return response
+	}
+	names := make(map[string]bool, len(existing))
+	for _, cuisine := range existing {
+		names[normalizeName(cuisine.Name)] = true
+	}
+
+	for _, cuisine := range catalog.Cuisines {
+		if names[normalizeName(cuisine.Name)] {
+			response.Skipped = append(response.Skipped, cuisine.Name)
+			continue
+		}
+		added := s.AddCuisine(ctx, cuisine)
+		if added.Message.Status != strconv.Itoa(http.StatusOK) {
+			response.Message = added.Message
+			return response
+		}
+		response.Created = append(response.Created, cuisine.Name)
+	}
+
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+	response.Message.Count = len(response.Created)
+
+	return response
+}
+
+// SeedCatalog upserts catalog by name: missing cuisines are created and existing ones get the
+// dishes they lack. Nothing is renamed, retagged or removed, so seeding twice changes nothing.
+// A cuisine another replica creates while this one seeds is skipped; that relies on the unique
+// cuisine name index from migration 10.
+func (s *Service) SeedCatalog(ctx context.Context, catalog models.Catalog) (response models.ImportResponse) {
+	if err := validation.Struct(catalog); err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	existing, err := s.MongoService.GetAllCuisines(ctx)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "FindAll error", http.StatusInternalServerError)
+		return response
+	}
+	byName := make(map[string]*models.Cuisine, len(existing))
+	for _, cuisine := range existing {
+		byName[normalizeName(cuisine.Name)] = cuisine
+	}
+
+	for _, cuisine := range catalog.Cuisines {
+		current, ok := byName[normalizeName(cuisine.Name)]
+		if !ok {
+			added := s.AddCuisine(ctx, cuisine)
+			if added.Message.Status == strconv.Itoa(http.StatusConflict) {
+				response.Skipped = append(response.Skipped, cuisine.Name)
+				continue
+			}
+			if added.Message.Status != strconv.Itoa(http.StatusOK) {
+				response.Message = added.Message
+				return response
+			}
+			response.Created = append(response.Created, cuisine.Name)
+			continue
+		}
+
+		dishes := make(map[string]bool, len(current.Dishes))
+		for _, dish := range current.Dishes {
+			dishes[normalizeName(dish.Name)] = true
+		}
+		var missing []models.Dish
+		for _, dish := range cuisine.Dishes {
+			if !dishes[normalizeName(dish.Name)] {
+				missing = append(missing, dish)
+			}
+		}
+		if len(missing) == 0 {
+			response.Skipped = append(response.Skipped, current.Name)
+			continue
+		}
+		added := s.AddDishes(ctx, models.AddDishesRequest{Cuisine: current.ID, Dishes: missing})
+		if added.Message.Status != strconv.Itoa(http.StatusCreated) {
+			response.Message = added.Message
+			return response
+		}
+		response.Updated = append(response.Updated, current.Name)
+	}
+
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+	response.Message.Count = len(response.Created) + len(response.Updated)
+
+	return response
+}
+
+// Migrations lists the database migrations and whether each has been applied.
+func (s *Service) Migrations(ctx context.Context) (response models.MigrationsResponse) {
+	results, err := s.Migrator.Migrations(ctx)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Migration error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Migrations = results
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+	response.Message.Count = len(results)
+
+	return response
+}
+
+// Migrate applies the pending database migrations and returns the ones it applied.
+func (s *Service) Migrate(ctx context.Context) (response models.MigrationsResponse) {
+	results, err := s.Migrator.Migrate(ctx)
+	response.Migrations = results
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Migration error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+	response.Message.Count = len(results)
+
+	return response
+}
+
+func parseID(id string, kind string) (primitive.ObjectID, error) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return objectId, apperrors.New(apperrors.ErrValidation, apperrors.CodeInvalidID, fmt.Sprintf("%v is not a valid %v id", id, kind))
+	}
+	return objectId, nil
+}
+
+// normalizeName makes names that differ only in case or surrounding spaces equal.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
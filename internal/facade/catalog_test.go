@@ -0,0 +1,300 @@
+package facade
+
+import (
+	"context"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestService_RenameCuisine(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	tests := []struct {
+		name       string
+		id         string
+		request    models.RenameRequest
+		storeErr   error
+		wantStore  bool
+		wantStatus string
+		wantCode   string
+	}{
+		{
+			name:       "Happy Path",
+			id:         id.Hex(),
+			request:    models.RenameRequest{Name: "Thai Street Food"},
+			wantStore:  true,
+			wantStatus: strconv.Itoa(http.StatusOK),
+		},
+		{
+			name:       "Sad Path: invalid id",
+			id:         "abc",
+			request:    models.RenameRequest{Name: "Thai Street Food"},
+			wantStatus: strconv.Itoa(http.StatusBadRequest),
+			wantCode:   apperrors.CodeInvalidID,
+		},
+		{
+			name:       "Sad Path: invalid name",
+			id:         id.Hex(),
+			request:    models.RenameRequest{Name: "<b>"},
+			wantStatus: strconv.Itoa(http.StatusBadRequest),
+			wantCode:   apperrors.CodeValidation,
+		},
+		{
+			name:       "Sad Path: not found",
+			id:         id.Hex(),
+			request:    models.RenameRequest{Name: "Thai Street Food"},
+			storeErr:   apperrors.New(apperrors.ErrNotFound, apperrors.CodeCuisineNotFound, "cuisine not found"),
+			wantStore:  true,
+			wantStatus: strconv.Itoa(http.StatusNotFound),
+			wantCode:   apperrors.CodeCuisineNotFound,
+		},
+		{
+			name:       "Sad Path: name taken",
+			id:         id.Hex(),
+			request:    models.RenameRequest{Name: "Italian"},
+			storeErr:   apperrors.New(apperrors.ErrConflict, apperrors.CodeCuisineExists, "cuisine Italian already exists"),
+			wantStore:  true,
+			wantStatus: strconv.Itoa(http.StatusConflict),
+			wantCode:   apperrors.CodeCuisineExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockCatalogSvc := mongodb.NewMockCatalogServiceI(ctrl)
+			s := &Service{CatalogService: mockCatalogSvc}
+			if tt.wantStore {
+				var result *models.Cuisine
+				if tt.storeErr == nil {
+					result = &models.Cuisine{ID: id, Name: tt.request.Name}
+				}
+				mockCatalogSvc.EXPECT().RenameCuisine(gomock.Any(), id, tt.request.Name).Return(result, tt.storeErr)
+			}
+
+			got := s.RenameCuisine(context.Background(), tt.id, tt.request)
+			assert.Equal(t, tt.wantStatus, got.Message.Status)
+			if tt.wantCode == "" {
+				assert.Equal(t, tt.request.Name, got.Cuisine.Name)
+				return
+			}
+			assert.Nil(t, got.Cuisine)
+			assert.Equal(t, tt.wantCode, got.Message.ErrorLog[0].Code)
+		})
+	}
+}
+
+func TestService_DeleteDish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCatalogSvc := mongodb.NewMockCatalogServiceI(ctrl)
+	s := &Service{CatalogService: mockCatalogSvc}
+	id := primitive.NewObjectID()
+
+	mockCatalogSvc.EXPECT().DeleteDish(gomock.Any(), id).Return(&models.Dish{ID: id, Name: "Pad Thai"}, nil)
+	got := s.DeleteDish(context.Background(), id.Hex())
+	assert.Equal(t, strconv.Itoa(http.StatusOK), got.Message.Status)
+	assert.Equal(t, "Pad Thai", got.Dish.Name)
+
+	mockCatalogSvc.EXPECT().DeleteDish(gomock.Any(), id).Return(nil, apperrors.New(apperrors.ErrNotFound, apperrors.CodeDishNotFound, "dish not found"))
+	got = s.DeleteDish(context.Background(), id.Hex())
+	assert.Equal(t, strconv.Itoa(http.StatusNotFound), got.Message.Status)
+}
+
+func TestService_AddDishes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCatalogSvc := mongodb.NewMockCatalogServiceI(ctrl)
+	s := &Service{CatalogService: mockCatalogSvc}
+	cuisineId := primitive.NewObjectID()
+
+	invalid := s.AddDishes(context.Background(), models.AddDishesRequest{
+		Cuisine: cuisineId,
+		Dishes:  []models.Dish{{Name: "Pad Thai"}, {Name: "pad thai"}},
+	})
+	assert.Equal(t, strconv.Itoa(http.StatusBadRequest), invalid.Message.Status, "dish names must be unique")
+
+	request := models.AddDishesRequest{Cuisine: cuisineId, Dishes: []models.Dish{{Name: "Pad Thai", Tags: []string{"noodles"}}}}
+	mockCatalogSvc.EXPECT().AddDishesToCuisine(gomock.Any(), request).Return([]models.Dish{{ID: primitive.NewObjectID(), Name: "Pad Thai"}}, nil)
+	got := s.AddDishes(context.Background(), request)
+	assert.Equal(t, strconv.Itoa(http.StatusCreated), got.Message.Status)
+	assert.Equal(t, 1, got.Message.Count)
+}
+
+func TestService_ExportCatalog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMongoSvc := mongodb.NewMockServiceI(ctrl)
+	s := &Service{MongoService: mockMongoSvc}
+
+	mockMongoSvc.EXPECT().GetAllCuisines(gomock.Any()).Return([]*models.Cuisine{
+		{ID: primitive.NewObjectID(), Name: "thai", Tags: []string{"spicy"}, Dishes: []models.Dish{
+			{ID: primitive.NewObjectID(), Cuisine: primitive.NewObjectID(), Name: "Pad Thai", Tags: []string{"noodles"}},
+		}},
+		{ID: primitive.NewObjectID(), Name: "Italian"},
+	}, nil)
+
+	got := s.ExportCatalog(context.Background())
+	assert.Equal(t, strconv.Itoa(http.StatusOK), got.Message.Status)
+	assert.Equal(t, &models.Catalog{Cuisines: []models.AddCuisineRequest{
+		{Name: "Italian", Dishes: []models.Dish{}},
+		{Name: "thai", Tags: []string{"spicy"}, Dishes: []models.Dish{{Name: "Pad Thai", Tags: []string{"noodles"}}}},
+	}}, got.Catalog, "ids are dropped and cuisines sorted by name")
+}
+
+func TestService_ImportCatalog(t *testing.T) {
+	tests := []struct {
+		name        string
+		catalog     models.Catalog
+		existing    []*models.Cuisine
+		insertErr   error
+		wantInserts []string
+		wantCreated []string
+		wantSkipped []string
+		wantStatus  string
+	}{
+		{
+			name: "Happy Path: existing names are skipped",
+			catalog: models.Catalog{Cuisines: []models.AddCuisineRequest{
+				{Name: "Thai", Dishes: []models.Dish{{Name: "Pad Thai"}}},
+				{Name: "Italian"},
+			}},
+			existing:    []*models.Cuisine{{Name: " thai"}},
+			wantInserts: []string{"Italian"},
+			wantCreated: []string{"Italian"},
+			wantSkipped: []string{"Thai"},
+			wantStatus:  strconv.Itoa(http.StatusOK),
+		},
+		{
+			name: "Sad Path: nothing is written for an invalid catalog",
+			catalog: models.Catalog{Cuisines: []models.AddCuisineRequest{
+				{Name: "Italian"},
+				{Name: "italian"},
+			}},
+			wantStatus: strconv.Itoa(http.StatusBadRequest),
+		},
+		{
+			name: "Sad Path: insert error stops the import",
+			catalog: models.Catalog{Cuisines: []models.AddCuisineRequest{
+				{Name: "Italian"},
+				{Name: "Mexican"},
+			}},
+			insertErr:   fmt.Errorf("write concern error"),
+			wantInserts: []string{"Italian"},
+			wantStatus:  strconv.Itoa(http.StatusInternalServerError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockMongoSvc := mongodb.NewMockServiceI(ctrl)
+			s := &Service{MongoService: mockMongoSvc}
+
+			if tt.wantInserts != nil {
+				mockMongoSvc.EXPECT().GetAllCuisines(gomock.Any()).Return(tt.existing, nil)
+			}
+			var inserted []string
+			mockMongoSvc.EXPECT().AddNewCuisine(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, request models.AddCuisineRequest) (*models.Cuisine, error) {
+					inserted = append(inserted, request.Name)
+					return &models.Cuisine{Name: request.Name}, tt.insertErr
+				}).Times(len(tt.wantInserts))
+
+			got := s.ImportCatalog(context.Background(), tt.catalog)
+			assert.Equal(t, tt.wantStatus, got.Message.Status)
+			assert.Equal(t, tt.wantInserts, inserted)
+			assert.Equal(t, tt.wantCreated, got.Created)
+			assert.Equal(t, tt.wantSkipped, got.Skipped)
+		})
+	}
+}
+
+func TestService_SeedCatalog(t *testing.T) {
+	thaiId := primitive.NewObjectID()
+	catalog := models.Catalog{Cuisines: []models.AddCuisineRequest{
+		{Name: "Thai", Dishes: []models.Dish{{Name: "Pad Thai"}, {Name: "Green Curry", Tags: []string{"spicy"}}}},
+		{Name: "Italian", Dishes: []models.Dish{{Name: "Lasagne"}}},
+	}}
+
+	tests := []struct {
+		name        string
+		existing    []*models.Cuisine
+		raced       string
+		wantCuisine []string
+		wantDishes  []models.Dish
+		wantCreated []string
+		wantUpdated []string
+		wantSkipped []string
+	}{
+		{
+			name:        "Empty catalog",
+			wantCuisine: []string{"Thai", "Italian"},
+			wantCreated: []string{"Thai", "Italian"},
+		},
+		{
+			name: "Existing cuisines gain missing dishes",
+			existing: []*models.Cuisine{
+				{ID: thaiId, Name: "thai ", Dishes: []models.Dish{{Name: "PAD THAI"}, {Name: "Khao Soi"}}},
+			},
+			wantCuisine: []string{"Italian"},
+			wantDishes:  []models.Dish{{Name: "Green Curry", Tags: []string{"spicy"}}},
+			wantCreated: []string{"Italian"},
+			wantUpdated: []string{"thai "},
+		},
+		{
+			name: "Seeding again changes nothing",
+			existing: []*models.Cuisine{
+				{ID: thaiId, Name: "Thai", Dishes: []models.Dish{{Name: "Pad Thai"}, {Name: "Green Curry"}}},
+				{ID: primitive.NewObjectID(), Name: "Italian", Dishes: []models.Dish{{Name: "Lasagne"}}},
+			},
+			wantSkipped: []string{"Thai", "Italian"},
+		},
+		{
+			name:        "Cuisine another replica created meanwhile is skipped",
+			raced:       "Thai",
+			wantCuisine: []string{"Thai", "Italian"},
+			wantCreated: []string{"Italian"},
+			wantSkipped: []string{"Thai"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockMongoSvc := mongodb.NewMockServiceI(ctrl)
+			mockCatalogSvc := mongodb.NewMockCatalogServiceI(ctrl)
+			s := &Service{MongoService: mockMongoSvc, CatalogService: mockCatalogSvc}
+
+			mockMongoSvc.EXPECT().GetAllCuisines(gomock.Any()).Return(tt.existing, nil)
+			var inserted []string
+			mockMongoSvc.EXPECT().AddNewCuisine(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, request models.AddCuisineRequest) (*models.Cuisine, error) {
+					inserted = append(inserted, request.Name)
+					if request.Name == tt.raced {
+						return nil, apperrors.New(apperrors.ErrConflict, apperrors.CodeCuisineExists, "cuisine Thai already exists")
+					}
+					return &models.Cuisine{Name: request.Name}, nil
+				}).Times(len(tt.wantCuisine))
+			if tt.wantDishes != nil {
+				mockCatalogSvc.EXPECT().AddDishesToCuisine(gomock.Any(), models.AddDishesRequest{Cuisine: thaiId, Dishes: tt.wantDishes}).
+					Return(tt.wantDishes, nil)
+			}
+
+			got := s.SeedCatalog(context.Background(), catalog)
+			assert.Equal(t, strconv.Itoa(http.StatusOK), got.Message.Status)
+			assert.Equal(t, tt.wantCuisine, inserted)
+			assert.Equal(t, tt.wantCreated, got.Created)
+			assert.Equal(t, tt.wantUpdated, got.Updated)
+			assert.Equal(t, tt.wantSkipped, got.Skipped)
+		})
+	}
+}
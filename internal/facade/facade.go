@@ -2,10 +2,17 @@ package facade
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/config"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/metrics"
 	"food-roulette-api/internal/models"
 	"food-roulette-api/internal/services/mongodb"
-	config "github.com/calebtracey/config-yaml"
+	"food-roulette-api/internal/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"math/rand"
 	"net/http"
 	"strconv"
 )
@@ -14,40 +21,142 @@ import (
 type ServiceI interface {
 	AddCuisine(ctx context.Context, cuisine models.AddCuisineRequest) models.CuisineResponse
 	AllCuisines(ctx context.Context) models.AllCuisinesResponse
+	IssueApiKey(ctx context.Context, request models.IssueKeyRequest) models.ApiKeyResponse
+	AllApiKeys(ctx context.Context) models.AllApiKeysResponse
+	RevokeApiKey(ctx context.Context, id string) models.ApiKeyResponse
+	Authenticate(ctx context.Context, key string) (*auth.Principal, error)
+	CurrentUser(ctx context.Context) models.UserResponse
+	SetPreference(ctx context.Context, request models.SetPreferenceRequest) models.UserResponse
+	RandomPick(ctx context.Context, request models.PickRequest) models.PickResponse
+	CreateHousehold(ctx context.Context, request models.AddHouseholdRequest) models.HouseholdResponse
+	MyHouseholds(ctx context.Context) models.AllHouseholdsResponse
+	InviteMember(ctx context.Context, householdId string, request models.InviteMemberRequest) models.HouseholdResponse
+	RemoveMember(ctx context.Context, householdId string, subject string) models.HouseholdResponse
+	HouseholdRole(ctx context.Context, householdId string) (primitive.ObjectID, auth.Role, error)
+	RateDish(ctx context.Context, dishId string, request models.RateDishRequest) models.RatingResponse
+	DishRatings(ctx context.Context, dishId string) models.AllRatingsResponse
+	RatingsForDishes(ctx context.Context, dishIds []string) models.AllRatingsResponse
+	AddDishes(ctx context.Context, request models.AddDishesRequest) models.AllDishesResponse
+	RenameCuisine(ctx context.Context, cuisineId string, request models.RenameRequest) models.CuisineResponse
+	DeleteCuisine(ctx context.Context, cuisineId string) models.CuisineResponse
+	RenameDish(ctx context.Context, dishId string, request models.RenameRequest) models.DishResponse
+	DeleteDish(ctx context.Context, dishId string) models.DishResponse
+	ExportCatalog(ctx context.Context) models.CatalogResponse
+	ImportCatalog(ctx context.Context, catalog models.Catalog) models.ImportResponse
+	SeedCatalog(ctx context.Context, catalog models.Catalog) models.ImportResponse
+	Migrations(ctx context.Context) models.MigrationsResponse
+	Migrate(ctx context.Context) models.MigrationsResponse
+	AddWebhook(ctx context.Context, request models.AddWebhookRequest) models.WebhookResponse
+	AllWebhooks(ctx context.Context) models.AllWebhooksResponse
+	DeleteWebhook(ctx context.Context, webhookId string) models.WebhookResponse
+	WebhookDeliveries(ctx context.Context, webhookId string) models.AllDeliveriesResponse
+	ReplayDelivery(ctx context.Context, webhookId string, deliveryId string) models.DeliveryResponse
 }
 
 type Service struct {
-	MongoService mongodb.ServiceI
+	MongoService     mongodb.ServiceI
+	KeyService       mongodb.KeyServiceI
+	UserService      mongodb.UserServiceI
+	HouseholdService mongodb.HouseholdServiceI
+	RatingService    mongodb.RatingServiceI
+	CatalogService   mongodb.CatalogServiceI
+	WebhookService   mongodb.WebhookServiceI
+	Migrator         mongodb.MigratorI
+	Pinger           mongodb.PingerI
+	Closer           mongodb.CloserI
+	// ChangeStream, ResumeTokens and DeliveryQueue feed background workers rather than requests.
+	ChangeStream     mongodb.ChangeStreamI
+	ResumeTokens     mongodb.ResumeTokenStoreI
+	DeliveryQueue    mongodb.DeliveryQueueI
+	BootstrapKeyHash string
+	Rand             *rand.Rand
+	Metrics          *metrics.Metrics
 }
 
-func NewService(appConfig *config.Config) (Service, error) {
-	mongoService, err := mongodb.InitializeMongoService(appConfig)
+func NewService(ctx context.Context, mongoConfig config.MongoConfig) (Service, error) {
+	mongoService, err := mongodb.InitializeMongoService(ctx, mongoConfig)
 	if err != nil {
 		return Service{}, err
 	}
 	return Service{
-		MongoService: mongoService,
+		MongoService:     mongoService,
+		KeyService:       mongoService,
+		UserService:      mongoService,
+		HouseholdService: mongoService,
+		RatingService:    mongoService,
+		CatalogService:   mongoService,
+		WebhookService:   mongoService,
+		Migrator:         mongoService,
+		Pinger:           mongoService,
+		Closer:           mongoService,
+		ChangeStream:     mongoService,
+		ResumeTokens:     mongoService,
+		DeliveryQueue:    mongoService,
 	}, nil
 }
 
-func (s *Service) AddCuisine(ctx context.Context, cuisine models.AddCuisineRequest) (response models.CuisineResponse) {
-	var message models.Message
+// Close disconnects from the database; it is a no-op when no connection was opened.
+func (s *Service) Close(ctx context.Context) error {
+	if s.Closer == nil {
+		return nil
+	}
+	return s.Closer.Disconnect(ctx)
+}
 
-	if cuisine.Name == "" {
-		message.ErrorLog = errorLogs([]error{fmt.Errorf("missing params for database insert")}, "Validation error", http.StatusBadRequest)
-		message.Status = strconv.Itoa(http.StatusBadRequest)
-		response.Message = message
+// Instrument wraps every storage service with a decorator that notifies observers around each operation.
+func (s *Service) Instrument(observers ...mongodb.Observer) {
+	instrumented := &mongodb.InstrumentedService{
+		Cuisines:   s.MongoService,
+		Keys:       s.KeyService,
+		Users:      s.UserService,
+		Households: s.HouseholdService,
+		Ratings:    s.RatingService,
+		Catalog:    s.CatalogService,
+		Webhooks:   s.WebhookService,
+		Observers:  observers,
+	}
+	// services left unset stay nil so optional features remain disabled
+	if s.MongoService != nil {
+		s.MongoService = instrumented
+	}
+	if s.KeyService != nil {
+		s.KeyService = instrumented
+	}
+	if s.UserService != nil {
+		s.UserService = instrumented
+	}
+	if s.HouseholdService != nil {
+		s.HouseholdService = instrumented
+	}
+	if s.RatingService != nil {
+		s.RatingService = instrumented
+	}
+	if s.CatalogService != nil {
+		s.CatalogService = instrumented
+	}
+	if s.WebhookService != nil {
+		s.WebhookService = instrumented
+	}
+}
+
+func (s *Service) AddCuisine(ctx context.Context, cuisine models.AddCuisineRequest) (response models.CuisineResponse) {
+	if err := validation.Struct(cuisine); err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
 		return response
 	}
 
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		cuisine.CreatedBy = principal.Subject
+	}
+
 	result, err := s.MongoService.AddNewCuisine(ctx, cuisine)
 	if err != nil {
-		message.ErrorLog = errorLogs([]error{err}, "Insertion error", http.StatusInternalServerError)
-		message.Status = strconv.Itoa(http.StatusInternalServerError)
-		response.Message = message
+		response.Message = errorMessage(ctx, err, "Insertion error", http.StatusInternalServerError)
 		return response
 	}
 
+	s.Metrics.CuisineCreated()
+	s.notify(ctx, models.EventCuisineAdded, result)
 	response.Cuisine = result
 	response.Message.Status = strconv.Itoa(http.StatusOK)
 
@@ -55,13 +164,22 @@ func (s *Service) AddCuisine(ctx context.Context, cuisine models.AddCuisineReque
 }
 
 func (s *Service) AllCuisines(ctx context.Context) (response models.AllCuisinesResponse) {
-	var message models.Message
-
 	results, err := s.MongoService.GetAllCuisines(ctx)
 	if err != nil {
-		message.ErrorLog = errorLogs([]error{err}, "FindAll error", http.StatusInternalServerError)
-		message.Status = strconv.Itoa(http.StatusInternalServerError)
-		response.Message = message
+		response.Message = errorMessage(ctx, err, "FindAll error", http.StatusInternalServerError)
+		return response
+	}
+
+	prefs, err := s.preferences(ctx)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Find error", http.StatusInternalServerError)
+		return response
+	}
+	results = prefs.personalize(results)
+
+	results, err = s.applyRatings(ctx, results)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Rating error", http.StatusInternalServerError)
 		return response
 	}
 	response.Message.Status = strconv.Itoa(http.StatusOK)
@@ -70,14 +188,57 @@ func (s *Service) AllCuisines(ctx context.Context) (response models.AllCuisinesR
 	return response
 }
 
-func errorLogs(errors []error, rootCause string, status int) []models.ErrorLog {
-	var errLogs []models.ErrorLog
-	for _, err := range errors {
-		errLogs = append(errLogs, models.ErrorLog{
+// errorMessage builds the failure message for err. Typed domain and storage errors decide their
+// own status and code; status is used for untyped errors. Only client-safe details are returned,
+// the full error is logged with the request id.
+func errorMessage(ctx context.Context, err error, rootCause string, status int) models.Message {
+	var violations validation.Errors
+	if errors.As(err, &violations) {
+		return violationsMessage(ctx, violations, rootCause)
+	}
+
+	err = mongodb.TranslateError(err)
+	var appErr *apperrors.Error
+	if errors.As(err, &appErr) {
+		status = apperrors.Status(err)
+	}
+
+	logger := logging.FromContext(ctx).WithField("status", status)
+	if status >= http.StatusInternalServerError {
+		logger.Errorf("%v: %v", rootCause, err)
+	} else {
+		logger.Warnf("%v: %v", rootCause, err)
+	}
+
+	code := apperrors.CodeForStatus(status)
+	if appErr != nil && appErr.Code != "" {
+		code = appErr.Code
+	}
+	return models.Message{
+		ErrorLog: []models.ErrorLog{{
 			RootCause: rootCause,
 			Status:    strconv.Itoa(status),
-			Trace:     err.Error(),
+			Code:      code,
+			Trace:     apperrors.Detail(err, status),
+		}},
+		Status: strconv.Itoa(status),
+	}
+}
+
+// violationsMessage reports every validation violation as its own error log entry keyed by field path.
+func violationsMessage(ctx context.Context, violations validation.Errors, rootCause string) models.Message {
+	logging.FromContext(ctx).WithField("status", http.StatusBadRequest).Warnf("%v: %v", rootCause, violations)
+
+	status := strconv.Itoa(http.StatusBadRequest)
+	message := models.Message{Status: status}
+	for _, violation := range violations {
+		message.ErrorLog = append(message.ErrorLog, models.ErrorLog{
+			RootCause: rootCause,
+			Status:    status,
+			Code:      apperrors.CodeValidation,
+			Field:     violation.Field,
+			Trace:     violation.Message,
 		})
 	}
-	return errLogs
+	return message
 }
@@ -3,9 +3,12 @@ package facade
 import (
 	"context"
 	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
 	"food-roulette-api/internal/models"
 	"food-roulette-api/internal/services/mongodb"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"net/http"
 	"reflect"
@@ -61,8 +64,10 @@ func TestService_AddCuisine(t *testing.T) {
 					ErrorLog: []models.ErrorLog{
 						{
 							Status:    strconv.Itoa(http.StatusBadRequest),
+							Code:      "validation_failed",
+							Field:     "name",
 							RootCause: "Validation error",
-							Trace:     "missing params for database insert",
+							Trace:     "is required",
 						},
 					},
 					Status: strconv.Itoa(http.StatusBadRequest),
@@ -83,8 +88,9 @@ func TestService_AddCuisine(t *testing.T) {
 					ErrorLog: []models.ErrorLog{
 						{
 							Status:    strconv.Itoa(http.StatusInternalServerError),
+							Code:      "internal_error",
 							RootCause: "Insertion error",
-							Trace:     "test error",
+							Trace:     "Internal Server Error",
 						},
 					},
 					Status: strconv.Itoa(http.StatusInternalServerError),
@@ -92,6 +98,29 @@ func TestService_AddCuisine(t *testing.T) {
 			},
 			wantError: fmt.Errorf("test error"),
 		},
+		{
+			name:         "Sad Path: duplicate cuisine",
+			MongoService: mockMongoSvc,
+			ctx:          context.Background(),
+			cuisine: models.AddCuisineRequest{
+				Name: "test food",
+			},
+			wantResponse: models.CuisineResponse{
+				Cuisine: nil,
+				Message: models.Message{
+					ErrorLog: []models.ErrorLog{
+						{
+							Status:    strconv.Itoa(http.StatusConflict),
+							Code:      "cuisine_already_exists",
+							RootCause: "Insertion error",
+							Trace:     "cuisine test food already exists",
+						},
+					},
+					Status: strconv.Itoa(http.StatusConflict),
+				},
+			},
+			wantError: apperrors.New(apperrors.ErrConflict, apperrors.CodeCuisineExists, "cuisine test food already exists"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -106,24 +135,63 @@ func TestService_AddCuisine(t *testing.T) {
 	}
 }
 
+func TestService_AddCuisine_ReportsEveryViolation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMongoSvc := mongodb.NewMockServiceI(ctrl)
+	s := &Service{MongoService: mockMongoSvc}
+
+	gotResponse := s.AddCuisine(context.Background(), models.AddCuisineRequest{
+		Name:   "T",
+		Dishes: []models.Dish{{Name: "Pad Thai"}, {Name: "PAD THAI"}},
+		Tags:   []string{"Spicy"},
+	})
+
+	var fields []string
+	for _, errLog := range gotResponse.Message.ErrorLog {
+		fields = append(fields, errLog.Field)
+		assert.Equal(t, "validation_failed", errLog.Code)
+	}
+	assert.Equal(t, strconv.Itoa(http.StatusBadRequest), gotResponse.Message.Status)
+	assert.Equal(t, []string{"name", "dishes", "tags[0]"}, fields)
+}
+
+func TestService_AddCuisine_StampsOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMongoSvc := mongodb.NewMockServiceI(ctrl)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "user-123", Role: auth.RoleEditor})
+	s := &Service{
+		MongoService: mockMongoSvc,
+	}
+
+	mockMongoSvc.EXPECT().AddNewCuisine(ctx, models.AddCuisineRequest{Name: "test food", CreatedBy: "user-123"}).
+		Return(&models.Cuisine{Name: "test food", CreatedBy: "user-123"}, nil)
+
+	gotResponse := s.AddCuisine(ctx, models.AddCuisineRequest{Name: "test food"})
+	if gotResponse.Cuisine == nil || gotResponse.Cuisine.CreatedBy != "user-123" {
+		t.Errorf("AddCuisine() = %v, want cuisine created by user-123", gotResponse)
+	}
+}
+
 func TestService_AllCuisines(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockMongoSvc := mongodb.NewMockServiceI(ctrl)
 	happyCuisines := []*models.Cuisine{
 		{
-			primitive.ObjectID{},
-			"test food one",
-			"cuisine",
-			[]models.Dish{},
-			[]string{},
+			ID:     primitive.ObjectID{},
+			Name:   "test food one",
+			Type:   "cuisine",
+			Dishes: []models.Dish{},
+			Tags:   []string{},
 		},
 		{
-			primitive.ObjectID{},
-			"test food two",
-			"cuisine",
-			[]models.Dish{},
-			[]string{},
+			ID:     primitive.ObjectID{},
+			Name:   "test food two",
+			Type:   "cuisine",
+			Dishes: []models.Dish{},
+			Tags:   []string{},
 		},
 	}
 
@@ -158,8 +226,9 @@ func TestService_AllCuisines(t *testing.T) {
 					ErrorLog: []models.ErrorLog{
 						{
 							Status:    strconv.Itoa(http.StatusInternalServerError),
+							Code:      "internal_error",
 							RootCause: "FindAll error",
-							Trace:     "test error",
+							Trace:     "Internal Server Error",
 						},
 					},
 					Status: strconv.Itoa(http.StatusInternalServerError),
@@ -0,0 +1,172 @@
+package facade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidHousehold = apperrors.New(apperrors.ErrValidation, apperrors.CodeInvalidID, "invalid household id")
+	ErrNotMember        = apperrors.New(apperrors.ErrForbidden, apperrors.CodeNotMember, "caller is not a member of this household")
+)
+
+func (s *Service) CreateHousehold(ctx context.Context, request models.AddHouseholdRequest) (response models.HouseholdResponse) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		response.Message = errorMessage(ctx, fmt.Errorf("no authenticated user"), "Unauthorized", http.StatusUnauthorized)
+		return response
+	}
+	if err := validation.Struct(request); err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	now := time.Now().UTC()
+	result, err := s.HouseholdService.AddHousehold(ctx, models.Household{
+		Name:      request.Name,
+		Members:   []models.Member{{Subject: principal.Subject, Role: string(auth.RoleAdmin), JoinedAt: &now}},
+		CreatedBy: principal.Subject,
+		CreatedAt: &now,
+	})
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Insertion error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Household = result
+	response.Message.Status = strconv.Itoa(http.StatusCreated)
+
+	return response
+}
+
+func (s *Service) MyHouseholds(ctx context.Context) (response models.AllHouseholdsResponse) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		response.Message = errorMessage(ctx, fmt.Errorf("no authenticated user"), "Unauthorized", http.StatusUnauthorized)
+		return response
+	}
+
+	results, err := s.HouseholdService.GetHouseholdsForMember(ctx, principal.Subject)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "FindAll error", http.StatusInternalServerError)
+		return response
+	}
+	response.Households = results
+	response.Message.Count = len(results)
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+// InviteMember adds or updates a member of the household. Only household admins may invite.
+func (s *Service) InviteMember(ctx context.Context, householdId string, request models.InviteMemberRequest) (response models.HouseholdResponse) {
+	if err := validation.Struct(request); err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+	role, _ := auth.ParseRole(request.Role)
+
+	id, principal, status, err := s.requireHouseholdAdmin(ctx, householdId)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Membership error", status)
+		return response
+	}
+
+	now := time.Now().UTC()
+	result, err := s.HouseholdService.SetMember(ctx, id, models.Member{
+		Subject:   request.Subject,
+		Role:      string(role),
+		InvitedBy: principal.Subject,
+		JoinedAt:  &now,
+	})
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Update error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Household = result
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+func (s *Service) RemoveMember(ctx context.Context, householdId string, subject string) (response models.HouseholdResponse) {
+	id, _, status, err := s.requireHouseholdAdmin(ctx, householdId)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Membership error", status)
+		return response
+	}
+
+	result, err := s.HouseholdService.RemoveMember(ctx, id, subject)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Update error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Household = result
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+// HouseholdRole returns the caller's role within the household, which becomes their effective
+// role for tenant scoped requests. Service admins are treated as admins of every household.
+func (s *Service) HouseholdRole(ctx context.Context, householdId string) (primitive.ObjectID, auth.Role, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return primitive.NilObjectID, "", ErrNotMember
+	}
+	id, err := primitive.ObjectIDFromHex(householdId)
+	if err != nil {
+		return primitive.NilObjectID, "", ErrInvalidHousehold
+	}
+
+	household, err := s.HouseholdService.GetHousehold(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return primitive.NilObjectID, "", ErrNotMember
+	}
+	if err != nil {
+		return primitive.NilObjectID, "", err
+	}
+	if principal.Role == auth.RoleAdmin {
+		return id, auth.RoleAdmin, nil
+	}
+
+	for _, member := range household.Members {
+		if member.Subject == principal.Subject {
+			role, roleErr := auth.ParseRole(member.Role)
+			if roleErr != nil {
+				return primitive.NilObjectID, "", roleErr
+			}
+			return id, role, nil
+		}
+	}
+
+	return primitive.NilObjectID, "", ErrNotMember
+}
+
+func (s *Service) requireHouseholdAdmin(ctx context.Context, householdId string) (primitive.ObjectID, *auth.Principal, int, error) {
+	id, role, err := s.HouseholdRole(ctx, householdId)
+	switch {
+	case errors.Is(err, ErrInvalidHousehold):
+		return id, nil, http.StatusBadRequest, err
+	case errors.Is(err, ErrNotMember):
+		return id, nil, http.StatusForbidden, err
+	case err != nil:
+		return id, nil, http.StatusInternalServerError, err
+	case !role.Allows(auth.RoleAdmin):
+		return id, nil, http.StatusForbidden, fmt.Errorf("household admin role is required")
+	}
+	principal, _ := auth.PrincipalFromContext(ctx)
+	return id, principal, http.StatusOK, nil
+}
@@ -0,0 +1,91 @@
+package facade
+
+import (
+	"context"
+	"errors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestService_HouseholdRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockHouseholdSvc := mongodb.NewMockHouseholdServiceI(ctrl)
+	householdId := primitive.NewObjectID()
+	household := &models.Household{
+		ID:      householdId,
+		Members: []models.Member{{Subject: "member", Role: "editor"}},
+	}
+
+	tests := []struct {
+		name        string
+		principal   *auth.Principal
+		householdId string
+		wantRole    auth.Role
+		wantErr     error
+	}{
+		{
+			name:        "Member gets household role",
+			principal:   &auth.Principal{Subject: "member", Role: auth.RoleReader},
+			householdId: householdId.Hex(),
+			wantRole:    auth.RoleEditor,
+		},
+		{
+			name:        "Service admin is household admin",
+			principal:   &auth.Principal{Subject: "ops", Role: auth.RoleAdmin},
+			householdId: householdId.Hex(),
+			wantRole:    auth.RoleAdmin,
+		},
+		{
+			name:        "Outsider is not a member",
+			principal:   &auth.Principal{Subject: "outsider", Role: auth.RoleEditor},
+			householdId: householdId.Hex(),
+			wantErr:     ErrNotMember,
+		},
+		{
+			name:        "Malformed id",
+			principal:   &auth.Principal{Subject: "member", Role: auth.RoleReader},
+			householdId: "not-an-id",
+			wantErr:     ErrInvalidHousehold,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				HouseholdService: mockHouseholdSvc,
+			}
+			ctx := auth.WithPrincipal(context.Background(), tt.principal)
+			mockHouseholdSvc.EXPECT().GetHousehold(ctx, householdId).Return(household, nil).MaxTimes(1)
+
+			_, gotRole, err := s.HouseholdRole(ctx, tt.householdId)
+			assert.True(t, errors.Is(err, tt.wantErr))
+			assert.Equal(t, tt.wantRole, gotRole)
+		})
+	}
+}
+
+func TestService_InviteMember_RequiresHouseholdAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockHouseholdSvc := mongodb.NewMockHouseholdServiceI(ctrl)
+	householdId := primitive.NewObjectID()
+	mockHouseholdSvc.EXPECT().GetHousehold(gomock.Any(), householdId).Return(&models.Household{
+		ID:      householdId,
+		Members: []models.Member{{Subject: "member", Role: "editor"}},
+	}, nil)
+	s := &Service{
+		HouseholdService: mockHouseholdSvc,
+	}
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "member", Role: auth.RoleEditor})
+
+	gotResponse := s.InviteMember(ctx, householdId.Hex(), models.InviteMemberRequest{Subject: "friend", Role: "reader"})
+
+	assert.Equal(t, strconv.Itoa(http.StatusForbidden), gotResponse.Message.Status)
+}
@@ -0,0 +1,126 @@
+package facade
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var ErrInvalidApiKey = errors.New("invalid api key")
+
+func (s *Service) IssueApiKey(ctx context.Context, request models.IssueKeyRequest) (response models.ApiKeyResponse) {
+	if err := validation.Struct(request); err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+	role, _ := auth.ParseRole(request.Role)
+
+	rawKey, err := auth.GenerateKey()
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Key generation error", http.StatusInternalServerError)
+		return response
+	}
+
+	apiKey := models.ApiKey{
+		Name:      request.Name,
+		Prefix:    rawKey[:len(auth.KeyPrefix)+6],
+		Hash:      auth.HashKey(rawKey),
+		Role:      string(role),
+		CreatedAt: time.Now().UTC(),
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		apiKey.CreatedBy = principal.Subject
+	}
+
+	result, err := s.KeyService.AddApiKey(ctx, apiKey)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Insertion error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.ApiKey = result
+	response.Key = rawKey
+	response.Message.Status = strconv.Itoa(http.StatusCreated)
+
+	return response
+}
+
+func (s *Service) AllApiKeys(ctx context.Context) (response models.AllApiKeysResponse) {
+	results, err := s.KeyService.GetAllApiKeys(ctx)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "FindAll error", http.StatusInternalServerError)
+		return response
+	}
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+	response.Message.Count = len(results)
+	response.ApiKeys = results
+
+	return response
+}
+
+func (s *Service) RevokeApiKey(ctx context.Context, id string) (response models.ApiKeyResponse) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		response.Message = errorMessage(ctx, apperrors.New(apperrors.ErrValidation, apperrors.CodeInvalidID, fmt.Sprintf("%v is not a valid api key id", id)), "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	err = s.KeyService.RevokeApiKey(ctx, objectId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		response.Message = errorMessage(ctx, apperrors.New(apperrors.ErrNotFound, apperrors.CodeKeyNotFound, fmt.Sprintf("api key %v not found", id)), "Revoke error", http.StatusNotFound)
+		return response
+	}
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Revoke error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.ApiKey = &models.ApiKey{ID: objectId}
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+// Authenticate resolves a raw API key to the principal it was issued for. The bootstrap key,
+// when configured, always maps to an admin so the first real keys can be issued.
+func (s *Service) Authenticate(ctx context.Context, key string) (*auth.Principal, error) {
+	if key == "" {
+		return nil, ErrInvalidApiKey
+	}
+	hash := auth.HashKey(key)
+
+	if s.BootstrapKeyHash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(s.BootstrapKeyHash)) == 1 {
+		return &auth.Principal{Subject: "bootstrap", Role: auth.RoleAdmin}, nil
+	}
+
+	apiKey, err := s.KeyService.FindApiKeyByHash(ctx, hash)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrInvalidApiKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := auth.ParseRole(apiKey.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// key names are not unique, so the subject is the namespaced id, like chat users', and two
+	// keys never share preferences, ratings or a rate limit bucket
+	return &auth.Principal{
+		Subject: "apikey:" + apiKey.ID.Hex(),
+		Role:    role,
+		KeyID:   apiKey.ID.Hex(),
+	}, nil
+}
@@ -0,0 +1,122 @@
+package facade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestService_IssueApiKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockKeySvc := mongodb.NewMockKeyServiceI(ctrl)
+
+	tests := []struct {
+		name       string
+		request    models.IssueKeyRequest
+		insertErr  error
+		wantStatus string
+		wantKey    bool
+	}{
+		{
+			name:       "Happy Path",
+			request:    models.IssueKeyRequest{Name: "frontend", Role: "editor"},
+			wantStatus: strconv.Itoa(http.StatusCreated),
+			wantKey:    true,
+		},
+		{
+			name:       "Sad Path: invalid role",
+			request:    models.IssueKeyRequest{Name: "frontend", Role: "owner"},
+			wantStatus: strconv.Itoa(http.StatusBadRequest),
+		},
+		{
+			name:       "Sad Path: insert error",
+			request:    models.IssueKeyRequest{Name: "frontend", Role: "reader"},
+			insertErr:  fmt.Errorf("write concern error"),
+			wantStatus: strconv.Itoa(http.StatusInternalServerError),
+		},
+		{
+			name:       "Sad Path: database unavailable",
+			request:    models.IssueKeyRequest{Name: "frontend", Role: "reader"},
+			insertErr:  mongo.ErrClientDisconnected,
+			wantStatus: strconv.Itoa(http.StatusServiceUnavailable),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				KeyService: mockKeySvc,
+			}
+			mockKeySvc.EXPECT().AddApiKey(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, key models.ApiKey) (*models.ApiKey, error) {
+					return &key, tt.insertErr
+				}).MaxTimes(1)
+
+			gotResponse := s.IssueApiKey(context.Background(), tt.request)
+			assert.Equal(t, tt.wantStatus, gotResponse.Message.Status)
+			if tt.wantKey {
+				assert.Equal(t, auth.HashKey(gotResponse.Key), gotResponse.ApiKey.Hash)
+				assert.Equal(t, tt.request.Role, gotResponse.ApiKey.Role)
+			} else {
+				assert.Empty(t, gotResponse.Key)
+			}
+		})
+	}
+}
+
+func TestService_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockKeySvc := mongodb.NewMockKeyServiceI(ctrl)
+	keyId := primitive.NewObjectID()
+
+	tests := []struct {
+		name          string
+		key           string
+		mockKey       *models.ApiKey
+		mockErr       error
+		wantPrincipal *auth.Principal
+		wantErr       error
+	}{
+		{
+			name:          "Happy Path: bootstrap key",
+			key:           "bootstrap-secret",
+			wantPrincipal: &auth.Principal{Subject: "bootstrap", Role: auth.RoleAdmin},
+		},
+		{
+			name:          "Happy Path: stored key",
+			key:           "mp_stored",
+			mockKey:       &models.ApiKey{ID: keyId, Name: "frontend", Role: "reader"},
+			wantPrincipal: &auth.Principal{Subject: "apikey:" + keyId.Hex(), Role: auth.RoleReader, KeyID: keyId.Hex()},
+		},
+		{
+			name:    "Sad Path: unknown key",
+			key:     "mp_unknown",
+			mockErr: mongo.ErrNoDocuments,
+			wantErr: ErrInvalidApiKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				KeyService:       mockKeySvc,
+				BootstrapKeyHash: auth.HashKey("bootstrap-secret"),
+			}
+			mockKeySvc.EXPECT().FindApiKeyByHash(gomock.Any(), auth.HashKey(tt.key)).Return(tt.mockKey, tt.mockErr).MaxTimes(1)
+
+			gotPrincipal, err := s.Authenticate(context.Background(), tt.key)
+			assert.True(t, errors.Is(err, tt.wantErr))
+			assert.Equal(t, tt.wantPrincipal, gotPrincipal)
+		})
+	}
+}
@@ -6,10 +6,12 @@ package facade
 
 import (
 	context "context"
+	auth "food-roulette-api/internal/auth"
 	models "food-roulette-api/internal/models"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MockServiceI is a mock of ServiceI interface.
@@ -49,6 +51,48 @@ func (mr *MockServiceIMockRecorder) AddCuisine(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCuisine", reflect.TypeOf((*MockServiceI)(nil).AddCuisine), arg0, arg1)
 }
 
+// AddDishes mocks base method.
+func (m *MockServiceI) AddDishes(arg0 context.Context, arg1 models.AddDishesRequest) models.AllDishesResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDishes", arg0, arg1)
+	ret0, _ := ret[0].(models.AllDishesResponse)
+	return ret0
+}
+
+// AddDishes indicates an expected call of AddDishes.
+func (mr *MockServiceIMockRecorder) AddDishes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDishes", reflect.TypeOf((*MockServiceI)(nil).AddDishes), arg0, arg1)
+}
+
+// AddWebhook mocks base method.
+func (m *MockServiceI) AddWebhook(arg0 context.Context, arg1 models.AddWebhookRequest) models.WebhookResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhook", arg0, arg1)
+	ret0, _ := ret[0].(models.WebhookResponse)
+	return ret0
+}
+
+// AddWebhook indicates an expected call of AddWebhook.
+func (mr *MockServiceIMockRecorder) AddWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhook", reflect.TypeOf((*MockServiceI)(nil).AddWebhook), arg0, arg1)
+}
+
+// AllApiKeys mocks base method.
+func (m *MockServiceI) AllApiKeys(arg0 context.Context) models.AllApiKeysResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllApiKeys", arg0)
+	ret0, _ := ret[0].(models.AllApiKeysResponse)
+	return ret0
+}
+
+// AllApiKeys indicates an expected call of AllApiKeys.
+func (mr *MockServiceIMockRecorder) AllApiKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllApiKeys", reflect.TypeOf((*MockServiceI)(nil).AllApiKeys), arg0)
+}
+
 // AllCuisines mocks base method.
 func (m *MockServiceI) AllCuisines(arg0 context.Context) models.AllCuisinesResponse {
 	m.ctrl.T.Helper()
@@ -62,3 +106,384 @@ func (mr *MockServiceIMockRecorder) AllCuisines(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllCuisines", reflect.TypeOf((*MockServiceI)(nil).AllCuisines), arg0)
 }
+
+// AllWebhooks mocks base method.
+func (m *MockServiceI) AllWebhooks(arg0 context.Context) models.AllWebhooksResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllWebhooks", arg0)
+	ret0, _ := ret[0].(models.AllWebhooksResponse)
+	return ret0
+}
+
+// AllWebhooks indicates an expected call of AllWebhooks.
+func (mr *MockServiceIMockRecorder) AllWebhooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllWebhooks", reflect.TypeOf((*MockServiceI)(nil).AllWebhooks), arg0)
+}
+
+// Authenticate mocks base method.
+func (m *MockServiceI) Authenticate(arg0 context.Context, arg1 string) (*auth.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", arg0, arg1)
+	ret0, _ := ret[0].(*auth.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockServiceIMockRecorder) Authenticate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockServiceI)(nil).Authenticate), arg0, arg1)
+}
+
+// CreateHousehold mocks base method.
+func (m *MockServiceI) CreateHousehold(arg0 context.Context, arg1 models.AddHouseholdRequest) models.HouseholdResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHousehold", arg0, arg1)
+	ret0, _ := ret[0].(models.HouseholdResponse)
+	return ret0
+}
+
+// CreateHousehold indicates an expected call of CreateHousehold.
+func (mr *MockServiceIMockRecorder) CreateHousehold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHousehold", reflect.TypeOf((*MockServiceI)(nil).CreateHousehold), arg0, arg1)
+}
+
+// CurrentUser mocks base method.
+func (m *MockServiceI) CurrentUser(arg0 context.Context) models.UserResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CurrentUser", arg0)
+	ret0, _ := ret[0].(models.UserResponse)
+	return ret0
+}
+
+// CurrentUser indicates an expected call of CurrentUser.
+func (mr *MockServiceIMockRecorder) CurrentUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentUser", reflect.TypeOf((*MockServiceI)(nil).CurrentUser), arg0)
+}
+
+// DeleteCuisine mocks base method.
+func (m *MockServiceI) DeleteCuisine(arg0 context.Context, arg1 string) models.CuisineResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCuisine", arg0, arg1)
+	ret0, _ := ret[0].(models.CuisineResponse)
+	return ret0
+}
+
+// DeleteCuisine indicates an expected call of DeleteCuisine.
+func (mr *MockServiceIMockRecorder) DeleteCuisine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCuisine", reflect.TypeOf((*MockServiceI)(nil).DeleteCuisine), arg0, arg1)
+}
+
+// DeleteDish mocks base method.
+func (m *MockServiceI) DeleteDish(arg0 context.Context, arg1 string) models.DishResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDish", arg0, arg1)
+	ret0, _ := ret[0].(models.DishResponse)
+	return ret0
+}
+
+// DeleteDish indicates an expected call of DeleteDish.
+func (mr *MockServiceIMockRecorder) DeleteDish(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDish", reflect.TypeOf((*MockServiceI)(nil).DeleteDish), arg0, arg1)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockServiceI) DeleteWebhook(arg0 context.Context, arg1 string) models.WebhookResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1)
+	ret0, _ := ret[0].(models.WebhookResponse)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockServiceIMockRecorder) DeleteWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockServiceI)(nil).DeleteWebhook), arg0, arg1)
+}
+
+// DishRatings mocks base method.
+func (m *MockServiceI) DishRatings(arg0 context.Context, arg1 string) models.AllRatingsResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DishRatings", arg0, arg1)
+	ret0, _ := ret[0].(models.AllRatingsResponse)
+	return ret0
+}
+
+// DishRatings indicates an expected call of DishRatings.
+func (mr *MockServiceIMockRecorder) DishRatings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DishRatings", reflect.TypeOf((*MockServiceI)(nil).DishRatings), arg0, arg1)
+}
+
+// ExportCatalog mocks base method.
+func (m *MockServiceI) ExportCatalog(arg0 context.Context) models.CatalogResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCatalog", arg0)
+	ret0, _ := ret[0].(models.CatalogResponse)
+	return ret0
+}
+
+// ExportCatalog indicates an expected call of ExportCatalog.
+func (mr *MockServiceIMockRecorder) ExportCatalog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCatalog", reflect.TypeOf((*MockServiceI)(nil).ExportCatalog), arg0)
+}
+
+// HouseholdRole mocks base method.
+func (m *MockServiceI) HouseholdRole(arg0 context.Context, arg1 string) (primitive.ObjectID, auth.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HouseholdRole", arg0, arg1)
+	ret0, _ := ret[0].(primitive.ObjectID)
+	ret1, _ := ret[1].(auth.Role)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// HouseholdRole indicates an expected call of HouseholdRole.
+func (mr *MockServiceIMockRecorder) HouseholdRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HouseholdRole", reflect.TypeOf((*MockServiceI)(nil).HouseholdRole), arg0, arg1)
+}
+
+// ImportCatalog mocks base method.
+func (m *MockServiceI) ImportCatalog(arg0 context.Context, arg1 models.Catalog) models.ImportResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCatalog", arg0, arg1)
+	ret0, _ := ret[0].(models.ImportResponse)
+	return ret0
+}
+
+// ImportCatalog indicates an expected call of ImportCatalog.
+func (mr *MockServiceIMockRecorder) ImportCatalog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCatalog", reflect.TypeOf((*MockServiceI)(nil).ImportCatalog), arg0, arg1)
+}
+
+// InviteMember mocks base method.
+func (m *MockServiceI) InviteMember(arg0 context.Context, arg1 string, arg2 models.InviteMemberRequest) models.HouseholdResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.HouseholdResponse)
+	return ret0
+}
+
+// InviteMember indicates an expected call of InviteMember.
+func (mr *MockServiceIMockRecorder) InviteMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteMember", reflect.TypeOf((*MockServiceI)(nil).InviteMember), arg0, arg1, arg2)
+}
+
+// IssueApiKey mocks base method.
+func (m *MockServiceI) IssueApiKey(arg0 context.Context, arg1 models.IssueKeyRequest) models.ApiKeyResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueApiKey", arg0, arg1)
+	ret0, _ := ret[0].(models.ApiKeyResponse)
+	return ret0
+}
+
+// IssueApiKey indicates an expected call of IssueApiKey.
+func (mr *MockServiceIMockRecorder) IssueApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueApiKey", reflect.TypeOf((*MockServiceI)(nil).IssueApiKey), arg0, arg1)
+}
+
+// Migrate mocks base method.
+func (m *MockServiceI) Migrate(arg0 context.Context) models.MigrationsResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migrate", arg0)
+	ret0, _ := ret[0].(models.MigrationsResponse)
+	return ret0
+}
+
+// Migrate indicates an expected call of Migrate.
+func (mr *MockServiceIMockRecorder) Migrate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockServiceI)(nil).Migrate), arg0)
+}
+
+// Migrations mocks base method.
+func (m *MockServiceI) Migrations(arg0 context.Context) models.MigrationsResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migrations", arg0)
+	ret0, _ := ret[0].(models.MigrationsResponse)
+	return ret0
+}
+
+// Migrations indicates an expected call of Migrations.
+func (mr *MockServiceIMockRecorder) Migrations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrations", reflect.TypeOf((*MockServiceI)(nil).Migrations), arg0)
+}
+
+// MyHouseholds mocks base method.
+func (m *MockServiceI) MyHouseholds(arg0 context.Context) models.AllHouseholdsResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MyHouseholds", arg0)
+	ret0, _ := ret[0].(models.AllHouseholdsResponse)
+	return ret0
+}
+
+// MyHouseholds indicates an expected call of MyHouseholds.
+func (mr *MockServiceIMockRecorder) MyHouseholds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MyHouseholds", reflect.TypeOf((*MockServiceI)(nil).MyHouseholds), arg0)
+}
+
+// RandomPick mocks base method.
+func (m *MockServiceI) RandomPick(arg0 context.Context, arg1 models.PickRequest) models.PickResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RandomPick", arg0, arg1)
+	ret0, _ := ret[0].(models.PickResponse)
+	return ret0
+}
+
+// RandomPick indicates an expected call of RandomPick.
+func (mr *MockServiceIMockRecorder) RandomPick(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomPick", reflect.TypeOf((*MockServiceI)(nil).RandomPick), arg0, arg1)
+}
+
+// RateDish mocks base method.
+func (m *MockServiceI) RateDish(arg0 context.Context, arg1 string, arg2 models.RateDishRequest) models.RatingResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateDish", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.RatingResponse)
+	return ret0
+}
+
+// RateDish indicates an expected call of RateDish.
+func (mr *MockServiceIMockRecorder) RateDish(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateDish", reflect.TypeOf((*MockServiceI)(nil).RateDish), arg0, arg1, arg2)
+}
+
+// RatingsForDishes mocks base method.
+func (m *MockServiceI) RatingsForDishes(arg0 context.Context, arg1 []string) models.AllRatingsResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RatingsForDishes", arg0, arg1)
+	ret0, _ := ret[0].(models.AllRatingsResponse)
+	return ret0
+}
+
+// RatingsForDishes indicates an expected call of RatingsForDishes.
+func (mr *MockServiceIMockRecorder) RatingsForDishes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RatingsForDishes", reflect.TypeOf((*MockServiceI)(nil).RatingsForDishes), arg0, arg1)
+}
+
+// RemoveMember mocks base method.
+func (m *MockServiceI) RemoveMember(arg0 context.Context, arg1, arg2 string) models.HouseholdResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.HouseholdResponse)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockServiceIMockRecorder) RemoveMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockServiceI)(nil).RemoveMember), arg0, arg1, arg2)
+}
+
+// RenameCuisine mocks base method.
+func (m *MockServiceI) RenameCuisine(arg0 context.Context, arg1 string, arg2 models.RenameRequest) models.CuisineResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameCuisine", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.CuisineResponse)
+	return ret0
+}
+
+// RenameCuisine indicates an expected call of RenameCuisine.
+func (mr *MockServiceIMockRecorder) RenameCuisine(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameCuisine", reflect.TypeOf((*MockServiceI)(nil).RenameCuisine), arg0, arg1, arg2)
+}
+
+// RenameDish mocks base method.
+func (m *MockServiceI) RenameDish(arg0 context.Context, arg1 string, arg2 models.RenameRequest) models.DishResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameDish", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.DishResponse)
+	return ret0
+}
+
+// RenameDish indicates an expected call of RenameDish.
+func (mr *MockServiceIMockRecorder) RenameDish(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameDish", reflect.TypeOf((*MockServiceI)(nil).RenameDish), arg0, arg1, arg2)
+}
+
+// ReplayDelivery mocks base method.
+func (m *MockServiceI) ReplayDelivery(arg0 context.Context, arg1, arg2 string) models.DeliveryResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.DeliveryResponse)
+	return ret0
+}
+
+// ReplayDelivery indicates an expected call of ReplayDelivery.
+func (mr *MockServiceIMockRecorder) ReplayDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDelivery", reflect.TypeOf((*MockServiceI)(nil).ReplayDelivery), arg0, arg1, arg2)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockServiceI) RevokeApiKey(arg0 context.Context, arg1 string) models.ApiKeyResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeApiKey", arg0, arg1)
+	ret0, _ := ret[0].(models.ApiKeyResponse)
+	return ret0
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockServiceIMockRecorder) RevokeApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockServiceI)(nil).RevokeApiKey), arg0, arg1)
+}
+
+// SeedCatalog mocks base method.
+func (m *MockServiceI) SeedCatalog(arg0 context.Context, arg1 models.Catalog) models.ImportResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeedCatalog", arg0, arg1)
+	ret0, _ := ret[0].(models.ImportResponse)
+	return ret0
+}
+
+// SeedCatalog indicates an expected call of SeedCatalog.
+func (mr *MockServiceIMockRecorder) SeedCatalog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedCatalog", reflect.TypeOf((*MockServiceI)(nil).SeedCatalog), arg0, arg1)
+}
+
+// SetPreference mocks base method.
+func (m *MockServiceI) SetPreference(arg0 context.Context, arg1 models.SetPreferenceRequest) models.UserResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreference", arg0, arg1)
+	ret0, _ := ret[0].(models.UserResponse)
+	return ret0
+}
+
+// SetPreference indicates an expected call of SetPreference.
+func (mr *MockServiceIMockRecorder) SetPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*MockServiceI)(nil).SetPreference), arg0, arg1)
+}
+
+// WebhookDeliveries mocks base method.
+func (m *MockServiceI) WebhookDeliveries(arg0 context.Context, arg1 string) models.AllDeliveriesResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].(models.AllDeliveriesResponse)
+	return ret0
+}
+
+// WebhookDeliveries indicates an expected call of WebhookDeliveries.
+func (mr *MockServiceIMockRecorder) WebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookDeliveries", reflect.TypeOf((*MockServiceI)(nil).WebhookDeliveries), arg0, arg1)
+}
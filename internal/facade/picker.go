@@ -0,0 +1,241 @@
+package facade
+
+import (
+	"context"
+	"fmt"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// favoriteWeight is how many times more likely a favorite is to be picked than a neutral choice.
+	favoriteWeight = 3
+	// unratedScore is the rating weight given to dishes and cuisines nobody has rated yet.
+	unratedScore = 3.0
+)
+
+func (s *Service) RandomPick(ctx context.Context, request models.PickRequest) (response models.PickResponse) {
+	if err := validation.Struct(request); err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	cuisines, err := s.MongoService.GetAllCuisines(ctx)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "FindAll error", http.StatusInternalServerError)
+		return response
+	}
+
+	prefs, err := s.preferences(ctx)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Find error", http.StatusInternalServerError)
+		return response
+	}
+
+	cuisines, err = s.applyRatings(ctx, cuisines)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Rating error", http.StatusInternalServerError)
+		return response
+	}
+
+	candidates := filterByMinRating(filterByTags(prefs.exclude(cuisines), request.Tags), request.MinRating)
+	if len(candidates) == 0 {
+		response.Message = errorMessage(ctx, fmt.Errorf("no cuisines match the pick filter"), "Pick error", http.StatusNotFound)
+		return response
+	}
+
+	cuisine := candidates[s.weightedIndex(len(candidates), func(i int) float64 {
+		return float64(prefs.cuisineWeight(candidates[i].ID)) * ratingWeight(candidates[i].Rating)
+	})]
+	response.Cuisine = cuisine
+
+	if len(cuisine.Dishes) > 0 {
+		dish := cuisine.Dishes[s.weightedIndex(len(cuisine.Dishes), func(i int) float64 {
+			return float64(prefs.dishWeight(cuisine.Dishes[i].ID)) * ratingWeight(cuisine.Dishes[i].Rating)
+		})]
+		response.Dish = &dish
+	}
+	s.Metrics.PickServed(response.Dish != nil)
+	s.notify(ctx, models.EventPickMade, pickMade{Cuisine: response.Cuisine, Dish: response.Dish})
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+func (s *Service) weightedIndex(n int, weight func(i int) float64) int {
+	float := rand.Float64
+	if s.Rand != nil {
+		float = s.Rand.Float64
+	}
+
+	var total float64
+	for i := 0; i < n; i++ {
+		total += weight(i)
+	}
+	target := float() * total
+	for i := 0; i < n; i++ {
+		target -= weight(i)
+		if target < 0 {
+			return i
+		}
+	}
+	return n - 1
+}
+
+// ratingWeight makes well rated choices proportionally more likely; unrated ones count as average.
+func ratingWeight(rating *models.RatingSummary) float64 {
+	if rating == nil || rating.Count == 0 {
+		return unratedScore
+	}
+	return rating.Average
+}
+
+// filterByMinRating keeps only dishes rated at least minRating, and cuisines that still have one.
+func filterByMinRating(cuisines []*models.Cuisine, minRating float64) []*models.Cuisine {
+	if minRating <= 0 {
+		return cuisines
+	}
+	var results []*models.Cuisine
+	for _, cuisine := range cuisines {
+		c := *cuisine
+		c.Dishes = nil
+		for _, dish := range cuisine.Dishes {
+			if dish.Rating != nil && dish.Rating.Average >= minRating {
+				c.Dishes = append(c.Dishes, dish)
+			}
+		}
+		if len(c.Dishes) > 0 {
+			results = append(results, &c)
+		}
+	}
+	return results
+}
+
+func filterByTags(cuisines []*models.Cuisine, tags []string) []*models.Cuisine {
+	if len(tags) == 0 {
+		return cuisines
+	}
+	var results []*models.Cuisine
+	for _, cuisine := range cuisines {
+		if hasAnyTag(cuisine.Tags, tags) {
+			results = append(results, cuisine)
+			continue
+		}
+		for _, dish := range cuisine.Dishes {
+			if hasAnyTag(dish.Tags, tags) {
+				results = append(results, cuisine)
+				break
+			}
+		}
+	}
+	return results
+}
+
+func hasAnyTag(have []string, want []string) bool {
+	for _, h := range have {
+		for _, w := range want {
+			if strings.EqualFold(h, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// preferenceSet indexes a user's favorites and dislikes for personalizing results.
+type preferenceSet struct {
+	favoriteCuisines map[primitive.ObjectID]bool
+	dislikedCuisines map[primitive.ObjectID]bool
+	favoriteDishes   map[primitive.ObjectID]bool
+	dislikedDishes   map[primitive.ObjectID]bool
+}
+
+// preferences loads the caller's preferences; anonymous callers get an empty set.
+func (s *Service) preferences(ctx context.Context) (preferenceSet, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || s.UserService == nil {
+		return preferenceSet{}, nil
+	}
+	user, err := s.userFor(ctx, principal)
+	if err != nil {
+		return preferenceSet{}, err
+	}
+	return preferenceSet{
+		favoriteCuisines: idSet(user.FavoriteCuisines),
+		dislikedCuisines: idSet(user.DislikedCuisines),
+		favoriteDishes:   idSet(user.FavoriteDishes),
+		dislikedDishes:   idSet(user.DislikedDishes),
+	}, nil
+}
+
+func (p preferenceSet) empty() bool {
+	return len(p.favoriteCuisines)+len(p.dislikedCuisines)+len(p.favoriteDishes)+len(p.dislikedDishes) == 0
+}
+
+// exclude drops disliked cuisines and disliked dishes without modifying the input.
+func (p preferenceSet) exclude(cuisines []*models.Cuisine) []*models.Cuisine {
+	if p.empty() {
+		return cuisines
+	}
+	results := make([]*models.Cuisine, 0, len(cuisines))
+	for _, cuisine := range cuisines {
+		if p.dislikedCuisines[cuisine.ID] {
+			continue
+		}
+		c := *cuisine
+		c.Dishes = nil
+		for _, dish := range cuisine.Dishes {
+			if !p.dislikedDishes[dish.ID] {
+				c.Dishes = append(c.Dishes, dish)
+			}
+		}
+		results = append(results, &c)
+	}
+	return results
+}
+
+// personalize excludes dislikes and moves favorites to the front, keeping the stored order otherwise.
+func (p preferenceSet) personalize(cuisines []*models.Cuisine) []*models.Cuisine {
+	if p.empty() {
+		return cuisines
+	}
+	results := p.exclude(cuisines)
+	for _, cuisine := range results {
+		sort.SliceStable(cuisine.Dishes, func(i, j int) bool {
+			return p.favoriteDishes[cuisine.Dishes[i].ID] && !p.favoriteDishes[cuisine.Dishes[j].ID]
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return p.cuisineWeight(results[i].ID) > p.cuisineWeight(results[j].ID)
+	})
+	return results
+}
+
+func (p preferenceSet) cuisineWeight(id primitive.ObjectID) int {
+	if p.favoriteCuisines[id] {
+		return favoriteWeight
+	}
+	return 1
+}
+
+func (p preferenceSet) dishWeight(id primitive.ObjectID) int {
+	if p.favoriteDishes[id] {
+		return favoriteWeight
+	}
+	return 1
+}
+
+func idSet(ids []primitive.ObjectID) map[primitive.ObjectID]bool {
+	set := make(map[primitive.ObjectID]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
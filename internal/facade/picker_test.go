@@ -0,0 +1,99 @@
+package facade
+
+import (
+	"context"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestService_RandomPick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMongoSvc := mongodb.NewMockServiceI(ctrl)
+	mockUserSvc := mongodb.NewMockUserServiceI(ctrl)
+	thai := &models.Cuisine{ID: primitive.NewObjectID(), Name: "thai", Tags: []string{"spicy"}}
+	padThai := models.Dish{ID: primitive.NewObjectID(), Name: "pad thai"}
+	greenCurry := models.Dish{ID: primitive.NewObjectID(), Name: "green curry"}
+	thai.Dishes = []models.Dish{padThai, greenCurry}
+	italian := &models.Cuisine{ID: primitive.NewObjectID(), Name: "italian", Tags: []string{"pasta"}}
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "user-123", Role: auth.RoleReader})
+
+	tests := []struct {
+		name        string
+		request     models.PickRequest
+		user        *models.User
+		userErr     error
+		wantStatus  string
+		wantCuisine string
+		wantDish    string
+	}{
+		{
+			name:        "Happy Path: dislikes are excluded",
+			user:        &models.User{DislikedCuisines: []primitive.ObjectID{italian.ID}, DislikedDishes: []primitive.ObjectID{padThai.ID}},
+			wantStatus:  strconv.Itoa(http.StatusOK),
+			wantCuisine: "thai",
+			wantDish:    "green curry",
+		},
+		{
+			name:        "Happy Path: tag filter without stored preferences",
+			request:     models.PickRequest{Tags: []string{"PASTA"}},
+			userErr:     mongo.ErrNoDocuments,
+			wantStatus:  strconv.Itoa(http.StatusOK),
+			wantCuisine: "italian",
+		},
+		{
+			name:       "Sad Path: nothing left to pick",
+			request:    models.PickRequest{Tags: []string{"pasta"}},
+			user:       &models.User{DislikedCuisines: []primitive.ObjectID{italian.ID}},
+			wantStatus: strconv.Itoa(http.StatusNotFound),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				MongoService: mockMongoSvc,
+				UserService:  mockUserSvc,
+				Rand:         rand.New(rand.NewSource(1)),
+			}
+			mockMongoSvc.EXPECT().GetAllCuisines(ctx).Return([]*models.Cuisine{thai, italian}, nil)
+			mockUserSvc.EXPECT().GetUser(ctx, "user-123").Return(tt.user, tt.userErr)
+
+			gotResponse := s.RandomPick(ctx, tt.request)
+			assert.Equal(t, tt.wantStatus, gotResponse.Message.Status)
+			if tt.wantCuisine != "" {
+				assert.Equal(t, tt.wantCuisine, gotResponse.Cuisine.Name)
+			}
+			if tt.wantDish != "" {
+				assert.Equal(t, tt.wantDish, gotResponse.Dish.Name)
+			}
+		})
+	}
+}
+
+func TestPreferenceSet_Personalize(t *testing.T) {
+	first := &models.Cuisine{ID: primitive.NewObjectID(), Name: "first"}
+	second := &models.Cuisine{ID: primitive.NewObjectID(), Name: "second"}
+	disliked := &models.Cuisine{ID: primitive.NewObjectID(), Name: "disliked"}
+	favorite := &models.Cuisine{ID: primitive.NewObjectID(), Name: "favorite"}
+	prefs := preferenceSet{
+		favoriteCuisines: idSet([]primitive.ObjectID{favorite.ID}),
+		dislikedCuisines: idSet([]primitive.ObjectID{disliked.ID}),
+	}
+
+	got := prefs.personalize([]*models.Cuisine{first, disliked, second, favorite})
+
+	var names []string
+	for _, cuisine := range got {
+		names = append(names, cuisine.Name)
+	}
+	assert.Equal(t, []string{"favorite", "first", "second"}, names)
+}
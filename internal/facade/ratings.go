@@ -0,0 +1,134 @@
+package facade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"net/http"
+	"strconv"
+)
+
+func (s *Service) RateDish(ctx context.Context, dishId string, request models.RateDishRequest) (response models.RatingResponse) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		response.Message = errorMessage(ctx, fmt.Errorf("no authenticated user"), "Unauthorized", http.StatusUnauthorized)
+		return response
+	}
+
+	id, err := primitive.ObjectIDFromHex(dishId)
+	if err != nil {
+		response.Message = errorMessage(ctx, apperrors.New(apperrors.ErrValidation, apperrors.CodeInvalidID, fmt.Sprintf("%v is not a valid dish id", dishId)), "Validation error", http.StatusBadRequest)
+		return response
+	}
+	if err = validation.Struct(request); err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	result, err := s.RatingService.SetRating(ctx, models.Rating{
+		Dish:    id,
+		Subject: principal.Subject,
+		Score:   request.Score,
+		Note:    request.Note,
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		response.Message = errorMessage(ctx, apperrors.New(apperrors.ErrNotFound, apperrors.CodeDishNotFound, fmt.Sprintf("dish %v not found", dishId)), "Rating error", http.StatusNotFound)
+		return response
+	}
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Rating error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Rating = result
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+func (s *Service) DishRatings(ctx context.Context, dishId string) (response models.AllRatingsResponse) {
+	id, err := primitive.ObjectIDFromHex(dishId)
+	if err != nil {
+		response.Message = errorMessage(ctx, apperrors.New(apperrors.ErrValidation, apperrors.CodeInvalidID, fmt.Sprintf("%v is not a valid dish id", dishId)), "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	results, err := s.RatingService.GetRatingsForDish(ctx, id)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "FindAll error", http.StatusInternalServerError)
+		return response
+	}
+	response.Ratings = results
+	response.Message.Count = len(results)
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+// RatingsForDishes returns the ratings of several dishes with a single storage query.
+func (s *Service) RatingsForDishes(ctx context.Context, dishIds []string) (response models.AllRatingsResponse) {
+	ids := make([]primitive.ObjectID, len(dishIds))
+	for i, dishId := range dishIds {
+		id, err := primitive.ObjectIDFromHex(dishId)
+		if err != nil {
+			response.Message = errorMessage(ctx, apperrors.New(apperrors.ErrValidation, apperrors.CodeInvalidID, fmt.Sprintf("%v is not a valid dish id", dishId)), "Validation error", http.StatusBadRequest)
+			return response
+		}
+		ids[i] = id
+	}
+
+	results, err := s.RatingService.GetRatingsForDishes(ctx, ids)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "FindAll error", http.StatusInternalServerError)
+		return response
+	}
+	response.Ratings = results
+	response.Message.Count = len(results)
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+// applyRatings returns copies of cuisines with the aggregate rating of each dish and, weighted by
+// rating count, of each cuisine.
+func (s *Service) applyRatings(ctx context.Context, cuisines []*models.Cuisine) ([]*models.Cuisine, error) {
+	if s.RatingService == nil || len(cuisines) == 0 {
+		return cuisines, nil
+	}
+	summaries, err := s.RatingService.GetRatingSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byDish := make(map[primitive.ObjectID]models.RatingSummary, len(summaries))
+	for _, summary := range summaries {
+		byDish[summary.Dish] = summary
+	}
+
+	results := make([]*models.Cuisine, len(cuisines))
+	for i, cuisine := range cuisines {
+		c := *cuisine
+		c.Dishes = make([]models.Dish, len(cuisine.Dishes))
+		var total float64
+		var count int
+		for j, dish := range cuisine.Dishes {
+			if summary, ok := byDish[dish.ID]; ok {
+				dish.Rating = &models.RatingSummary{Average: summary.Average, Count: summary.Count}
+				total += summary.Average * float64(summary.Count)
+				count += summary.Count
+			}
+			c.Dishes[j] = dish
+		}
+		if count > 0 {
+			c.Rating = &models.RatingSummary{Average: total / float64(count), Count: count}
+		}
+		results[i] = &c
+	}
+
+	return results, nil
+}
@@ -0,0 +1,143 @@
+package facade
+
+import (
+	"context"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestService_RateDish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRatingSvc := mongodb.NewMockRatingServiceI(ctrl)
+	dishId := primitive.NewObjectID()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "user-123", Role: auth.RoleReader})
+
+	tests := []struct {
+		name       string
+		dishId     string
+		request    models.RateDishRequest
+		mockErr    error
+		wantStatus string
+	}{
+		{
+			name:       "Happy Path",
+			dishId:     dishId.Hex(),
+			request:    models.RateDishRequest{Score: 4, Note: "great"},
+			wantStatus: strconv.Itoa(http.StatusOK),
+		},
+		{
+			name:       "Sad Path: score out of range",
+			dishId:     dishId.Hex(),
+			request:    models.RateDishRequest{Score: 6},
+			wantStatus: strconv.Itoa(http.StatusBadRequest),
+		},
+		{
+			name:       "Sad Path: unknown dish",
+			dishId:     dishId.Hex(),
+			request:    models.RateDishRequest{Score: 2},
+			mockErr:    mongo.ErrNoDocuments,
+			wantStatus: strconv.Itoa(http.StatusNotFound),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				RatingService: mockRatingSvc,
+			}
+			want := models.Rating{Dish: dishId, Subject: "user-123", Score: tt.request.Score, Note: tt.request.Note}
+			mockRatingSvc.EXPECT().SetRating(ctx, want).Return(&want, tt.mockErr).MaxTimes(1)
+
+			gotResponse := s.RateDish(ctx, tt.dishId, tt.request)
+			assert.Equal(t, tt.wantStatus, gotResponse.Message.Status)
+		})
+	}
+}
+
+func TestService_RatingsForDishes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRatingSvc := mongodb.NewMockRatingServiceI(ctrl)
+	ramen, udon := primitive.NewObjectID(), primitive.NewObjectID()
+	s := &Service{
+		RatingService: mockRatingSvc,
+	}
+
+	mockRatingSvc.EXPECT().GetRatingsForDishes(gomock.Any(), []primitive.ObjectID{ramen, udon}).Return([]*models.Rating{
+		{Dish: ramen, Subject: "user-123", Score: 5},
+		{Dish: udon, Subject: "user-123", Score: 2},
+	}, nil)
+
+	gotResponse := s.RatingsForDishes(context.Background(), []string{ramen.Hex(), udon.Hex()})
+	assert.Equal(t, strconv.Itoa(http.StatusOK), gotResponse.Message.Status)
+	assert.Equal(t, 2, gotResponse.Message.Count)
+
+	gotResponse = s.RatingsForDishes(context.Background(), []string{ramen.Hex(), "ramen"})
+	assert.Equal(t, strconv.Itoa(http.StatusBadRequest), gotResponse.Message.Status)
+	assert.Equal(t, "invalid_object_id", gotResponse.Message.ErrorLog[0].Code)
+}
+
+func TestService_AllCuisines_Ratings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMongoSvc := mongodb.NewMockServiceI(ctrl)
+	mockRatingSvc := mongodb.NewMockRatingServiceI(ctrl)
+	ramen := models.Dish{ID: primitive.NewObjectID(), Name: "ramen"}
+	udon := models.Dish{ID: primitive.NewObjectID(), Name: "udon"}
+	gyoza := models.Dish{ID: primitive.NewObjectID(), Name: "gyoza"}
+	japanese := &models.Cuisine{ID: primitive.NewObjectID(), Name: "japanese", Dishes: []models.Dish{ramen, udon, gyoza}}
+	s := &Service{
+		MongoService:  mockMongoSvc,
+		RatingService: mockRatingSvc,
+	}
+
+	mockMongoSvc.EXPECT().GetAllCuisines(gomock.Any()).Return([]*models.Cuisine{japanese}, nil)
+	mockRatingSvc.EXPECT().GetRatingSummaries(gomock.Any()).Return([]models.RatingSummary{
+		{Dish: ramen.ID, Average: 5, Count: 3},
+		{Dish: udon.ID, Average: 1, Count: 1},
+	}, nil)
+
+	gotResponse := s.AllCuisines(context.Background())
+
+	got := gotResponse.Cuisines[0]
+	assert.Equal(t, &models.RatingSummary{Average: 4, Count: 4}, got.Rating)
+	assert.Equal(t, &models.RatingSummary{Average: 5, Count: 3}, got.Dishes[0].Rating)
+	assert.Nil(t, got.Dishes[2].Rating)
+	assert.Nil(t, japanese.Rating, "stored cuisines must not be modified")
+}
+
+func TestService_RandomPick_MinRating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMongoSvc := mongodb.NewMockServiceI(ctrl)
+	mockRatingSvc := mongodb.NewMockRatingServiceI(ctrl)
+	loved := models.Dish{ID: primitive.NewObjectID(), Name: "loved"}
+	meh := models.Dish{ID: primitive.NewObjectID(), Name: "meh"}
+	unrated := models.Dish{ID: primitive.NewObjectID(), Name: "unrated"}
+	cuisine := &models.Cuisine{ID: primitive.NewObjectID(), Name: "mixed", Dishes: []models.Dish{loved, meh, unrated}}
+	s := &Service{
+		MongoService:  mockMongoSvc,
+		RatingService: mockRatingSvc,
+		Rand:          rand.New(rand.NewSource(7)),
+	}
+
+	mockMongoSvc.EXPECT().GetAllCuisines(gomock.Any()).Return([]*models.Cuisine{cuisine}, nil).Times(5)
+	mockRatingSvc.EXPECT().GetRatingSummaries(gomock.Any()).Return([]models.RatingSummary{
+		{Dish: loved.ID, Average: 4.5, Count: 2},
+		{Dish: meh.ID, Average: 2, Count: 4},
+	}, nil).Times(5)
+
+	for i := 0; i < 5; i++ {
+		gotResponse := s.RandomPick(context.Background(), models.PickRequest{MinRating: 4})
+		assert.Equal(t, "loved", gotResponse.Dish.Name)
+	}
+}
@@ -0,0 +1,268 @@
+package facade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/tracing"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"strconv"
+)
+
+// TracedService decorates a ServiceI with a span per facade call.
+type TracedService struct {
+	Next ServiceI
+}
+
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "facade."+name)
+}
+
+// endWithMessage ends span, marking it failed when the response carries a server error status.
+func endWithMessage(span trace.Span, message models.Message) {
+	status, _ := strconv.Atoi(message.Status)
+	span.SetAttributes(attribute.Int("response.status", status))
+	var err error
+	if status >= http.StatusInternalServerError && len(message.ErrorLog) > 0 {
+		err = fmt.Errorf("%v: %v", message.ErrorLog[0].RootCause, message.ErrorLog[0].Trace)
+	}
+	tracing.End(span, err)
+}
+
+func (s *TracedService) AddCuisine(ctx context.Context, cuisine models.AddCuisineRequest) models.CuisineResponse {
+	ctx, span := startSpan(ctx, "AddCuisine")
+	response := s.Next.AddCuisine(ctx, cuisine)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) AllCuisines(ctx context.Context) models.AllCuisinesResponse {
+	ctx, span := startSpan(ctx, "AllCuisines")
+	response := s.Next.AllCuisines(ctx)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) IssueApiKey(ctx context.Context, request models.IssueKeyRequest) models.ApiKeyResponse {
+	ctx, span := startSpan(ctx, "IssueApiKey")
+	response := s.Next.IssueApiKey(ctx, request)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) AllApiKeys(ctx context.Context) models.AllApiKeysResponse {
+	ctx, span := startSpan(ctx, "AllApiKeys")
+	response := s.Next.AllApiKeys(ctx)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) RevokeApiKey(ctx context.Context, id string) models.ApiKeyResponse {
+	ctx, span := startSpan(ctx, "RevokeApiKey")
+	response := s.Next.RevokeApiKey(ctx, id)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) Authenticate(ctx context.Context, key string) (*auth.Principal, error) {
+	ctx, span := startSpan(ctx, "Authenticate")
+	principal, err := s.Next.Authenticate(ctx, key)
+	if errors.Is(err, ErrInvalidApiKey) {
+		span.SetAttributes(attribute.Bool("auth.rejected", true))
+		tracing.End(span, nil)
+	} else {
+		tracing.End(span, err)
+	}
+	return principal, err
+}
+
+func (s *TracedService) CurrentUser(ctx context.Context) models.UserResponse {
+	ctx, span := startSpan(ctx, "CurrentUser")
+	response := s.Next.CurrentUser(ctx)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) SetPreference(ctx context.Context, request models.SetPreferenceRequest) models.UserResponse {
+	ctx, span := startSpan(ctx, "SetPreference")
+	response := s.Next.SetPreference(ctx, request)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) RandomPick(ctx context.Context, request models.PickRequest) models.PickResponse {
+	ctx, span := startSpan(ctx, "RandomPick")
+	response := s.Next.RandomPick(ctx, request)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) CreateHousehold(ctx context.Context, request models.AddHouseholdRequest) models.HouseholdResponse {
+	ctx, span := startSpan(ctx, "CreateHousehold")
+	response := s.Next.CreateHousehold(ctx, request)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) MyHouseholds(ctx context.Context) models.AllHouseholdsResponse {
+	ctx, span := startSpan(ctx, "MyHouseholds")
+	response := s.Next.MyHouseholds(ctx)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) InviteMember(ctx context.Context, householdId string, request models.InviteMemberRequest) models.HouseholdResponse {
+	ctx, span := startSpan(ctx, "InviteMember")
+	response := s.Next.InviteMember(ctx, householdId, request)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) RemoveMember(ctx context.Context, householdId string, subject string) models.HouseholdResponse {
+	ctx, span := startSpan(ctx, "RemoveMember")
+	response := s.Next.RemoveMember(ctx, householdId, subject)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) HouseholdRole(ctx context.Context, householdId string) (primitive.ObjectID, auth.Role, error) {
+	ctx, span := startSpan(ctx, "HouseholdRole")
+	id, role, err := s.Next.HouseholdRole(ctx, householdId)
+	if errors.Is(err, ErrNotMember) || errors.Is(err, ErrInvalidHousehold) {
+		tracing.End(span, nil)
+	} else {
+		tracing.End(span, err)
+	}
+	return id, role, err
+}
+
+func (s *TracedService) RateDish(ctx context.Context, dishId string, request models.RateDishRequest) models.RatingResponse {
+	ctx, span := startSpan(ctx, "RateDish")
+	response := s.Next.RateDish(ctx, dishId, request)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) DishRatings(ctx context.Context, dishId string) models.AllRatingsResponse {
+	ctx, span := startSpan(ctx, "DishRatings")
+	response := s.Next.DishRatings(ctx, dishId)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) RatingsForDishes(ctx context.Context, dishIds []string) models.AllRatingsResponse {
+	ctx, span := startSpan(ctx, "RatingsForDishes")
+	response := s.Next.RatingsForDishes(ctx, dishIds)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) AddDishes(ctx context.Context, request models.AddDishesRequest) models.AllDishesResponse {
+	ctx, span := startSpan(ctx, "AddDishes")
+	response := s.Next.AddDishes(ctx, request)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) RenameCuisine(ctx context.Context, cuisineId string, request models.RenameRequest) models.CuisineResponse {
+	ctx, span := startSpan(ctx, "RenameCuisine")
+	response := s.Next.RenameCuisine(ctx, cuisineId, request)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) DeleteCuisine(ctx context.Context, cuisineId string) models.CuisineResponse {
+	ctx, span := startSpan(ctx, "DeleteCuisine")
+	response := s.Next.DeleteCuisine(ctx, cuisineId)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) RenameDish(ctx context.Context, dishId string, request models.RenameRequest) models.DishResponse {
+	ctx, span := startSpan(ctx, "RenameDish")
+	response := s.Next.RenameDish(ctx, dishId, request)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) DeleteDish(ctx context.Context, dishId string) models.DishResponse {
+	ctx, span := startSpan(ctx, "DeleteDish")
+	response := s.Next.DeleteDish(ctx, dishId)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) ExportCatalog(ctx context.Context) models.CatalogResponse {
+	ctx, span := startSpan(ctx, "ExportCatalog")
+	response := s.Next.ExportCatalog(ctx)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) ImportCatalog(ctx context.Context, catalog models.Catalog) models.ImportResponse {
+	ctx, span := startSpan(ctx, "ImportCatalog")
+	response := s.Next.ImportCatalog(ctx, catalog)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) SeedCatalog(ctx context.Context, catalog models.Catalog) models.ImportResponse {
+	ctx, span := startSpan(ctx, "SeedCatalog")
+	response := s.Next.SeedCatalog(ctx, catalog)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) Migrations(ctx context.Context) models.MigrationsResponse {
+	ctx, span := startSpan(ctx, "Migrations")
+	response := s.Next.Migrations(ctx)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) Migrate(ctx context.Context) models.MigrationsResponse {
+	ctx, span := startSpan(ctx, "Migrate")
+	response := s.Next.Migrate(ctx)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) AddWebhook(ctx context.Context, request models.AddWebhookRequest) models.WebhookResponse {
+	ctx, span := startSpan(ctx, "AddWebhook")
+	response := s.Next.AddWebhook(ctx, request)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) AllWebhooks(ctx context.Context) models.AllWebhooksResponse {
+	ctx, span := startSpan(ctx, "AllWebhooks")
+	response := s.Next.AllWebhooks(ctx)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) DeleteWebhook(ctx context.Context, webhookId string) models.WebhookResponse {
+	ctx, span := startSpan(ctx, "DeleteWebhook")
+	response := s.Next.DeleteWebhook(ctx, webhookId)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) WebhookDeliveries(ctx context.Context, webhookId string) models.AllDeliveriesResponse {
+	ctx, span := startSpan(ctx, "WebhookDeliveries")
+	response := s.Next.WebhookDeliveries(ctx, webhookId)
+	endWithMessage(span, response.Message)
+	return response
+}
+
+func (s *TracedService) ReplayDelivery(ctx context.Context, webhookId string, deliveryId string) models.DeliveryResponse {
+	ctx, span := startSpan(ctx, "ReplayDelivery")
+	response := s.Next.ReplayDelivery(ctx, webhookId, deliveryId)
+	endWithMessage(span, response.Message)
+	return response
+}
@@ -0,0 +1,67 @@
+package facade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"net/http"
+	"strconv"
+)
+
+func (s *Service) CurrentUser(ctx context.Context) (response models.UserResponse) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		response.Message = errorMessage(ctx, fmt.Errorf("no authenticated user"), "Unauthorized", http.StatusUnauthorized)
+		return response
+	}
+
+	user, err := s.userFor(ctx, principal)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Find error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.User = user
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+func (s *Service) SetPreference(ctx context.Context, request models.SetPreferenceRequest) (response models.UserResponse) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		response.Message = errorMessage(ctx, fmt.Errorf("no authenticated user"), "Unauthorized", http.StatusUnauthorized)
+		return response
+	}
+
+	if err := validation.Struct(request); err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+	id, _ := primitive.ObjectIDFromHex(request.ID)
+
+	user, err := s.UserService.SetPreference(ctx, principal.Subject, request.Target, id, request.Preference)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Update error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.User = user
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+// userFor returns the stored preferences of principal, or an empty user when none exist yet.
+func (s *Service) userFor(ctx context.Context, principal *auth.Principal) (*models.User, error) {
+	user, err := s.UserService.GetUser(ctx, principal.Subject)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &models.User{Subject: principal.Subject}, nil
+	}
+	return user, err
+}
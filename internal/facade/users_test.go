@@ -0,0 +1,62 @@
+package facade
+
+import (
+	"context"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestService_SetPreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserSvc := mongodb.NewMockUserServiceI(ctrl)
+	dishId := primitive.NewObjectID()
+	user := &models.User{Subject: "user-123", FavoriteDishes: []primitive.ObjectID{dishId}}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		request    models.SetPreferenceRequest
+		wantStatus string
+		wantUser   *models.User
+	}{
+		{
+			name:       "Happy Path",
+			ctx:        auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "user-123", Role: auth.RoleReader}),
+			request:    models.SetPreferenceRequest{Target: "dish", ID: dishId.Hex(), Preference: "favorite"},
+			wantStatus: strconv.Itoa(http.StatusOK),
+			wantUser:   user,
+		},
+		{
+			name:       "Sad Path: unknown preference",
+			ctx:        auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "user-123", Role: auth.RoleReader}),
+			request:    models.SetPreferenceRequest{Target: "dish", ID: dishId.Hex(), Preference: "love"},
+			wantStatus: strconv.Itoa(http.StatusBadRequest),
+		},
+		{
+			name:       "Sad Path: anonymous caller",
+			ctx:        context.Background(),
+			request:    models.SetPreferenceRequest{Target: "dish", ID: dishId.Hex(), Preference: "favorite"},
+			wantStatus: strconv.Itoa(http.StatusUnauthorized),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				UserService: mockUserSvc,
+			}
+			mockUserSvc.EXPECT().SetPreference(tt.ctx, "user-123", "dish", dishId, "favorite").Return(user, nil).MaxTimes(1)
+
+			gotResponse := s.SetPreference(tt.ctx, tt.request)
+			assert.Equal(t, tt.wantStatus, gotResponse.Message.Status)
+			assert.Equal(t, tt.wantUser, gotResponse.User)
+		})
+	}
+}
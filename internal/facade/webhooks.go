@@ -0,0 +1,190 @@
+package facade
+
+import (
+	"context"
+	"encoding/json"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/tenant"
+	"food-roulette-api/internal/validation"
+	"food-roulette-api/internal/webhooks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// pickMade is the data of a pick.made event.
+type pickMade struct {
+	Cuisine *models.Cuisine `json:"cuisine"`
+	Dish    *models.Dish    `json:"dish,omitempty"`
+}
+
+// AddWebhook registers a webhook in the caller's household. Receivers must be https URLs with a
+// public address. The signing secret is returned here only; it cannot be read back later.
+func (s *Service) AddWebhook(ctx context.Context, request models.AddWebhookRequest) (response models.WebhookResponse) {
+	err := validation.Struct(request)
+	if err == nil {
+		err = webhooks.CheckURL(ctx, request.URL)
+	}
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Secret generation error", http.StatusInternalServerError)
+		return response
+	}
+
+	now := time.Now().UTC()
+	webhook := models.Webhook{
+		URL:       request.URL,
+		Events:    request.Events,
+		Secret:    secret,
+		CreatedAt: &now,
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		webhook.CreatedBy = principal.Subject
+	}
+
+	result, err := s.WebhookService.AddWebhook(ctx, webhook)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Insertion error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Webhook = result
+	response.Secret = secret
+	response.Message.Status = strconv.Itoa(http.StatusCreated)
+
+	return response
+}
+
+func (s *Service) AllWebhooks(ctx context.Context) (response models.AllWebhooksResponse) {
+	results, err := s.WebhookService.GetWebhooks(ctx)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "FindAll error", http.StatusInternalServerError)
+		return response
+	}
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+	response.Message.Count = len(results)
+	response.Webhooks = results
+
+	return response
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, webhookId string) (response models.WebhookResponse) {
+	id, err := parseID(webhookId, "webhook")
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	if err = s.WebhookService.DeleteWebhook(ctx, id); err != nil {
+		response.Message = errorMessage(ctx, err, "Delete error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Webhook = &models.Webhook{ID: id}
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+
+	return response
+}
+
+// WebhookDeliveries returns the delivery log of a webhook, newest first.
+func (s *Service) WebhookDeliveries(ctx context.Context, webhookId string) (response models.AllDeliveriesResponse) {
+	id, err := parseID(webhookId, "webhook")
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	results, err := s.WebhookService.GetDeliveries(ctx, id)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "FindAll error", http.StatusInternalServerError)
+		return response
+	}
+	response.Message.Status = strconv.Itoa(http.StatusOK)
+	response.Message.Count = len(results)
+	response.Deliveries = results
+
+	return response
+}
+
+// ReplayDelivery sends a delivery again with its original payload and event ID.
+func (s *Service) ReplayDelivery(ctx context.Context, webhookId string, deliveryId string) (response models.DeliveryResponse) {
+	id, err := parseID(webhookId, "webhook")
+	var delivery primitive.ObjectID
+	if err == nil {
+		delivery, err = parseID(deliveryId, "delivery")
+	}
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Validation error", http.StatusBadRequest)
+		return response
+	}
+
+	result, err := s.WebhookService.ReplayDelivery(ctx, id, delivery)
+	if err != nil {
+		response.Message = errorMessage(ctx, err, "Replay error", http.StatusInternalServerError)
+		return response
+	}
+
+	response.Delivery = result
+	response.Message.Status = strconv.Itoa(http.StatusAccepted)
+
+	return response
+}
+
+// notify queues a delivery of event to every webhook of the household subscribed to it. The
+// dispatcher sends them in the background; a failure to queue is logged rather than failing the
+// request that caused the event.
+func (s *Service) notify(ctx context.Context, event string, data interface{}) {
+	if s.WebhookService == nil {
+		return
+	}
+	logger := logging.FromContext(ctx)
+
+	subscribed, err := s.WebhookService.WebhooksFor(ctx, event)
+	if err != nil {
+		logger.Errorf("failed to find webhooks for event: %v; err: %v", event, err.Error())
+		return
+	}
+	if len(subscribed) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	payload := webhooks.Payload{
+		ID:         primitive.NewObjectID().Hex(),
+		Type:       event,
+		OccurredAt: now,
+		Data:       data,
+	}
+	if householdId, ok := tenant.HouseholdFromContext(ctx); ok {
+		payload.HouseholdID = householdId.Hex()
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.Errorf("failed to encode webhook payload for event: %v; err: %v", event, err.Error())
+		return
+	}
+
+	deliveries := make([]models.WebhookDelivery, len(subscribed))
+	for i, webhook := range subscribed {
+		deliveries[i] = models.WebhookDelivery{
+			Webhook:       webhook.ID,
+			EventID:       payload.ID,
+			Event:         event,
+			Payload:       string(body),
+			Status:        models.DeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt:     &now,
+		}
+	}
+	if err = s.WebhookService.EnqueueDeliveries(ctx, deliveries); err != nil {
+		logger.Errorf("failed to queue webhook deliveries for event: %v; err: %v", event, err.Error())
+	}
+}
@@ -0,0 +1,218 @@
+package facade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	"food-roulette-api/internal/tenant"
+	"food-roulette-api/internal/webhooks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestService_AddWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    models.AddWebhookRequest
+		wantStore  bool
+		wantStatus string
+		wantCode   string
+	}{
+		{
+			name:       "Happy Path",
+			request:    models.AddWebhookRequest{URL: "https://203.0.113.10/lunch", Events: []string{models.EventPickMade, models.EventCuisineAdded}},
+			wantStore:  true,
+			wantStatus: strconv.Itoa(http.StatusCreated),
+		},
+		{
+			name:       "Sad Path: unknown event",
+			request:    models.AddWebhookRequest{URL: "https://203.0.113.10/lunch", Events: []string{"plan.generated"}},
+			wantStatus: strconv.Itoa(http.StatusBadRequest),
+		},
+		{
+			name:       "Sad Path: not an https url",
+			request:    models.AddWebhookRequest{URL: "http://203.0.113.10/lunch", Events: []string{models.EventPickMade}},
+			wantStatus: strconv.Itoa(http.StatusBadRequest),
+		},
+		{
+			name:       "Sad Path: internal address",
+			request:    models.AddWebhookRequest{URL: "https://169.254.169.254/latest/meta-data", Events: []string{models.EventPickMade}},
+			wantStatus: strconv.Itoa(http.StatusBadRequest),
+			wantCode:   apperrors.CodeWebhookURL,
+		},
+		{
+			name:       "Sad Path: no events",
+			request:    models.AddWebhookRequest{URL: "https://203.0.113.10/lunch"},
+			wantStatus: strconv.Itoa(http.StatusBadRequest),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWebhookSvc := mongodb.NewMockWebhookServiceI(ctrl)
+			s := &Service{WebhookService: mockWebhookSvc}
+			if tt.wantStore {
+				mockWebhookSvc.EXPECT().AddWebhook(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, webhook models.Webhook) (*models.Webhook, error) {
+						webhook.ID = primitive.NewObjectID()
+						return &webhook, nil
+					})
+			}
+
+			got := s.AddWebhook(context.Background(), tt.request)
+			assert.Equal(t, tt.wantStatus, got.Message.Status)
+			if tt.wantStore {
+				assert.True(t, strings.HasPrefix(got.Secret, "whsec_"))
+				assert.Equal(t, got.Secret, got.Webhook.Secret)
+				assert.Equal(t, tt.request.Events, got.Webhook.Events)
+			} else {
+				assert.Empty(t, got.Secret)
+			}
+			if tt.wantCode != "" {
+				assert.Equal(t, tt.wantCode, got.Message.ErrorLog[0].Code)
+			}
+		})
+	}
+}
+
+func TestService_ReplayDelivery(t *testing.T) {
+	webhookId := primitive.NewObjectID()
+	deliveryId := primitive.NewObjectID()
+
+	tests := []struct {
+		name       string
+		webhookId  string
+		deliveryId string
+		storeErr   error
+		wantStore  bool
+		wantStatus string
+		wantCode   string
+	}{
+		{
+			name:       "Happy Path",
+			webhookId:  webhookId.Hex(),
+			deliveryId: deliveryId.Hex(),
+			wantStore:  true,
+			wantStatus: strconv.Itoa(http.StatusAccepted),
+		},
+		{
+			name:       "Sad Path: invalid delivery id",
+			webhookId:  webhookId.Hex(),
+			deliveryId: "abc",
+			wantStatus: strconv.Itoa(http.StatusBadRequest),
+			wantCode:   apperrors.CodeInvalidID,
+		},
+		{
+			name:       "Sad Path: not found",
+			webhookId:  webhookId.Hex(),
+			deliveryId: deliveryId.Hex(),
+			storeErr:   apperrors.New(apperrors.ErrNotFound, apperrors.CodeDeliveryNotFound, "delivery not found"),
+			wantStore:  true,
+			wantStatus: strconv.Itoa(http.StatusNotFound),
+			wantCode:   apperrors.CodeDeliveryNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWebhookSvc := mongodb.NewMockWebhookServiceI(ctrl)
+			s := &Service{WebhookService: mockWebhookSvc}
+			if tt.wantStore {
+				var result *models.WebhookDelivery
+				if tt.storeErr == nil {
+					result = &models.WebhookDelivery{ID: deliveryId, Webhook: webhookId, Status: models.DeliveryPending}
+				}
+				mockWebhookSvc.EXPECT().ReplayDelivery(gomock.Any(), webhookId, deliveryId).Return(result, tt.storeErr)
+			}
+
+			got := s.ReplayDelivery(context.Background(), tt.webhookId, tt.deliveryId)
+			assert.Equal(t, tt.wantStatus, got.Message.Status)
+			if tt.wantCode == "" {
+				assert.Equal(t, models.DeliveryPending, got.Delivery.Status)
+				return
+			}
+			assert.Nil(t, got.Delivery)
+			assert.Equal(t, tt.wantCode, got.Message.ErrorLog[0].Code)
+		})
+	}
+}
+
+func TestService_DeleteWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebhookSvc := mongodb.NewMockWebhookServiceI(ctrl)
+	s := &Service{WebhookService: mockWebhookSvc}
+	id := primitive.NewObjectID()
+
+	mockWebhookSvc.EXPECT().DeleteWebhook(gomock.Any(), id).Return(nil)
+	got := s.DeleteWebhook(context.Background(), id.Hex())
+	assert.Equal(t, strconv.Itoa(http.StatusOK), got.Message.Status)
+
+	mockWebhookSvc.EXPECT().DeleteWebhook(gomock.Any(), id).Return(apperrors.New(apperrors.ErrNotFound, apperrors.CodeWebhookNotFound, "webhook not found"))
+	got = s.DeleteWebhook(context.Background(), id.Hex())
+	assert.Equal(t, strconv.Itoa(http.StatusNotFound), got.Message.Status)
+}
+
+func TestService_AddCuisine_NotifiesWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMongoSvc := mongodb.NewMockServiceI(ctrl)
+	mockWebhookSvc := mongodb.NewMockWebhookServiceI(ctrl)
+	s := &Service{MongoService: mockMongoSvc, WebhookService: mockWebhookSvc}
+	householdId := primitive.NewObjectID()
+	ctx := tenant.WithHousehold(context.Background(), householdId)
+	first, second := primitive.NewObjectID(), primitive.NewObjectID()
+
+	cuisine := &models.Cuisine{ID: primitive.NewObjectID(), Name: "Thai"}
+	mockMongoSvc.EXPECT().AddNewCuisine(gomock.Any(), gomock.Any()).Return(cuisine, nil)
+	mockWebhookSvc.EXPECT().WebhooksFor(gomock.Any(), models.EventCuisineAdded).Return([]*models.Webhook{{ID: first}, {ID: second}}, nil)
+	mockWebhookSvc.EXPECT().EnqueueDeliveries(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, deliveries []models.WebhookDelivery) error {
+			assert.Len(t, deliveries, 2)
+			assert.Equal(t, first, deliveries[0].Webhook)
+			assert.Equal(t, second, deliveries[1].Webhook)
+			for _, delivery := range deliveries {
+				assert.Equal(t, models.DeliveryPending, delivery.Status)
+				assert.NotNil(t, delivery.NextAttemptAt)
+				// every webhook gets the same event
+				assert.Equal(t, deliveries[0].EventID, delivery.EventID)
+				assert.Equal(t, deliveries[0].Payload, delivery.Payload)
+			}
+
+			var payload webhooks.Payload
+			assert.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &payload))
+			assert.Equal(t, deliveries[0].EventID, payload.ID)
+			assert.Equal(t, models.EventCuisineAdded, payload.Type)
+			assert.Equal(t, householdId.Hex(), payload.HouseholdID)
+			assert.Equal(t, "Thai", payload.Data.(map[string]interface{})["name"])
+			return nil
+		})
+
+	got := s.AddCuisine(ctx, models.AddCuisineRequest{Name: "Thai"})
+	assert.Equal(t, strconv.Itoa(http.StatusOK), got.Message.Status)
+}
+
+func TestService_RandomPick_WebhookFailureDoesNotFailThePick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMongoSvc := mongodb.NewMockServiceI(ctrl)
+	mockWebhookSvc := mongodb.NewMockWebhookServiceI(ctrl)
+	s := &Service{MongoService: mockMongoSvc, WebhookService: mockWebhookSvc}
+
+	mockMongoSvc.EXPECT().GetAllCuisines(gomock.Any()).Return([]*models.Cuisine{{ID: primitive.NewObjectID(), Name: "Thai"}}, nil)
+	mockWebhookSvc.EXPECT().WebhooksFor(gomock.Any(), models.EventPickMade).Return(nil, fmt.Errorf("connection reset"))
+
+	got := s.RandomPick(context.Background(), models.PickRequest{})
+	assert.Equal(t, strconv.Itoa(http.StatusOK), got.Message.Status)
+	assert.Equal(t, "Thai", got.Cuisine.Name)
+}
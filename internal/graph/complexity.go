@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"strconv"
+)
+
+const (
+	// DefaultMaxComplexity admits listing cuisines, their dishes and the ratings of each dish, but
+	// rejects queries nesting a fourth list through the back references of ratings.
+	DefaultMaxComplexity = 10000
+
+	// defaultListSize is the number of items a list field is assumed to return without first.
+	defaultListSize = 10
+)
+
+// complexity scores the selected operation of doc before it is executed: every field costs one,
+// and the selections below a list field are multiplied by its first argument or defaultListSize.
+func complexity(s *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}) int {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operation *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operation == nil || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		}
+	}
+	if operation == nil {
+		return 0
+	}
+
+	root := s.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = s.MutationType()
+	}
+	c := complexityCalculator{schema: s, fragments: fragments, variables: variables}
+	return c.selectionSet(operation.SelectionSet, root, map[string]bool{})
+}
+
+type complexityCalculator struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+func (c complexityCalculator) selectionSet(set *ast.SelectionSet, parent *graphql.Object, spreading map[string]bool) int {
+	if set == nil || parent == nil {
+		return 0
+	}
+	total := 0
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			total += c.field(selection, parent, spreading)
+		case *ast.InlineFragment:
+			target := parent
+			if selection.TypeCondition != nil {
+				target, _ = c.schema.Type(selection.TypeCondition.Name.Value).(*graphql.Object)
+			}
+			total += c.selectionSet(selection.SelectionSet, target, spreading)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := c.fragments[name]
+			if !ok || spreading[name] {
+				continue
+			}
+			spreading[name] = true
+			target, _ := c.schema.Type(fragment.TypeCondition.Name.Value).(*graphql.Object)
+			total += c.selectionSet(fragment.SelectionSet, target, spreading)
+			delete(spreading, name)
+		}
+	}
+	return total
+}
+
+func (c complexityCalculator) field(field *ast.Field, parent *graphql.Object, spreading map[string]bool) int {
+	definition, ok := parent.Fields()[field.Name.Value]
+	if !ok {
+		// introspection and unknown fields are left to validation
+		return 1
+	}
+
+	fieldType := definition.Type
+	if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+		fieldType = nonNull.OfType
+	}
+	multiplier := 1
+	if list, ok := fieldType.(*graphql.List); ok {
+		multiplier = c.listSize(field)
+		fieldType = list.OfType
+		if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+			fieldType = nonNull.OfType
+		}
+	}
+
+	object, _ := fieldType.(*graphql.Object)
+	return 1 + multiplier*c.selectionSet(field.SelectionSet, object, spreading)
+}
+
+func (c complexityCalculator) listSize(field *ast.Field) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "first" {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil && n >= 0 {
+				return n
+			}
+		case *ast.Variable:
+			switch n := c.variables[value.Name.Value].(type) {
+			case int:
+				if n >= 0 {
+					return n
+				}
+			case float64:
+				if n >= 0 {
+					return int(n)
+				}
+			}
+		}
+	}
+	return defaultListSize
+}
@@ -0,0 +1,118 @@
+// Package graph serves cuisines, dishes, ratings and picks as a GraphQL schema on top of the facade.
+package graph
+
+import (
+	"context"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"net/http"
+	"strconv"
+)
+
+// Request is a GraphQL over HTTP request body.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Execute parses, validates and runs request against service. Queries scoring above
+// maxComplexity are rejected before any resolver runs; zero selects DefaultMaxComplexity.
+func Execute(ctx context.Context, service facade.ServiceI, request Request, maxComplexity int) *graphql.Result {
+	if maxComplexity <= 0 {
+		maxComplexity = DefaultMaxComplexity
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(request.Query), Name: "GraphQL request"})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if validation := graphql.ValidateDocument(&schema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+	if score := complexity(&schema, doc, request.OperationName, request.Variables); score > maxComplexity {
+		tooComplex := &Error{
+			err: apperrors.New(apperrors.ErrValidation, apperrors.CodeQueryTooComplex,
+				fmt.Sprintf("query complexity %v exceeds the limit of %v", score, maxComplexity)),
+			extensions: map[string]interface{}{"complexity": score, "maxComplexity": maxComplexity},
+		}
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{{
+			Message:    tooComplex.Error(),
+			Locations:  []location.SourceLocation{},
+			Extensions: tooComplex.Extensions(),
+		}}}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        schema,
+		AST:           doc,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       withLoaders(ctx, service),
+	})
+}
+
+// Error is a resolver error that carries the stable problem code of the failure in its extensions.
+type Error struct {
+	err        error
+	status     int
+	extensions map[string]interface{}
+}
+
+func (e *Error) Error() string {
+	return apperrors.Detail(e.err, e.statusCode())
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": apperrors.CodeFor(e.err, e.statusCode())}
+	for key, value := range e.extensions {
+		extensions[key] = value
+	}
+	return extensions
+}
+
+func (e *Error) statusCode() int {
+	if e.status != 0 {
+		return e.status
+	}
+	return apperrors.Status(e.err)
+}
+
+// messageError converts a failed facade response into an Error, or returns nil on success.
+func messageError(message models.Message) error {
+	status, _ := strconv.Atoi(message.Status)
+	if status < http.StatusBadRequest && len(message.ErrorLog) == 0 {
+		return nil
+	}
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	first := message.ErrorLog[0]
+	e := &Error{
+		err:    apperrors.New(nil, first.Code, first.Trace),
+		status: status,
+	}
+	var invalidParams []map[string]interface{}
+	for _, errLog := range message.ErrorLog {
+		if errLog.Field != "" {
+			invalidParams = append(invalidParams, map[string]interface{}{"name": errLog.Field, "reason": errLog.Trace})
+		}
+	}
+	if len(invalidParams) > 0 {
+		e.err = apperrors.New(apperrors.ErrValidation, first.Code, fmt.Sprintf("%v field(s) failed validation", len(invalidParams)))
+		e.extensions = map[string]interface{}{"invalidParams": invalidParams}
+	}
+	return e
+}
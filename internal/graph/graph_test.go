@@ -0,0 +1,211 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+var ok = models.Message{Status: strconv.Itoa(http.StatusOK)}
+
+func testCatalog() []*models.Cuisine {
+	thai := &models.Cuisine{ID: primitive.NewObjectID(), Name: "Thai", Tags: []string{"spicy"}, Dishes: []models.Dish{
+		{ID: primitive.NewObjectID(), Name: "Pad Thai"},
+		{ID: primitive.NewObjectID(), Name: "Green Curry", Rating: &models.RatingSummary{Average: 4, Count: 1}},
+	}}
+	italian := &models.Cuisine{ID: primitive.NewObjectID(), Name: "Italian", Dishes: []models.Dish{
+		{ID: primitive.NewObjectID(), Name: "Lasagna"},
+	}}
+	return []*models.Cuisine{thai, italian}
+}
+
+func asJSON(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func TestExecute_BatchesNestedLoads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	cuisines := testCatalog()
+	curry := cuisines[0].Dishes[1]
+	rating := &models.Rating{ID: primitive.NewObjectID(), Dish: curry.ID, Subject: "user-123", Score: 4}
+
+	mockFacade.EXPECT().AllCuisines(gomock.Any()).Return(models.AllCuisinesResponse{Cuisines: cuisines, Message: ok}).Times(1)
+	mockFacade.EXPECT().RatingsForDishes(gomock.Any(), []string{cuisines[0].Dishes[0].ID.Hex(), curry.ID.Hex(), cuisines[1].Dishes[0].ID.Hex()}).
+		Return(models.AllRatingsResponse{Ratings: []*models.Rating{rating}, Message: ok}).Times(1)
+
+	result := Execute(context.Background(), mockFacade, Request{
+		Query: `{ cuisines { name tags dishes { name rating { average } ratings { score dish { name cuisine { name } } } } } }`,
+	}, 0)
+
+	assert.Empty(t, result.Errors)
+	assert.JSONEq(t, `{"cuisines":[
+		{"name":"Thai","tags":["spicy"],"dishes":[
+			{"name":"Pad Thai","rating":null,"ratings":[]},
+			{"name":"Green Curry","rating":{"average":4},"ratings":[{"score":4,"dish":{"name":"Green Curry","cuisine":{"name":"Thai"}}}]}
+		]},
+		{"name":"Italian","tags":[],"dishes":[{"name":"Lasagna","rating":null,"ratings":[]}]}
+	]}`, asJSON(t, result.Data))
+}
+
+func TestExecute_RandomPick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	cuisine := testCatalog()[0]
+
+	mockFacade.EXPECT().RandomPick(gomock.Any(), models.PickRequest{Tags: []string{"spicy"}, MinRating: 3.5}).
+		Return(models.PickResponse{Cuisine: cuisine, Dish: &cuisine.Dishes[1], Message: ok})
+
+	result := Execute(context.Background(), mockFacade, Request{
+		Query:     `query Pick($filter: PickFilter) { randomPick(filter: $filter) { cuisine { name } dish { id name } } }`,
+		Variables: map[string]interface{}{"filter": map[string]interface{}{"tags": []interface{}{"spicy"}, "minRating": 3.5}},
+	}, 0)
+
+	assert.Empty(t, result.Errors)
+	assert.JSONEq(t, `{"randomPick":{"cuisine":{"name":"Thai"},"dish":{"id":"`+cuisine.Dishes[1].ID.Hex()+`","name":"Green Curry"}}}`, asJSON(t, result.Data))
+}
+
+func TestExecute_Mutations(t *testing.T) {
+	const addCuisine = `mutation { addCuisine(input: {name: "Thai", tags: ["spicy"], dishes: [{name: "Pad Thai"}]}) { id name dishes { name } } }`
+	cuisine := testCatalog()[0]
+	editor := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "user-123", Role: auth.RoleEditor})
+	reader := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "user-123", Role: auth.RoleReader})
+	status := strconv.Itoa(http.StatusBadRequest)
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		mockRes        *models.CuisineResponse
+		wantData       string
+		wantCode       string
+		wantMessage    string
+		wantInvalidLen int
+	}{
+		{
+			name:     "Editor adds a cuisine",
+			ctx:      editor,
+			mockRes:  &models.CuisineResponse{Cuisine: cuisine, Message: ok},
+			wantData: `{"addCuisine":{"id":"` + cuisine.ID.Hex() + `","name":"Thai","dishes":[{"name":"Pad Thai"},{"name":"Green Curry"}]}}`,
+		},
+		{
+			name:        "Reader is forbidden",
+			ctx:         reader,
+			wantData:    `null`,
+			wantCode:    "forbidden",
+			wantMessage: "role editor is required for addCuisine",
+		},
+		{
+			name: "Facade validation errors keep their fields",
+			ctx:  editor,
+			mockRes: &models.CuisineResponse{Message: models.Message{Status: status, ErrorLog: []models.ErrorLog{
+				{Status: status, Code: "validation_failed", Field: "name", RootCause: "Validation error", Trace: "is required"},
+			}}},
+			wantData:       `null`,
+			wantCode:       "validation_failed",
+			wantMessage:    "1 field(s) failed validation",
+			wantInvalidLen: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFacade := facade.NewMockServiceI(ctrl)
+			if tt.mockRes != nil {
+				mockFacade.EXPECT().AddCuisine(gomock.Any(), models.AddCuisineRequest{
+					Name:   "Thai",
+					Tags:   []string{"spicy"},
+					Dishes: []models.Dish{{Name: "Pad Thai"}},
+				}).Return(*tt.mockRes)
+			}
+
+			result := Execute(tt.ctx, mockFacade, Request{Query: addCuisine}, 0)
+
+			assert.JSONEq(t, tt.wantData, asJSON(t, result.Data))
+			if tt.wantCode == "" {
+				assert.Empty(t, result.Errors)
+				return
+			}
+			if assert.Len(t, result.Errors, 1) {
+				assert.Equal(t, tt.wantMessage, result.Errors[0].Message)
+				assert.Equal(t, tt.wantCode, result.Errors[0].Extensions["code"])
+				if tt.wantInvalidLen > 0 {
+					assert.Len(t, result.Errors[0].Extensions["invalidParams"], tt.wantInvalidLen)
+				}
+			}
+		})
+	}
+}
+
+func TestExecute_RejectsComplexQueries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+
+	result := Execute(context.Background(), mockFacade, Request{
+		Query: `{ cuisines { dishes { ratings { dish { ratings { dish { name } } } } } } }`,
+	}, 0)
+
+	assert.Nil(t, result.Data)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "query_too_complex", result.Errors[0].Extensions["code"])
+		assert.Equal(t, DefaultMaxComplexity, result.Errors[0].Extensions["maxComplexity"])
+	}
+}
+
+func TestComplexity(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		want      int
+	}{
+		{
+			name:  "Scalars cost one each",
+			query: `{ randomPick { cuisine { name tags } } }`,
+			want:  4,
+		},
+		{
+			name:  "Lists multiply their selections",
+			query: `{ cuisines { name dishes { name } } }`,
+			want:  1 + 10*(1+1+10*1),
+		},
+		{
+			name:      "First bounds the list size",
+			query:     `query Q($n: Int) { cuisines(first: 2) { dishes(first: $n) { name } } }`,
+			variables: map[string]interface{}{"n": float64(3)},
+			want:      1 + 2*(1+3*1),
+		},
+		{
+			name:  "Fragments are expanded",
+			query: `{ cuisines(first: 1) { ...names } } fragment names on Cuisine { name ... on Cuisine { createdBy } }`,
+			want:  1 + 1*(1+1),
+		},
+		{
+			name:  "Mutations are scored from the mutation type",
+			query: `mutation { rateDish(dishId: "1", input: {score: 5}) { score dish { name } } }`,
+			want:  1 + 1 + 1 + 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, complexity(&schema, doc, "", tt.variables))
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package graph
+
+import (
+	"context"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"sync"
+)
+
+// loaders batch and cache the storage reads of one GraphQL request, so nested fields cost one
+// facade call per kind of data rather than one per parent object.
+type loaders struct {
+	service facade.ServiceI
+	catalog *catalogLoader
+	ratings *ratingLoader
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, service facade.ServiceI) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		service: service,
+		catalog: &catalogLoader{service: service},
+		ratings: &ratingLoader{service: service},
+	})
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// catalogLoader loads the caller's cuisines and their dishes once per request and indexes them,
+// so cuisine and dish lookups from any depth of the query share a single read.
+type catalogLoader struct {
+	service facade.ServiceI
+	once    sync.Once
+
+	cuisines      []*models.Cuisine
+	cuisinesById  map[string]*models.Cuisine
+	dishesById    map[string]*models.Dish
+	cuisineOfDish map[string]*models.Cuisine
+	err           error
+}
+
+func (l *catalogLoader) load(ctx context.Context) error {
+	l.once.Do(func() {
+		response := l.service.AllCuisines(ctx)
+		if err := messageError(response.Message); err != nil {
+			l.err = err
+			return
+		}
+		l.cuisines = response.Cuisines
+		l.cuisinesById = make(map[string]*models.Cuisine, len(response.Cuisines))
+		l.dishesById = make(map[string]*models.Dish)
+		l.cuisineOfDish = make(map[string]*models.Cuisine)
+		for _, cuisine := range response.Cuisines {
+			l.cuisinesById[cuisine.ID.Hex()] = cuisine
+			for i := range cuisine.Dishes {
+				dish := &cuisine.Dishes[i]
+				l.dishesById[dish.ID.Hex()] = dish
+				l.cuisineOfDish[dish.ID.Hex()] = cuisine
+			}
+		}
+	})
+	return l.err
+}
+
+func (l *catalogLoader) Cuisines(ctx context.Context) ([]*models.Cuisine, error) {
+	if err := l.load(ctx); err != nil {
+		return nil, err
+	}
+	return l.cuisines, nil
+}
+
+func (l *catalogLoader) Cuisine(ctx context.Context, id string) (*models.Cuisine, error) {
+	if err := l.load(ctx); err != nil {
+		return nil, err
+	}
+	return l.cuisinesById[id], nil
+}
+
+func (l *catalogLoader) Dish(ctx context.Context, id string) (*models.Dish, error) {
+	if err := l.load(ctx); err != nil {
+		return nil, err
+	}
+	return l.dishesById[id], nil
+}
+
+func (l *catalogLoader) CuisineOfDish(ctx context.Context, dishId string) (*models.Cuisine, error) {
+	if err := l.load(ctx); err != nil {
+		return nil, err
+	}
+	return l.cuisineOfDish[dishId], nil
+}
+
+// ratingLoader batches the ratings of every dish requested while a level of the query is being
+// resolved. Load only queues the dish and returns a thunk; the executor calls the thunks after the
+// whole level is resolved, and the first of them fetches every queued dish in one facade call.
+type ratingLoader struct {
+	service facade.ServiceI
+
+	mu      sync.Mutex
+	pending []string
+	queued  map[string]bool
+	results map[string][]*models.Rating
+	errs    map[string]error
+}
+
+func (l *ratingLoader) Load(ctx context.Context, dishId string) func() (interface{}, error) {
+	l.mu.Lock()
+	if l.queued == nil {
+		l.queued = make(map[string]bool)
+		l.results = make(map[string][]*models.Rating)
+		l.errs = make(map[string]error)
+	}
+	if !l.queued[dishId] {
+		l.queued[dishId] = true
+		l.pending = append(l.pending, dishId)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.flush(ctx)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if err := l.errs[dishId]; err != nil {
+			return nil, err
+		}
+		if ratings := l.results[dishId]; ratings != nil {
+			return ratings, nil
+		}
+		return []*models.Rating{}, nil
+	}
+}
+
+func (l *ratingLoader) flush(ctx context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.pending) == 0 {
+		return
+	}
+	dishIds := l.pending
+	l.pending = nil
+
+	response := l.service.RatingsForDishes(ctx, dishIds)
+	if err := messageError(response.Message); err != nil {
+		for _, dishId := range dishIds {
+			l.errs[dishId] = err
+		}
+		return
+	}
+	for _, rating := range response.Ratings {
+		dishId := rating.Dish.Hex()
+		l.results[dishId] = append(l.results[dishId], rating)
+	}
+}
@@ -0,0 +1,337 @@
+package graph
+
+import (
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/models"
+	"github.com/graphql-go/graphql"
+)
+
+// schema is built once; resolvers reach the facade through the loaders of each request context.
+var schema graphql.Schema
+
+var ratingSummaryType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "RatingSummary",
+	Description: "Average score and number of ratings",
+	Fields: graphql.Fields{
+		"average": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"count":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+	},
+})
+
+// The object types refer to each other, so they are created in init with lazily built fields.
+var cuisineType, dishType, ratingType *graphql.Object
+
+func init() {
+	cuisineType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Cuisine",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolveCuisineID},
+				"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"tags":      &graphql.Field{Type: tagsType, Resolve: resolveCuisineTags},
+				"rating":    &graphql.Field{Type: ratingSummaryType},
+				"createdBy": &graphql.Field{Type: graphql.String},
+				"createdAt": &graphql.Field{Type: graphql.DateTime},
+				"dishes": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(dishType))),
+					Args:    graphql.FieldConfigArgument{"first": firstArg},
+					Resolve: resolveCuisineDishes,
+				},
+			}
+		}),
+	})
+	dishType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Dish",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":      &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolveDishID},
+				"name":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"tags":    &graphql.Field{Type: tagsType, Resolve: resolveDishTags},
+				"rating":  &graphql.Field{Type: ratingSummaryType},
+				"cuisine": &graphql.Field{Type: cuisineType, Resolve: resolveDishCuisine},
+				"ratings": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(ratingType))),
+					Args:    graphql.FieldConfigArgument{"first": firstArg},
+					Resolve: resolveDishRatings,
+				},
+			}
+		}),
+	})
+	ratingType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Rating",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolveRatingID},
+				"score":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"note":      &graphql.Field{Type: graphql.String},
+				"subject":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"createdAt": &graphql.Field{Type: graphql.DateTime},
+				"updatedAt": &graphql.Field{Type: graphql.DateTime},
+				"dish":      &graphql.Field{Type: dishType, Resolve: resolveRatingDish},
+			}
+		}),
+	})
+	schema = mustBuildSchema()
+}
+
+var tagsType = graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))
+
+var firstArg = &graphql.ArgumentConfig{
+	Type:        graphql.Int,
+	Description: "Return at most this many items",
+}
+
+var pickType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Pick",
+	Fields: graphql.FieldsThunk(func() graphql.Fields {
+		return graphql.Fields{
+			"cuisine": &graphql.Field{Type: graphql.NewNonNull(cuisineType)},
+			"dish":    &graphql.Field{Type: dishType},
+		}
+	}),
+})
+
+var pickFilterType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "PickFilter",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"tags":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		"minRating": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+	},
+})
+
+var dishInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "DishInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"tags": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+	},
+})
+
+var addCuisineInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "AddCuisineInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"tags":   &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		"dishes": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(dishInputType))},
+	},
+})
+
+var rateDishInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "RateDishInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"score": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		"note":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+	},
+})
+
+func mustBuildSchema() graphql.Schema {
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"cuisines": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(cuisineType))),
+				Description: "Cuisines of the caller's tenant, personalized and rated",
+				Args:        graphql.FieldConfigArgument{"first": firstArg},
+				Resolve:     resolveCuisines,
+			},
+			"cuisine": &graphql.Field{
+				Type:    cuisineType,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: resolveCuisine,
+			},
+			"dish": &graphql.Field{
+				Type:    dishType,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: resolveDish,
+			},
+			"randomPick": &graphql.Field{
+				Type:        graphql.NewNonNull(pickType),
+				Description: "A random cuisine and dish, weighted by preferences and ratings",
+				Args:        graphql.FieldConfigArgument{"filter": &graphql.ArgumentConfig{Type: pickFilterType}},
+				Resolve:     resolveRandomPick,
+			},
+		},
+	})
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"addCuisine": &graphql.Field{
+				Type:        graphql.NewNonNull(cuisineType),
+				Description: "Add a cuisine with its dishes; requires the editor role",
+				Args:        graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(addCuisineInputType)}},
+				Resolve:     resolveAddCuisine,
+			},
+			"rateDish": &graphql.Field{
+				Type:        graphql.NewNonNull(ratingType),
+				Description: "Add or edit the caller's rating of a dish",
+				Args: graphql.FieldConfigArgument{
+					"dishId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(rateDishInputType)},
+				},
+				Resolve: resolveRateDish,
+			},
+		},
+	})
+
+	result, err := graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+	if err != nil {
+		panic(fmt.Sprintf("graph: invalid schema: %v", err))
+	}
+	return result
+}
+
+func resolveCuisines(p graphql.ResolveParams) (interface{}, error) {
+	cuisines, err := loadersFrom(p.Context).catalog.Cuisines(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	return first(cuisines, p.Args), nil
+}
+
+func resolveCuisine(p graphql.ResolveParams) (interface{}, error) {
+	return loadersFrom(p.Context).catalog.Cuisine(p.Context, p.Args["id"].(string))
+}
+
+func resolveDish(p graphql.ResolveParams) (interface{}, error) {
+	return loadersFrom(p.Context).catalog.Dish(p.Context, p.Args["id"].(string))
+}
+
+func resolveRandomPick(p graphql.ResolveParams) (interface{}, error) {
+	var request models.PickRequest
+	if filter, ok := p.Args["filter"].(map[string]interface{}); ok {
+		request.Tags = stringList(filter["tags"])
+		request.MinRating, _ = filter["minRating"].(float64)
+	}
+	response := loadersFrom(p.Context).service.RandomPick(p.Context, request)
+	if err := messageError(response.Message); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func resolveAddCuisine(p graphql.ResolveParams) (interface{}, error) {
+	if err := requireRole(p, auth.RoleEditor); err != nil {
+		return nil, err
+	}
+	input := p.Args["input"].(map[string]interface{})
+	request := models.AddCuisineRequest{
+		Name: input["name"].(string),
+		Tags: stringList(input["tags"]),
+	}
+	if dishes, ok := input["dishes"].([]interface{}); ok {
+		for _, value := range dishes {
+			dish := value.(map[string]interface{})
+			request.Dishes = append(request.Dishes, models.Dish{Name: dish["name"].(string), Tags: stringList(dish["tags"])})
+		}
+	}
+
+	response := loadersFrom(p.Context).service.AddCuisine(p.Context, request)
+	if err := messageError(response.Message); err != nil {
+		return nil, err
+	}
+	return response.Cuisine, nil
+}
+
+func resolveRateDish(p graphql.ResolveParams) (interface{}, error) {
+	if err := requireRole(p, auth.RoleReader); err != nil {
+		return nil, err
+	}
+	input := p.Args["input"].(map[string]interface{})
+	request := models.RateDishRequest{Score: input["score"].(int)}
+	request.Note, _ = input["note"].(string)
+
+	response := loadersFrom(p.Context).service.RateDish(p.Context, p.Args["dishId"].(string), request)
+	if err := messageError(response.Message); err != nil {
+		return nil, err
+	}
+	return response.Rating, nil
+}
+
+func resolveCuisineID(p graphql.ResolveParams) (interface{}, error) {
+	return p.Source.(*models.Cuisine).ID.Hex(), nil
+}
+
+func resolveCuisineTags(p graphql.ResolveParams) (interface{}, error) {
+	return nonNilTags(p.Source.(*models.Cuisine).Tags), nil
+}
+
+func resolveCuisineDishes(p graphql.ResolveParams) (interface{}, error) {
+	cuisine := p.Source.(*models.Cuisine)
+	dishes := make([]*models.Dish, len(cuisine.Dishes))
+	for i := range cuisine.Dishes {
+		dishes[i] = &cuisine.Dishes[i]
+	}
+	return first(dishes, p.Args), nil
+}
+
+func resolveDishID(p graphql.ResolveParams) (interface{}, error) {
+	return p.Source.(*models.Dish).ID.Hex(), nil
+}
+
+func resolveDishTags(p graphql.ResolveParams) (interface{}, error) {
+	return nonNilTags(p.Source.(*models.Dish).Tags), nil
+}
+
+func resolveDishCuisine(p graphql.ResolveParams) (interface{}, error) {
+	return loadersFrom(p.Context).catalog.CuisineOfDish(p.Context, p.Source.(*models.Dish).ID.Hex())
+}
+
+func resolveDishRatings(p graphql.ResolveParams) (interface{}, error) {
+	load := loadersFrom(p.Context).ratings.Load(p.Context, p.Source.(*models.Dish).ID.Hex())
+	return func() (interface{}, error) {
+		ratings, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return first(ratings.([]*models.Rating), p.Args), nil
+	}, nil
+}
+
+func resolveRatingID(p graphql.ResolveParams) (interface{}, error) {
+	return p.Source.(*models.Rating).ID.Hex(), nil
+}
+
+func resolveRatingDish(p graphql.ResolveParams) (interface{}, error) {
+	return loadersFrom(p.Context).catalog.Dish(p.Context, p.Source.(*models.Rating).Dish.Hex())
+}
+
+// requireRole rejects mutations from callers whose role, or household role, is below role.
+func requireRole(p graphql.ResolveParams, role auth.Role) error {
+	principal, ok := auth.PrincipalFromContext(p.Context)
+	if !ok {
+		return &Error{err: apperrors.New(apperrors.ErrUnauthorized, apperrors.CodeUnauthorized, "missing or invalid credentials")}
+	}
+	if !principal.Role.Allows(role) {
+		return &Error{err: apperrors.New(apperrors.ErrForbidden, apperrors.CodeForbidden, fmt.Sprintf("role %v is required for %v", role, p.Info.FieldName))}
+	}
+	return nil
+}
+
+// first applies the optional first argument of list fields.
+func first[T any](items []T, args map[string]interface{}) []T {
+	if n, ok := args["first"].(int); ok && n >= 0 && n < len(items) {
+		return items[:n]
+	}
+	return items
+}
+
+func stringList(value interface{}) []string {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		result = append(result, v.(string))
+	}
+	return result
+}
+
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
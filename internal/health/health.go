@@ -0,0 +1,157 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"food-roulette-api/internal/logging"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+	StatusDraining    = "draining"
+)
+
+// Reasons a dependency is unavailable. Reports are served without credentials, so the check's
+// error, which may name hosts or users, is only logged.
+const (
+	ReasonTimeout     = "timeout"
+	ReasonCheckFailed = "check_failed"
+)
+
+const defaultTimeout = 2 * time.Second
+
+// CheckFunc reports whether a dependency is usable; it must respect ctx cancellation.
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Readiness tracks the dependencies a replica needs before it should receive traffic and
+// whether the replica is draining for shutdown. A nil *Readiness is always ready.
+type Readiness struct {
+	// Timeout bounds each dependency check.
+	Timeout time.Duration
+	// DrainDelay is how long shutdown waits after reporting not-ready so load balancers stop routing.
+	DrainDelay time.Duration
+
+	mu       sync.RWMutex
+	checks   []check
+	draining int32
+}
+
+type Report struct {
+	Status string             `json:"status"`
+	Checks []DependencyStatus `json:"checks,omitempty"`
+}
+
+type DependencyStatus struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func NewReadiness(timeout time.Duration, drainDelay time.Duration) *Readiness {
+	return &Readiness{
+		Timeout:    timeout,
+		DrainDelay: drainDelay,
+	}
+}
+
+func (r *Readiness) Register(name string, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, check{name: name, fn: fn})
+}
+
+// Drain marks the replica as not ready; it cannot be undone.
+func (r *Readiness) Drain() {
+	if r == nil {
+		return
+	}
+	atomic.StoreInt32(&r.draining, 1)
+}
+
+func (r *Readiness) Draining() bool {
+	return r != nil && atomic.LoadInt32(&r.draining) == 1
+}
+
+// Check runs every registered dependency check concurrently, each bounded by Timeout.
+func (r *Readiness) Check(ctx context.Context) Report {
+	if r == nil {
+		return Report{Status: StatusOK}
+	}
+	if r.Draining() {
+		return Report{Status: StatusDraining}
+	}
+
+	r.mu.RLock()
+	checks := make([]check, len(r.checks))
+	copy(checks, r.checks)
+	r.mu.RUnlock()
+
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	report := Report{
+		Status: StatusOK,
+		Checks: make([]DependencyStatus, len(checks)),
+	}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			report.Checks[i] = run(ctx, c, timeout)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for _, dependency := range report.Checks {
+		if dependency.Status != StatusOK {
+			report.Status = StatusUnavailable
+		}
+	}
+	return report
+}
+
+func run(ctx context.Context, c check, timeout time.Duration) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	startTime := time.Now()
+	result := make(chan error, 1)
+	go func() {
+		result <- c.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	status := DependencyStatus{
+		Name:    c.name,
+		Status:  StatusOK,
+		Latency: time.Since(startTime).String(),
+	}
+	if err != nil {
+		logging.FromContext(ctx).Warnf("readiness check %v failed: %v", c.name, err.Error())
+		status.Status = StatusUnavailable
+		status.Reason = ReasonCheckFailed
+		if errors.Is(err, context.DeadlineExceeded) {
+			status.Reason = ReasonTimeout
+		}
+	}
+	return status
+}
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReadiness_Check(t *testing.T) {
+	tests := []struct {
+		name        string
+		checks      map[string]CheckFunc
+		drain       bool
+		wantStatus  string
+		wantReasons map[string]string
+	}{
+		{
+			name:       "No dependencies",
+			wantStatus: StatusOK,
+		},
+		{
+			name: "All dependencies up",
+			checks: map[string]CheckFunc{
+				"mongodb": func(ctx context.Context) error { return nil },
+			},
+			wantStatus: StatusOK,
+		},
+		{
+			name: "Dependency down",
+			checks: map[string]CheckFunc{
+				"mongodb": func(ctx context.Context) error { return fmt.Errorf("server selection error") },
+			},
+			wantStatus:  StatusUnavailable,
+			wantReasons: map[string]string{"mongodb": ReasonCheckFailed},
+		},
+		{
+			name: "Dependency hangs past the timeout",
+			checks: map[string]CheckFunc{
+				"mongodb": func(ctx context.Context) error { time.Sleep(time.Second); return nil },
+			},
+			wantStatus:  StatusUnavailable,
+			wantReasons: map[string]string{"mongodb": ReasonTimeout},
+		},
+		{
+			name: "Draining skips checks",
+			checks: map[string]CheckFunc{
+				"mongodb": func(ctx context.Context) error { t.Error("check ran while draining"); return nil },
+			},
+			drain:      true,
+			wantStatus: StatusDraining,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readiness := NewReadiness(20*time.Millisecond, 0)
+			for name, fn := range tt.checks {
+				readiness.Register(name, fn)
+			}
+			if tt.drain {
+				readiness.Drain()
+			}
+
+			report := readiness.Check(context.Background())
+
+			assert.Equal(t, tt.wantStatus, report.Status)
+			for _, dependency := range report.Checks {
+				assert.Equal(t, tt.wantReasons[dependency.Name], dependency.Reason)
+				assert.NotEmpty(t, dependency.Latency)
+			}
+		})
+	}
+}
+
+func TestReadiness_Nil(t *testing.T) {
+	var readiness *Readiness
+	readiness.Drain()
+
+	assert.False(t, readiness.Draining())
+	assert.Equal(t, StatusOK, readiness.Check(context.Background()).Status)
+}
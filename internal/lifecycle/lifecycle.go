@@ -0,0 +1,220 @@
+// Package lifecycle runs the service's listeners until a termination signal and then shuts the
+// process down in a fixed order: report not-ready, stop accepting and drain in-flight work within
+// a deadline, then run the registered shutdown hooks one by one.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"food-roulette-api/internal/health"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Exit codes returned by ExitCode.
+const (
+	ExitOK = 0
+	// ExitServeFailed means a listener could not start or stopped on its own.
+	ExitServeFailed = 1
+	// ExitUsage means the configuration or command line was invalid; cmd/svr exits with it before Run.
+	ExitUsage = 2
+	// ExitShutdownIncomplete means in-flight work was cut off by the drain timeout or a hook failed.
+	ExitShutdownIncomplete = 3
+)
+
+// ServeError reports a listener that failed instead of being stopped by the Manager.
+type ServeError struct {
+	Server string
+	Err    error
+}
+
+func (e *ServeError) Error() string {
+	return fmt.Sprintf("%v server stopped: %v", e.Server, e.Err.Error())
+}
+
+func (e *ServeError) Unwrap() error {
+	return e.Err
+}
+
+// ShutdownError lists the servers that did not drain in time and the hooks that failed.
+type ShutdownError []string
+
+func (e ShutdownError) Error() string {
+	return "shutdown incomplete:\n  " + strings.Join(e, "\n  ")
+}
+
+// ExitCode maps the result of Run to the process exit status.
+func ExitCode(err error) int {
+	switch err.(type) {
+	case nil:
+		return ExitOK
+	case ShutdownError:
+		return ExitShutdownIncomplete
+	default:
+		return ExitServeFailed
+	}
+}
+
+type server struct {
+	name     string
+	serve    func() error
+	shutdown func(ctx context.Context) error
+}
+
+type hook struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Manager owns the process lifecycle. Register listeners with Serve and cleanup with OnShutdown,
+// then call Run once.
+type Manager struct {
+	// Readiness is drained first so load balancers stop routing; the listeners keep serving for
+	// its DrainDelay.
+	Readiness *health.Readiness
+	// DrainTimeout bounds how long the listeners wait for in-flight work once they stop accepting.
+	// Zero waits indefinitely.
+	DrainTimeout time.Duration
+	// HookTimeout bounds each shutdown hook. Zero waits indefinitely.
+	HookTimeout time.Duration
+	// Signals start the shutdown; SIGINT and SIGTERM when empty. A second one skips what is
+	// left of the drain.
+	Signals []os.Signal
+
+	servers []server
+	hooks   []hook
+}
+
+// Serve registers a listener. serve blocks until the listener stops and returns nil when that
+// was caused by shutdown; shutdown stops accepting, waits for in-flight work until ctx is done
+// and then forces the remaining connections closed.
+func (m *Manager) Serve(name string, serve func() error, shutdown func(ctx context.Context) error) {
+	m.servers = append(m.servers, server{name: name, serve: serve, shutdown: shutdown})
+}
+
+// OnShutdown registers a hook that runs after every listener has stopped, in registration order,
+// such as flushing telemetry or closing the database connection.
+func (m *Manager) OnShutdown(name string, run func(ctx context.Context) error) {
+	m.hooks = append(m.hooks, hook{name: name, run: run})
+}
+
+// Run starts every listener and blocks until a signal arrives or a listener fails, then shuts
+// down. It returns nil after a clean shutdown, a *ServeError when a listener failed and a
+// ShutdownError when the shutdown did not complete; see ExitCode.
+func (m *Manager) Run() error {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, m.signals()...)
+	defer signal.Stop(signals)
+
+	failures := make(chan error, len(m.servers))
+	var running sync.WaitGroup
+	for _, s := range m.servers {
+		running.Add(1)
+		go func(s server) {
+			defer running.Done()
+			if err := s.serve(); err != nil {
+				failures <- &ServeError{Server: s.name, Err: err}
+			}
+		}(s)
+	}
+
+	var failure error
+	select {
+	case sig := <-signals:
+		log.Infof("%v received, shutting down", sig)
+	case failure = <-failures:
+		log.Errorf("%v, shutting down", failure.Error())
+	}
+
+	ctx, force := context.WithCancel(context.Background())
+	defer force()
+	go func() {
+		select {
+		case sig := <-signals:
+			log.Warnf("%v received again, closing open connections", sig)
+			force()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := m.shutdown(ctx, failure == nil)
+	running.Wait()
+	if failure != nil {
+		return failure
+	}
+	return err
+}
+
+func (m *Manager) signals() []os.Signal {
+	if len(m.Signals) == 0 {
+		return []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	return m.Signals
+}
+
+// shutdown drains the listeners and runs the hooks. The drain delay is skipped after a listener
+// failure since the replica is not serving properly anyway.
+func (m *Manager) shutdown(ctx context.Context, delay bool) error {
+	m.Readiness.Drain()
+	if delay && m.Readiness != nil && m.Readiness.DrainDelay > 0 {
+		log.Infof("reporting not ready for %v before closing listeners", m.Readiness.DrainDelay)
+		select {
+		case <-time.After(m.Readiness.DrainDelay):
+		case <-ctx.Done():
+		}
+	}
+
+	var problems ShutdownError
+	var mu sync.Mutex
+	report := func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	drainCtx, cancel := withTimeout(ctx, m.DrainTimeout)
+	var stopping sync.WaitGroup
+	for _, s := range m.servers {
+		stopping.Add(1)
+		go func(s server) {
+			defer stopping.Done()
+			if err := s.shutdown(drainCtx); err != nil {
+				log.Errorf("%v server did not drain: %v", s.name, err.Error())
+				report("%v server: %v", s.name, err.Error())
+			}
+		}(s)
+	}
+	stopping.Wait()
+	cancel()
+	log.Infoln("listeners stopped")
+
+	for _, h := range m.hooks {
+		hookCtx, cancel := withTimeout(ctx, m.HookTimeout)
+		err := h.run(hookCtx)
+		cancel()
+		if err != nil {
+			log.Errorf("shutdown hook %v failed: %v", h.name, err.Error())
+			report("%v: %v", h.name, err.Error())
+			continue
+		}
+		log.Infof("shutdown hook %v done", h.name)
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+	log.Infoln("graceful shutdown complete")
+	return nil
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,251 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"food-roulette-api/internal/health"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// serveHTTP registers a test server on a free port with m and returns its URL and the server.
+func serveHTTP(t *testing.T, m *Manager, handler http.Handler) (string, *http.Server) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Handler: handler}
+	m.Serve("http", func() error {
+		if err := srv.Serve(listener); err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	}, func(ctx context.Context) error {
+		err := srv.Shutdown(ctx)
+		if err != nil {
+			_ = srv.Close()
+		}
+		return err
+	})
+	return "http://" + listener.Addr().String(), srv
+}
+
+// run starts m and waits until url answers, so the signal handler is installed.
+func run(t *testing.T, m *Manager, url string) <-chan error {
+	t.Helper()
+	result := make(chan error, 1)
+	go func() {
+		result <- m.Run()
+	}()
+	assert.Eventually(t, func() bool {
+		res, err := http.Get(url + "/ping")
+		if err != nil {
+			return false
+		}
+		_ = res.Body.Close()
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+	return result
+}
+
+func terminate(t *testing.T) {
+	t.Helper()
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func wait(t *testing.T, result <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("manager did not return")
+		return nil
+	}
+}
+
+// recorder collects the order in which hooks ran.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) hook(name string, err error) func(context.Context) error {
+	return func(ctx context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.calls = append(r.calls, name)
+		return err
+	}
+}
+
+func (r *recorder) ran() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+func TestManager_Run_DrainsInFlightRequests(t *testing.T) {
+	readiness := health.NewReadiness(time.Second, 100*time.Millisecond)
+	m := &Manager{Readiness: readiness, DrainTimeout: 2 * time.Second, HookTimeout: time.Second}
+	started := make(chan struct{})
+	url, _ := serveHTTP(t, m, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			close(started)
+			time.Sleep(300 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	calls := &recorder{}
+	m.OnShutdown("flush traces", calls.hook("flush traces", nil))
+	m.OnShutdown("disconnect mongodb", calls.hook("disconnect mongodb", nil))
+	result := run(t, m, url)
+
+	slow := make(chan int, 1)
+	go func() {
+		res, err := http.Get(url + "/slow")
+		if err != nil {
+			slow <- 0
+			return
+		}
+		_ = res.Body.Close()
+		slow <- res.StatusCode
+	}()
+	<-started
+	terminate(t)
+
+	assert.Eventually(t, readiness.Draining, time.Second, 5*time.Millisecond)
+	// hooks wait for the listeners, which wait for the request in flight
+	assert.Empty(t, calls.ran())
+
+	err := wait(t, result)
+	assert.NoError(t, err)
+	assert.Equal(t, ExitOK, ExitCode(err))
+	assert.Equal(t, http.StatusNoContent, <-slow)
+	assert.Equal(t, []string{"flush traces", "disconnect mongodb"}, calls.ran())
+}
+
+func TestManager_Run_DrainTimeout(t *testing.T) {
+	m := &Manager{DrainTimeout: 100 * time.Millisecond}
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	url, _ := serveHTTP(t, m, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/stuck" {
+			close(started)
+			<-release
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	calls := &recorder{}
+	m.OnShutdown("disconnect mongodb", calls.hook("disconnect mongodb", nil))
+	result := run(t, m, url)
+
+	stuck := make(chan error, 1)
+	go func() {
+		res, err := http.Get(url + "/stuck")
+		if err == nil {
+			_ = res.Body.Close()
+		}
+		stuck <- err
+	}()
+	<-started
+	begun := time.Now()
+	terminate(t)
+
+	err := wait(t, result)
+	assert.Less(t, int64(time.Since(begun)), int64(time.Second), "the drain stops at DrainTimeout")
+	assert.Equal(t, ShutdownError{"http server: context deadline exceeded"}, err)
+	assert.Equal(t, ExitShutdownIncomplete, ExitCode(err))
+	assert.Error(t, <-stuck, "the stuck connection is closed")
+	assert.Equal(t, []string{"disconnect mongodb"}, calls.ran(), "hooks run after a forced close")
+}
+
+func TestManager_Run_HookFailure(t *testing.T) {
+	m := &Manager{DrainTimeout: time.Second, HookTimeout: 50 * time.Millisecond}
+	url, _ := serveHTTP(t, m, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	calls := &recorder{}
+	m.OnShutdown("flush traces", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	m.OnShutdown("disconnect mongodb", calls.hook("disconnect mongodb", errors.New("connection reset")))
+	result := run(t, m, url)
+	terminate(t)
+
+	err := wait(t, result)
+	assert.Equal(t, ShutdownError{
+		"flush traces: context deadline exceeded",
+		"disconnect mongodb: connection reset",
+	}, err)
+	assert.Equal(t, ExitShutdownIncomplete, ExitCode(err))
+	assert.Equal(t, []string{"disconnect mongodb"}, calls.ran(), "a failed hook does not stop the next")
+}
+
+func TestManager_Run_SecondSignalForces(t *testing.T) {
+	m := &Manager{}
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	url, srv := serveHTTP(t, m, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/stuck" {
+			close(started)
+			<-release
+		}
+	}))
+	draining := make(chan struct{})
+	srv.RegisterOnShutdown(func() { close(draining) })
+	result := run(t, m, url)
+
+	go func() {
+		res, err := http.Get(url + "/stuck")
+		if err == nil {
+			_ = res.Body.Close()
+		}
+	}()
+	<-started
+	terminate(t)
+	<-draining
+	// without a DrainTimeout only a second signal ends the wait
+	terminate(t)
+
+	err := wait(t, result)
+	assert.Equal(t, ShutdownError{"http server: context canceled"}, err)
+}
+
+func TestManager_Run_ServeFailure(t *testing.T) {
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer busy.Close()
+
+	readiness := health.NewReadiness(time.Second, time.Hour)
+	m := &Manager{Readiness: readiness, DrainTimeout: time.Second}
+	srv := &http.Server{Addr: busy.Addr().String()}
+	m.Serve("http", srv.ListenAndServe, srv.Shutdown)
+	calls := &recorder{}
+	m.OnShutdown("disconnect mongodb", calls.hook("disconnect mongodb", nil))
+
+	result := make(chan error, 1)
+	go func() {
+		result <- m.Run()
+	}()
+	err = wait(t, result)
+
+	var serveErr *ServeError
+	if assert.True(t, errors.As(err, &serveErr), "got %v", err) {
+		assert.Equal(t, "http", serveErr.Server)
+	}
+	assert.Equal(t, ExitServeFailed, ExitCode(err))
+	assert.True(t, readiness.Draining())
+	assert.Equal(t, []string{"disconnect mongodb"}, calls.ran(), "hooks run without waiting for the drain delay")
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const namespace = "meal_picker"
+
+// Metrics owns the Prometheus collectors of the service. All methods are safe to call on a nil
+// *Metrics so instrumentation can be left unwired in tests and tools.
+type Metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestLatency  *prometheus.HistogramVec
+	mongoLatency    *prometheus.HistogramVec
+	mongoErrors     *prometheus.CounterVec
+	picksServed     *prometheus.CounterVec
+	cuisinesCreated prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "status"}),
+		requestLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		mongoLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "mongo_operation_duration_seconds",
+			Help:      "Latency of storage operations by operation name.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		mongoErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mongo_operation_errors_total",
+			Help:      "Failed storage operations by operation name.",
+		}, []string{"operation"}),
+		picksServed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "picks_served_total",
+			Help:      "Random picks served, by whether a dish was included.",
+		}, []string{"with_dish"}),
+		cuisinesCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cuisines_created_total",
+			Help:      "Cuisines added to the database.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestLatency,
+		m.mongoLatency,
+		m.mongoErrors,
+		m.picksServed,
+		m.cuisinesCreated,
+	)
+
+	return m
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	if m == nil {
+		return http.NotFoundHandler()
+	}
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) ObserveRequest(route string, method string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	code := strconv.Itoa(status)
+	m.requests.WithLabelValues(route, method, code).Inc()
+	m.requestLatency.WithLabelValues(route, method, code).Observe(duration.Seconds())
+}
+
+// Observe times a storage operation; call the returned func with the operation's error.
+func (m *Metrics) Observe(ctx context.Context, operation string) (context.Context, func(err error)) {
+	if m == nil {
+		return ctx, func(error) {}
+	}
+	start := time.Now()
+	return ctx, func(err error) {
+		m.mongoLatency.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+		if err != nil {
+			m.mongoErrors.WithLabelValues(operation).Inc()
+		}
+	}
+}
+
+func (m *Metrics) PickServed(withDish bool) {
+	if m == nil {
+		return
+	}
+	m.picksServed.WithLabelValues(strconv.FormatBool(withDish)).Inc()
+}
+
+func (m *Metrics) CuisineCreated() {
+	if m == nil {
+		return
+	}
+	m.cuisinesCreated.Inc()
+}
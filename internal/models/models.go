@@ -2,6 +2,7 @@ package models
 
 import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
 )
 
 type Cuisine struct {
@@ -10,11 +11,126 @@ type Cuisine struct {
 	Type   string             `bson:"type,omitempty" json:"type,omitempty"`
 	Dishes []Dish             `bson:"dishes,omitempty" json:"dishes,omitempty"`
 	Tags   []string           `bson:"tags,omitempty" json:"tags,omitempty"`
+
+	HouseholdID primitive.ObjectID `bson:"householdId,omitempty" json:"householdId,omitempty"`
+	CreatedBy   string             `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	CreatedAt   *time.Time         `bson:"createdAt,omitempty" json:"createdAt,omitempty"`
+
+	// Rating is aggregated from the ratings collection and never stored on the cuisine
+	Rating *RatingSummary `bson:"-" json:"rating,omitempty"`
 }
 
 type Dish struct {
 	ID      primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
 	Cuisine primitive.ObjectID `bson:"cuisine,omitempty" json:"cuisine,omitempty"`
-	Name    string             `bson:"name,omitempty" json:"name,omitempty"`
-	Tags    []string           `bson:"tags,omitempty" json:"tags,omitempty"`
+	Name    string             `bson:"name,omitempty" json:"name,omitempty" validate:"required,min=2,max=64,name"`
+	Tags    []string           `bson:"tags,omitempty" json:"tags,omitempty" validate:"max=10,unique,dive,tag"`
+
+	HouseholdID primitive.ObjectID `bson:"householdId,omitempty" json:"householdId,omitempty"`
+
+	// Rating is aggregated from the ratings collection and never stored on the dish
+	Rating *RatingSummary `bson:"-" json:"rating,omitempty"`
+}
+
+type ApiKey struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Name      string             `bson:"name,omitempty" json:"name,omitempty"`
+	Prefix    string             `bson:"prefix,omitempty" json:"prefix,omitempty"`
+	Hash      string             `bson:"hash,omitempty" json:"-"`
+	Role      string             `bson:"role,omitempty" json:"role,omitempty"`
+	CreatedBy string             `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	CreatedAt time.Time          `bson:"createdAt,omitempty" json:"createdAt,omitempty"`
+	RevokedAt *time.Time         `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+}
+
+type User struct {
+	ID               primitive.ObjectID   `bson:"_id,omitempty" json:"_id,omitempty"`
+	Subject          string               `bson:"subject,omitempty" json:"subject,omitempty"`
+	FavoriteCuisines []primitive.ObjectID `bson:"favoriteCuisines,omitempty" json:"favoriteCuisines,omitempty"`
+	DislikedCuisines []primitive.ObjectID `bson:"dislikedCuisines,omitempty" json:"dislikedCuisines,omitempty"`
+	FavoriteDishes   []primitive.ObjectID `bson:"favoriteDishes,omitempty" json:"favoriteDishes,omitempty"`
+	DislikedDishes   []primitive.ObjectID `bson:"dislikedDishes,omitempty" json:"dislikedDishes,omitempty"`
+	UpdatedAt        *time.Time           `bson:"updatedAt,omitempty" json:"updatedAt,omitempty"`
+}
+
+type Household struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Name      string             `bson:"name,omitempty" json:"name,omitempty"`
+	Members   []Member           `bson:"members,omitempty" json:"members,omitempty"`
+	CreatedBy string             `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	CreatedAt *time.Time         `bson:"createdAt,omitempty" json:"createdAt,omitempty"`
+}
+
+type Member struct {
+	Subject   string     `bson:"subject,omitempty" json:"subject,omitempty"`
+	Role      string     `bson:"role,omitempty" json:"role,omitempty"`
+	InvitedBy string     `bson:"invitedBy,omitempty" json:"invitedBy,omitempty"`
+	JoinedAt  *time.Time `bson:"joinedAt,omitempty" json:"joinedAt,omitempty"`
+}
+
+type Rating struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Dish        primitive.ObjectID `bson:"dish,omitempty" json:"dish,omitempty"`
+	Cuisine     primitive.ObjectID `bson:"cuisine,omitempty" json:"cuisine,omitempty"`
+	Subject     string             `bson:"subject,omitempty" json:"subject,omitempty"`
+	Score       int                `bson:"score,omitempty" json:"score,omitempty"`
+	Note        string             `bson:"note,omitempty" json:"note,omitempty"`
+	HouseholdID primitive.ObjectID `bson:"householdId,omitempty" json:"householdId,omitempty"`
+	CreatedAt   *time.Time         `bson:"createdAt,omitempty" json:"createdAt,omitempty"`
+	UpdatedAt   *time.Time         `bson:"updatedAt,omitempty" json:"updatedAt,omitempty"`
+}
+
+type RatingSummary struct {
+	Dish    primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	Cuisine primitive.ObjectID `bson:"cuisine,omitempty" json:"-"`
+	Average float64            `bson:"average" json:"average"`
+	Count   int                `bson:"count" json:"count"`
+}
+
+// Migration is a versioned change to the database layout, such as an index. AppliedAt is unset
+// while it is pending.
+type Migration struct {
+	Version     int        `bson:"_id" json:"version"`
+	Description string     `bson:"description" json:"description"`
+	AppliedAt   *time.Time `bson:"appliedAt,omitempty" json:"appliedAt,omitempty"`
+}
+
+// Webhook receives a signed POST for every event it subscribes to in its household. Secret keys
+// the signatures and is only returned when the webhook is registered.
+type Webhook struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	URL         string             `bson:"url,omitempty" json:"url,omitempty"`
+	Events      []string           `bson:"events,omitempty" json:"events,omitempty"`
+	Secret      string             `bson:"secret,omitempty" json:"-"`
+	HouseholdID primitive.ObjectID `bson:"householdId,omitempty" json:"householdId,omitempty"`
+	CreatedBy   string             `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	CreatedAt   *time.Time         `bson:"createdAt,omitempty" json:"createdAt,omitempty"`
+}
+
+// Delivery states.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookDelivery is one event queued for one webhook, and its log once attempted. A pending
+// delivery is sent at NextAttemptAt.
+type WebhookDelivery struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Webhook     primitive.ObjectID `bson:"webhook,omitempty" json:"webhook,omitempty"`
+	HouseholdID primitive.ObjectID `bson:"householdId,omitempty" json:"householdId,omitempty"`
+	EventID     string             `bson:"eventId,omitempty" json:"eventId,omitempty"`
+	Event       string             `bson:"event,omitempty" json:"event,omitempty"`
+	// Payload is the exact JSON body that is signed and sent.
+	Payload       string     `bson:"payload,omitempty" json:"payload,omitempty"`
+	Status        string     `bson:"status,omitempty" json:"status,omitempty"`
+	Attempts      int        `bson:"attempts" json:"attempts"`
+	NextAttemptAt *time.Time `bson:"nextAttemptAt,omitempty" json:"nextAttemptAt,omitempty"`
+	// LastStatusCode and LastError describe the latest attempt; LastError is empty on success.
+	LastAttemptAt  *time.Time `bson:"lastAttemptAt,omitempty" json:"lastAttemptAt,omitempty"`
+	LastStatusCode int        `bson:"lastStatusCode,omitempty" json:"lastStatusCode,omitempty"`
+	LastError      string     `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	CreatedAt      *time.Time `bson:"createdAt,omitempty" json:"createdAt,omitempty"`
+	DeliveredAt    *time.Time `bson:"deliveredAt,omitempty" json:"deliveredAt,omitempty"`
 }
@@ -1,15 +1,83 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
 
 type AddCuisineRequest struct {
-	Name   string   `json:"name,omitempty"`
-	Dishes []Dish   `json:"dishes,omitempty"`
-	Tags   []string `json:"tags,omitempty"`
+	Name   string   `json:"name,omitempty" validate:"required,min=2,max=64,name"`
+	Dishes []Dish   `json:"dishes,omitempty" validate:"max=100,uniquenames,dive"`
+	Tags   []string `json:"tags,omitempty" validate:"max=10,unique,dive,tag"`
+
+	// Audit fields are stamped by the service and never read from the request body
+	CreatedBy string     `json:"-" bson:"createdBy,omitempty"`
+	CreatedAt *time.Time `json:"-" bson:"createdAt,omitempty"`
 }
 
 type AddDishesRequest struct {
-	Cuisine primitive.ObjectID `json:"cuisine,omitempty"`
-	Name    string             `json:"name,omitempty"`
-	Dishes  []Dish             `json:"dishes,omitempty"`
+	Cuisine primitive.ObjectID `json:"cuisine,omitempty" validate:"required"`
+	Name    string             `json:"name,omitempty" validate:"omitempty,max=64,name"`
+	Dishes  []Dish             `json:"dishes,omitempty" validate:"required,max=100,uniquenames,dive"`
+}
+
+type RenameRequest struct {
+	Name string `json:"name,omitempty" validate:"required,min=2,max=64,name"`
+}
+
+// Catalog is the portable form of cuisines and their dishes used by import and export. It
+// carries no ids, so it can be loaded into another database or household.
+type Catalog struct {
+	Cuisines []AddCuisineRequest `json:"cuisines" validate:"max=1000,uniquenames,dive"`
+}
+
+type IssueKeyRequest struct {
+	Name string `json:"name,omitempty" validate:"required,min=2,max=64,name"`
+	Role string `json:"role,omitempty" validate:"required,oneof=reader editor admin"`
+}
+
+const (
+	PreferenceTargetCuisine = "cuisine"
+	PreferenceTargetDish    = "dish"
+
+	PreferenceFavorite = "favorite"
+	PreferenceDislike  = "dislike"
+	PreferenceNone     = "none"
+)
+
+type SetPreferenceRequest struct {
+	Target     string `json:"target,omitempty" validate:"required,oneof=cuisine dish"`
+	ID         string `json:"id,omitempty" validate:"required,objectid"`
+	Preference string `json:"preference,omitempty" validate:"required,oneof=favorite dislike none"`
+}
+
+type PickRequest struct {
+	Tags      []string `json:"tags,omitempty" validate:"max=10,dive,min=1,max=32"`
+	MinRating float64  `json:"minRating,omitempty" validate:"min=0,max=5"`
+}
+
+type AddHouseholdRequest struct {
+	Name string `json:"name,omitempty" validate:"required,min=2,max=64,name"`
+}
+
+type InviteMemberRequest struct {
+	Subject string `json:"subject,omitempty" validate:"required,max=256"`
+	Role    string `json:"role,omitempty" validate:"required,oneof=reader editor admin"`
+}
+
+type RateDishRequest struct {
+	Score int    `json:"score,omitempty" validate:"required,min=1,max=5"`
+	Note  string `json:"note,omitempty" validate:"max=500"`
+}
+
+// Webhook events. A plan.generated event will join them with meal plans, which the API does not
+// have yet.
+const (
+	EventPickMade     = "pick.made"
+	EventCuisineAdded = "cuisine.added"
+)
+
+type AddWebhookRequest struct {
+	URL    string   `json:"url,omitempty" validate:"required,max=2048,url,startswith=https://"`
+	Events []string `json:"events,omitempty" validate:"required,min=1,unique,dive,oneof=pick.made cuisine.added"`
 }
@@ -10,16 +10,133 @@ type AllCuisinesResponse struct {
 	Message  Message
 }
 
+type DishResponse struct {
+	Dish    *Dish
+	Message Message
+}
+
+type AllDishesResponse struct {
+	Dishes  []Dish
+	Message Message
+}
+
+type CatalogResponse struct {
+	Catalog *Catalog
+	Message Message
+}
+
+// ImportResponse lists the cuisines an import created and those it left alone because a cuisine
+// with the same name already existed. Seeding also reports existing cuisines it added dishes to
+// as Updated.
+type ImportResponse struct {
+	Created []string
+	Updated []string
+	Skipped []string
+	Message Message
+}
+
+type MigrationsResponse struct {
+	Migrations []*Migration
+	Message    Message
+}
+
 type Message struct {
 	ErrorLog  []ErrorLog `json:"ErrorLog,omitempty"`
 	HostName  string     `json:"HostName,omitempty"`
 	Status    string     `json:"Status,omitempty"`
 	TimeTaken string     `json:"TimeTaken,omitempty"`
 	Count     int        `json:"Count,omitempty"`
+	RequestID string     `json:"RequestID,omitempty"`
+}
+
+// Problem is an RFC 7807 problem details document. Message repeats the legacy envelope so
+// existing clients keep working while they move to the standard members.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	RequestID string `json:"requestId,omitempty"`
+	// InvalidParams lists every field that failed validation.
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+	Message       Message        `json:"Message"`
+}
+
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
 }
 
 type ErrorLog struct {
 	Status    string `json:"Status,omitempty"`
+	Code      string `json:"Code,omitempty"`
+	Field     string `json:"Field,omitempty"`
 	Trace     string `json:"Trace,omitempty"`
 	RootCause string `json:"RootCause,omitempty"`
 }
+
+type ApiKeyResponse struct {
+	ApiKey  *ApiKey
+	Key     string `json:"Key,omitempty"`
+	Message Message
+}
+
+type AllApiKeysResponse struct {
+	ApiKeys []*ApiKey
+	Message Message
+}
+
+type UserResponse struct {
+	User    *User
+	Message Message
+}
+
+type PickResponse struct {
+	Cuisine *Cuisine
+	Dish    *Dish
+	Message Message
+}
+
+type HouseholdResponse struct {
+	Household *Household
+	Message   Message
+}
+
+type AllHouseholdsResponse struct {
+	Households []*Household
+	Message    Message
+}
+
+type RatingResponse struct {
+	Rating  *Rating
+	Message Message
+}
+
+type AllRatingsResponse struct {
+	Ratings []*Rating
+	Message Message
+}
+
+type WebhookResponse struct {
+	Webhook *Webhook
+	// Secret is only returned when the webhook is registered.
+	Secret  string `json:"Secret,omitempty"`
+	Message Message
+}
+
+type AllWebhooksResponse struct {
+	Webhooks []*Webhook
+	Message  Message
+}
+
+type DeliveryResponse struct {
+	Delivery *WebhookDelivery
+	Message  Message
+}
+
+type AllDeliveriesResponse struct {
+	Deliveries []*WebhookDelivery
+	Message    Message
+}
@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"sync"
+)
+
+// YAML is the OpenAPI 3 document describing the HTTP API. Keep it in sync with
+// routes.InitializeRoutes; the routes contract tests fail when they drift apart.
+//
+//go:embed openapi.yaml
+var YAML []byte
+
+//go:embed docs.html
+var DocsHTML []byte
+
+// SwaggerUI holds the swagger-ui-dist 4.15.5 files the docs page loads from /api/docs/, vendored
+// so the page works offline and never runs third-party code fetched at request time. Only the
+// bundle and stylesheet are embedded; swagger-ui/LICENSE is kept next to them. To upgrade, change
+// the version below and run go generate.
+//
+//go:generate sh -c "curl -sSfL https://registry.npmjs.org/swagger-ui-dist/-/swagger-ui-dist-4.15.5.tgz | tar -xzf - -C swagger-ui --strip-components=1 package/LICENSE package/swagger-ui.css package/swagger-ui-bundle.js"
+//go:embed swagger-ui/swagger-ui-bundle.js swagger-ui/swagger-ui.css
+var SwaggerUI embed.FS
+
+var (
+	specOnce sync.Once
+	specJSON []byte
+	specErr  error
+)
+
+// JSON returns the document converted to JSON, as served at /api/openapi.json.
+func JSON() ([]byte, error) {
+	specOnce.Do(func() {
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal(YAML, &doc); err != nil {
+			specErr = fmt.Errorf("error decoding openapi document; err: %v", err.Error())
+			return
+		}
+		specJSON, specErr = json.Marshal(doc)
+	})
+	return specJSON, specErr
+}
@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often idle buckets are dropped from a MemoryStore.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+// MemoryStore keeps buckets in process memory, so each replica enforces its own limits.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, now: time.Now}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, found := s.buckets[key]
+	if !found {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	b.refill(now, limit)
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return b.result(allowed), nil
+}
+
+// Peek reports on the bucket identified by key as Take would, without taking a token.
+func (s *MemoryStore) Peek(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := bucket{tokens: float64(limit.Burst), updated: s.now()}
+	if found, ok := s.buckets[key]; ok {
+		b = *found
+	}
+	b.refill(s.now(), limit)
+	return b.result(b.tokens >= 1), nil
+}
+
+func (b *bucket) refill(now time.Time, limit Limit) {
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	b.updated = now
+	b.limit = limit
+}
+
+func (b *bucket) result(allowed bool) Result {
+	result := Result{Allowed: allowed, Limit: b.limit.Burst, Remaining: int(b.tokens)}
+	if !allowed {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / b.limit.Rate)
+	}
+	result.Reset = secondsToDuration((float64(b.limit.Burst) - b.tokens) / b.limit.Rate)
+	return result
+}
+
+// sweep drops buckets that have refilled completely; a full bucket is indistinguishable from a
+// new one, so forgetting it changes nothing but memory use.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*b.limit.Rate >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	limit := Limit{Rate: 2, Burst: 3}
+
+	for remaining := 2; remaining >= 0; remaining-- {
+		result, err := store.Take(context.Background(), "ip:10.0.0.1", limit)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, remaining, result.Remaining)
+	}
+
+	result, _ := store.Take(context.Background(), "ip:10.0.0.1", limit)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, result.Reset)
+
+	// other callers have their own bucket
+	result, _ = store.Take(context.Background(), "ip:10.0.0.2", limit)
+	assert.True(t, result.Allowed)
+
+	now = now.Add(500 * time.Millisecond)
+	result, _ = store.Take(context.Background(), "ip:10.0.0.1", limit)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+}
+
+func TestMemoryStore_Peek(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	limit := Limit{Rate: 2, Burst: 1}
+
+	result, err := store.Peek(context.Background(), "ip:10.0.0.1", limit)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+	assert.Empty(t, store.buckets, "peeking must not create buckets")
+
+	_, _ = store.Take(context.Background(), "ip:10.0.0.1", limit)
+	for i := 0; i < 2; i++ {
+		result, _ = store.Peek(context.Background(), "ip:10.0.0.1", limit)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	result, _ = store.Peek(context.Background(), "ip:10.0.0.1", limit)
+	assert.True(t, result.Allowed)
+	result, _ = store.Take(context.Background(), "ip:10.0.0.1", limit)
+	assert.True(t, result.Allowed, "peeking must not take the refilled token")
+}
+
+func TestMemoryStore_SweepsFullBuckets(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	_, _ = store.Take(context.Background(), "key:slow", Limit{Rate: 0.001, Burst: 5})
+	_, _ = store.Take(context.Background(), "ip:fast", Limit{Rate: 10, Burst: 5})
+	assert.Len(t, store.buckets, 2)
+
+	now = now.Add(2 * sweepInterval)
+	_, _ = store.Take(context.Background(), "ip:other", Limit{Rate: 10, Burst: 5})
+	assert.Contains(t, store.buckets, "key:slow")
+	assert.NotContains(t, store.buckets, "ip:fast")
+}
+
+func TestLimiter_Take(t *testing.T) {
+	limiter := &Limiter{
+		Store:  NewMemoryStore(),
+		PerKey: Limit{Rate: 1, Burst: 10},
+		PerIP:  Limit{Rate: 1, Burst: 2},
+	}
+
+	result, limited, err := limiter.Take(context.Background(), "editor", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.True(t, limited)
+	assert.Equal(t, 10, result.Limit)
+
+	result, limited, _ = limiter.Take(context.Background(), "", "10.0.0.1")
+	assert.True(t, limited)
+	assert.Equal(t, 2, result.Limit)
+
+	_, limited, _ = (&Limiter{Store: NewMemoryStore(), PerKey: Limit{Rate: 1, Burst: 10}}).Take(context.Background(), "", "10.0.0.1")
+	assert.False(t, limited)
+
+	var disabled *Limiter
+	_, limited, _ = disabled.Take(context.Background(), "editor", "10.0.0.1")
+	assert.False(t, limited)
+}
+
+func TestLimiter_PeekIP(t *testing.T) {
+	limiter := &Limiter{Store: NewMemoryStore(), PerIP: Limit{Rate: 1, Burst: 1}}
+
+	_, _, _ = limiter.Take(context.Background(), "", "10.0.0.1")
+	result, limited, err := limiter.PeekIP(context.Background(), "10.0.0.1")
+	assert.NoError(t, err)
+	assert.True(t, limited)
+	assert.False(t, result.Allowed)
+
+	_, limited, _ = (&Limiter{Store: NewMemoryStore(), PerKey: Limit{Rate: 1, Burst: 10}}).PeekIP(context.Background(), "10.0.0.1")
+	assert.False(t, limited)
+}
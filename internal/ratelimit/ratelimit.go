@@ -0,0 +1,79 @@
+// Package ratelimit implements token-bucket request limits behind a Store so the in-process
+// buckets can later be replaced by a store shared between replicas.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket holding up to Burst requests and refilling at Rate requests per second.
+// A zero Rate disables the limit.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// Result describes a bucket after a request has been counted against it.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is how long until the next request would be allowed; zero when Allowed.
+	RetryAfter time.Duration
+}
+
+// Store takes one token from the bucket identified by key. Peek reports on the bucket the same
+// way without taking a token.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+	Peek(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Limiter applies PerKey to authenticated callers and PerIP to anonymous ones. A nil *Limiter
+// allows every request.
+type Limiter struct {
+	Store  Store
+	PerKey Limit
+	PerIP  Limit
+}
+
+// Take counts a request from the caller identified by subject, or by ip when subject is empty.
+// The returned bool is false when no limit applies to the caller.
+func (l *Limiter) Take(ctx context.Context, subject string, ip string) (Result, bool, error) {
+	if l == nil || l.Store == nil {
+		return Result{}, false, nil
+	}
+	key, limit := "ip:"+ip, l.PerIP
+	if subject != "" {
+		key, limit = "key:"+subject, l.PerKey
+	}
+	if !limit.Enabled() {
+		return Result{}, false, nil
+	}
+	result, err := l.Store.Take(ctx, key, limit)
+	return result, true, err
+}
+
+// PeekIP reports whether the client IP's bucket still has a token, without taking one, so callers
+// can turn away an exhausted IP before doing any work for it. The returned bool is false when no
+// per-IP limit applies.
+func (l *Limiter) PeekIP(ctx context.Context, ip string) (Result, bool, error) {
+	if l == nil || l.Store == nil || !l.PerIP.Enabled() {
+		return Result{}, false, nil
+	}
+	result, err := l.Store.Peek(ctx, "ip:"+ip, l.PerIP)
+	return result, true, err
+}
+
+// Seconds rounds d up to whole seconds, as the Retry-After and RateLimit-Reset headers require.
+func Seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,97 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// Authenticate attaches the caller's principal to the request context when valid credentials
+// are supplied. It never rejects a request itself; routes opt in to protection with RequireRole.
+func (h Handler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := bearerTokenFromRequest(r); token != "" && h.Verifier != nil {
+			principal, err := h.Verifier.Verify(r.Context(), token)
+			if err != nil {
+				logrus.Debugf("bearer token rejected: %v", err.Error())
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+			return
+		}
+
+		key := apiKeyFromRequest(r)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, err := h.Service.Authenticate(r.Context(), key)
+		if err != nil {
+			if !errors.Is(err, facade.ErrInvalidApiKey) {
+				logrus.Errorf("api key lookup failed: %v", err.Error())
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// RequireRole rejects requests whose principal is missing (401) or lacks the given role (403).
+func (h Handler) RequireRole(role auth.Role, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.PrincipalFromContext(r.Context())
+		if !ok {
+			w.Header().Add("WWW-Authenticate", `ApiKey realm="meal-picker"`)
+			if h.Verifier != nil {
+				w.Header().Add("WWW-Authenticate", `Bearer realm="meal-picker"`)
+			}
+			writeErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", fmt.Errorf("missing or invalid credentials"))
+			return
+		}
+		if !principal.Role.Allows(role) {
+			writeErrorResponse(w, r, http.StatusForbidden, "Forbidden", fmt.Errorf("role %v is required for this route", role))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func apiKeyFromRequest(r *http.Request) string {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return key
+	}
+	scheme, value, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if found && strings.EqualFold(scheme, "ApiKey") {
+		return strings.TrimSpace(value)
+	}
+	return ""
+}
+
+func bearerTokenFromRequest(r *http.Request) string {
+	scheme, value, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if found && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(value)
+	}
+	return ""
+}
+
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, status int, rootCause string, err error) {
+	writeProblem(w, r, status, models.Message{
+		ErrorLog:  errorLogs([]error{err}, rootCause, status),
+		Status:    strconv.Itoa(status),
+		RequestID: logging.RequestID(r.Context()),
+	})
+}
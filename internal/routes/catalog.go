@@ -0,0 +1,148 @@
+package routes
+
+import (
+	"encoding/json"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"github.com/gorilla/mux"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+func (h Handler) AddDishes() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.AllDishesResponse
+
+		defer func() {
+			response, status := setAllDishesResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		apiRequest := models.AddDishesRequest{}
+		requestBody, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			response.Message.ErrorLog = errorLogs([]error{readErr}, "Unable to read request body", readStatus(readErr))
+			response.Message.Status = strconv.Itoa(readStatus(readErr))
+			return
+		}
+		if err := json.Unmarshal(requestBody, &apiRequest); err != nil {
+			response.Message.ErrorLog = errorLogs([]error{err}, "Unable to parse request", http.StatusBadRequest)
+			response.Message.Status = strconv.Itoa(http.StatusBadRequest)
+			return
+		}
+
+		response = h.Service.AddDishes(r.Context(), apiRequest)
+	}
+}
+
+func (h Handler) RenameCuisine() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.CuisineResponse
+
+		defer func() {
+			response, status := setInsertResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		apiRequest := models.RenameRequest{}
+		requestBody, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			response.Message.ErrorLog = errorLogs([]error{readErr}, "Unable to read request body", readStatus(readErr))
+			response.Message.Status = strconv.Itoa(readStatus(readErr))
+			return
+		}
+		if err := json.Unmarshal(requestBody, &apiRequest); err != nil {
+			response.Message.ErrorLog = errorLogs([]error{err}, "Unable to parse request", http.StatusBadRequest)
+			response.Message.Status = strconv.Itoa(http.StatusBadRequest)
+			return
+		}
+
+		response = h.Service.RenameCuisine(r.Context(), mux.Vars(r)["id"], apiRequest)
+	}
+}
+
+// DeleteCuisine removes a cuisine with its dishes and their ratings, and returns what was removed.
+func (h Handler) DeleteCuisine() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.CuisineResponse
+
+		defer func() {
+			response, status := setInsertResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		response = h.Service.DeleteCuisine(r.Context(), mux.Vars(r)["id"])
+	}
+}
+
+func (h Handler) RenameDish() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.DishResponse
+
+		defer func() {
+			response, status := setDishResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		apiRequest := models.RenameRequest{}
+		requestBody, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			response.Message.ErrorLog = errorLogs([]error{readErr}, "Unable to read request body", readStatus(readErr))
+			response.Message.Status = strconv.Itoa(readStatus(readErr))
+			return
+		}
+		if err := json.Unmarshal(requestBody, &apiRequest); err != nil {
+			response.Message.ErrorLog = errorLogs([]error{err}, "Unable to parse request", http.StatusBadRequest)
+			response.Message.Status = strconv.Itoa(http.StatusBadRequest)
+			return
+		}
+
+		response = h.Service.RenameDish(r.Context(), mux.Vars(r)["id"], apiRequest)
+	}
+}
+
+// DeleteDish removes a dish with its ratings, and returns what was removed.
+func (h Handler) DeleteDish() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.DishResponse
+
+		defer func() {
+			response, status := setDishResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		response = h.Service.DeleteDish(r.Context(), mux.Vars(r)["id"])
+	}
+}
+
+func setDishResponse(res models.DishResponse) (models.DishResponse, int) {
+	hn, _ := os.Hostname()
+	status, _ := strconv.Atoi(res.Message.Status)
+	res.Message.HostName = hn
+	return res, status
+}
+
+func setAllDishesResponse(res models.AllDishesResponse) (models.AllDishesResponse, int) {
+	hn, _ := os.Hostname()
+	status, _ := strconv.Atoi(res.Message.Status)
+	res.Message.HostName = hn
+	return res, status
+}
@@ -0,0 +1,182 @@
+package routes
+
+import (
+	"encoding/json"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestHandler_Catalog(t *testing.T) {
+	cuisineId := primitive.NewObjectID()
+	dishId := primitive.NewObjectID()
+	created := models.Message{Status: strconv.Itoa(http.StatusCreated)}
+	ok := models.Message{Status: strconv.Itoa(http.StatusOK)}
+	notFound := func(code string) models.Message {
+		return models.Message{
+			Status:   strconv.Itoa(http.StatusNotFound),
+			ErrorLog: []models.ErrorLog{{Status: strconv.Itoa(http.StatusNotFound), Code: code, RootCause: "Not found", Trace: "not found"}},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		body            string
+		expect          func(mockFacade *facade.MockServiceI)
+		wantCode        int
+		wantProblemCode string
+		wantDeprecation bool
+	}{
+		{
+			name:   "Add dishes",
+			method: http.MethodPost,
+			target: "/api/v1/dishes",
+			body:   `{"cuisine":"` + cuisineId.Hex() + `","dishes":[{"name":"Pad Thai","tags":["noodles"]}]}`,
+			expect: func(mockFacade *facade.MockServiceI) {
+				request := models.AddDishesRequest{Cuisine: cuisineId, Dishes: []models.Dish{{Name: "Pad Thai", Tags: []string{"noodles"}}}}
+				mockFacade.EXPECT().AddDishes(gomock.Any(), request).Return(models.AllDishesResponse{
+					Dishes: []models.Dish{{ID: dishId, Cuisine: cuisineId, Name: "Pad Thai"}}, Message: created,
+				})
+			},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:   "Add dishes on the legacy path",
+			method: http.MethodPost,
+			target: "/api/add/all/dishes",
+			body:   `{"cuisine":"` + cuisineId.Hex() + `","dishes":[{"name":"Pad Thai"}]}`,
+			expect: func(mockFacade *facade.MockServiceI) {
+				mockFacade.EXPECT().AddDishes(gomock.Any(), gomock.Any()).Return(models.AllDishesResponse{Message: created})
+			},
+			wantCode:        http.StatusCreated,
+			wantDeprecation: true,
+		},
+		{
+			name:   "Add dishes to a missing cuisine",
+			method: http.MethodPost,
+			target: "/api/v1/dishes",
+			body:   `{"cuisine":"` + cuisineId.Hex() + `","dishes":[{"name":"Pad Thai"}]}`,
+			expect: func(mockFacade *facade.MockServiceI) {
+				mockFacade.EXPECT().AddDishes(gomock.Any(), gomock.Any()).Return(models.AllDishesResponse{Message: notFound("cuisine_not_found")})
+			},
+			wantCode:        http.StatusNotFound,
+			wantProblemCode: "cuisine_not_found",
+		},
+		{
+			name:            "Add dishes with a malformed body",
+			method:          http.MethodPost,
+			target:          "/api/v1/dishes",
+			body:            `{"dishes":"Pad Thai"}`,
+			expect:          func(mockFacade *facade.MockServiceI) {},
+			wantCode:        http.StatusBadRequest,
+			wantProblemCode: "validation_failed",
+		},
+		{
+			name:   "Rename cuisine",
+			method: http.MethodPatch,
+			target: "/api/v1/cuisines/" + cuisineId.Hex(),
+			body:   `{"name":"Isan"}`,
+			expect: func(mockFacade *facade.MockServiceI) {
+				mockFacade.EXPECT().RenameCuisine(gomock.Any(), cuisineId.Hex(), models.RenameRequest{Name: "Isan"}).Return(models.CuisineResponse{
+					Cuisine: &models.Cuisine{ID: cuisineId, Name: "Isan"}, Message: ok,
+				})
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "Rename missing cuisine",
+			method: http.MethodPatch,
+			target: "/api/v1/cuisines/" + cuisineId.Hex(),
+			body:   `{"name":"Isan"}`,
+			expect: func(mockFacade *facade.MockServiceI) {
+				mockFacade.EXPECT().RenameCuisine(gomock.Any(), cuisineId.Hex(), gomock.Any()).Return(models.CuisineResponse{Message: notFound("cuisine_not_found")})
+			},
+			wantCode:        http.StatusNotFound,
+			wantProblemCode: "cuisine_not_found",
+		},
+		{
+			name:   "Delete cuisine",
+			method: http.MethodDelete,
+			target: "/api/v1/cuisines/" + cuisineId.Hex(),
+			expect: func(mockFacade *facade.MockServiceI) {
+				mockFacade.EXPECT().DeleteCuisine(gomock.Any(), cuisineId.Hex()).Return(models.CuisineResponse{Cuisine: &models.Cuisine{ID: cuisineId}, Message: ok})
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "Delete missing cuisine",
+			method: http.MethodDelete,
+			target: "/api/v1/cuisines/" + cuisineId.Hex(),
+			expect: func(mockFacade *facade.MockServiceI) {
+				mockFacade.EXPECT().DeleteCuisine(gomock.Any(), cuisineId.Hex()).Return(models.CuisineResponse{Message: notFound("cuisine_not_found")})
+			},
+			wantCode:        http.StatusNotFound,
+			wantProblemCode: "cuisine_not_found",
+		},
+		{
+			name:   "Rename dish",
+			method: http.MethodPatch,
+			target: "/api/v1/dishes/" + dishId.Hex(),
+			body:   `{"name":"Pad See Ew"}`,
+			expect: func(mockFacade *facade.MockServiceI) {
+				mockFacade.EXPECT().RenameDish(gomock.Any(), dishId.Hex(), models.RenameRequest{Name: "Pad See Ew"}).Return(models.DishResponse{
+					Dish: &models.Dish{ID: dishId, Name: "Pad See Ew"}, Message: ok,
+				})
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "Delete missing dish",
+			method: http.MethodDelete,
+			target: "/api/v1/dishes/" + dishId.Hex(),
+			expect: func(mockFacade *facade.MockServiceI) {
+				mockFacade.EXPECT().DeleteDish(gomock.Any(), dishId.Hex()).Return(models.DishResponse{Message: notFound("dish_not_found")})
+			},
+			wantCode:        http.StatusNotFound,
+			wantProblemCode: "dish_not_found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFacade := facade.NewMockServiceI(ctrl)
+			tt.expect(mockFacade)
+			router := Handler{Service: mockFacade}.InitializeRoutes()
+
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			r = r.WithContext(withEditor(r))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantDeprecation, w.Header().Get("Deprecation") != "")
+			if tt.wantProblemCode == "" {
+				return
+			}
+			var problem models.Problem
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+			assert.Equal(t, tt.wantProblemCode, problem.Code)
+		})
+	}
+
+	t.Run("Readers may not edit the catalog", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		router := Handler{Service: facade.NewMockServiceI(ctrl)}.InitializeRoutes()
+
+		r := httptest.NewRequest(http.MethodDelete, "/api/v1/cuisines/"+cuisineId.Hex(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r.WithContext(withReader(r)))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
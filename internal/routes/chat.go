@@ -0,0 +1,114 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/chat"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/tenant"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// chatResponseTimeout bounds building and posting the reply to a click.
+const chatResponseTimeout = 10 * time.Second
+
+// ChatCommand answers Slack and Mattermost slash commands, e.g. `/lunch pick spicy`.
+func (h Handler) ChatCommand() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		form, ok := h.verifyChat(w, r)
+		if !ok {
+			return
+		}
+
+		ctx := h.chatContext(r.Context(), form.Get("team_id"), form.Get("user_id"))
+		reply := chat.Run(ctx, h.Service, chat.Command{
+			Name:   form.Get("command"),
+			Text:   form.Get("text"),
+			TeamID: form.Get("team_id"),
+			UserID: form.Get("user_id"),
+		})
+		if err := json.NewEncoder(writeHeader(w, http.StatusOK)).Encode(reply); err != nil {
+			logrus.Errorln(err.Error())
+		}
+	}
+}
+
+// ChatInteraction answers clicks on the buttons of the messages ChatCommand posted. Chat servers
+// give up on clicks that are not acknowledged within seconds, so the click is acknowledged first
+// and the updated message is built and posted to the interaction's response URL afterwards.
+func (h Handler) ChatInteraction() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		form, ok := h.verifyChat(w, r)
+		if !ok {
+			return
+		}
+
+		var interaction chat.Interaction
+		if err := json.Unmarshal([]byte(form.Get("payload")), &interaction); err != nil {
+			writeErrorResponse(w, r, http.StatusBadRequest, "Unable to parse request", err)
+			return
+		}
+
+		// the reply outlives the request, so it keeps only the request ID of its context
+		detached := logging.WithRequestID(context.Background(), logging.RequestID(r.Context()))
+		ctx, cancel := context.WithTimeout(h.chatContext(detached, interaction.Team.ID, interaction.User.ID), chatResponseTimeout)
+		w.WriteHeader(http.StatusOK)
+
+		go func() {
+			defer cancel()
+			reply, err := chat.Interact(ctx, h.Service, interaction)
+			if err != nil {
+				logging.FromContext(ctx).Warnf("chat interaction ignored: %v", err.Error())
+				return
+			}
+			if err = chat.Respond(ctx, interaction.ResponseURL, reply); err != nil {
+				logging.FromContext(ctx).Errorf("chat response failed: %v", err.Error())
+			}
+		}()
+	}
+}
+
+// verifyChat reads the form of a chat request after checking it came from the chat server, and
+// reports whether it did; otherwise the problem has been written.
+func (h Handler) verifyChat(w http.ResponseWriter, r *http.Request) (url.Values, bool) {
+	requestBody, readErr := ioutil.ReadAll(r.Body)
+	if readErr != nil {
+		writeErrorResponse(w, r, readStatus(readErr), "Unable to read request body", readErr)
+		return nil, false
+	}
+
+	err := chat.Verify(h.Chat.SigningSecret, h.Chat.Token, r.Header, requestBody, time.Now())
+	switch {
+	case errors.Is(err, chat.ErrNotConfigured):
+		writeErrorResponse(w, r, http.StatusNotFound, "Not found", err)
+		return nil, false
+	case err != nil:
+		writeErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", err)
+		return nil, false
+	}
+
+	form, err := url.ParseQuery(string(requestBody))
+	if err != nil {
+		writeErrorResponse(w, r, http.StatusBadRequest, "Unable to parse request", err)
+		return nil, false
+	}
+	return form, true
+}
+
+// chatContext acts as the chat user, with the configured role, in the configured household.
+func (h Handler) chatContext(ctx context.Context, teamID string, userID string) context.Context {
+	// both settings were checked by Validate
+	role, _ := auth.ParseRole(h.Chat.Role)
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: chat.Subject(teamID, userID), Role: role})
+	if householdId, err := primitive.ObjectIDFromHex(h.Chat.Household); err == nil {
+		ctx = tenant.WithHousehold(ctx, householdId)
+	}
+	return ctx
+}
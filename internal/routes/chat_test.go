@@ -0,0 +1,135 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/chat"
+	"food-roulette-api/internal/config"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_ChatCommand(t *testing.T) {
+	householdId := primitive.NewObjectID()
+	body := url.Values{"command": {"/lunch"}, "text": {"pick"}, "team_id": {"T1"}, "user_id": {"U1"}}.Encode()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	tests := []struct {
+		name      string
+		chat      config.ChatConfig
+		signature string
+		wantCode  int
+		wantPick  bool
+	}{
+		{
+			name:      "Signed command runs as the chat user in the household",
+			chat:      config.ChatConfig{SigningSecret: "slack-secret", Household: householdId.Hex(), Role: "reader"},
+			signature: chat.Sign("slack-secret", timestamp, []byte(body)),
+			wantCode:  http.StatusOK,
+			wantPick:  true,
+		},
+		{
+			name:      "Bad signature is unauthorized",
+			chat:      config.ChatConfig{SigningSecret: "slack-secret", Role: "reader"},
+			signature: chat.Sign("guessed", timestamp, []byte(body)),
+			wantCode:  http.StatusUnauthorized,
+		},
+		{
+			name:      "Unconfigured integration is not found",
+			chat:      config.ChatConfig{Role: "reader"},
+			signature: chat.Sign("", timestamp, []byte(body)),
+			wantCode:  http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFacade := facade.NewMockServiceI(ctrl)
+			if tt.wantPick {
+				mockFacade.EXPECT().RandomPick(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, request models.PickRequest) models.PickResponse {
+						principal, _ := auth.PrincipalFromContext(ctx)
+						assert.Equal(t, &auth.Principal{Subject: "chat:T1:U1", Role: auth.RoleReader}, principal)
+						household, _ := tenant.HouseholdFromContext(ctx)
+						assert.Equal(t, householdId, household)
+						return models.PickResponse{Cuisine: &models.Cuisine{Name: "Thai"}, Message: models.Message{Status: strconv.Itoa(http.StatusOK)}}
+					})
+			}
+			h := Handler{Service: mockFacade, Chat: tt.chat}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/chat/commands", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set(chat.SignatureHeader, tt.signature)
+			req.Header.Set(chat.TimestampHeader, timestamp)
+			rec := httptest.NewRecorder()
+			h.ChatCommand().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantPick {
+				var reply chat.Reply
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reply))
+				assert.Equal(t, chat.InChannel, reply.ResponseType)
+				assert.Equal(t, "Lunch pick: Thai", reply.Text)
+			}
+		})
+	}
+}
+
+func TestHandler_ChatInteraction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	acked := make(chan struct{})
+	rerolled := make(chan struct{})
+	mockFacade.EXPECT().RandomPick(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request models.PickRequest) models.PickResponse {
+			defer close(rerolled)
+			select {
+			case <-acked:
+			case <-time.After(time.Second):
+				t.Error("the click was not acknowledged before the re-roll")
+			}
+			principal, _ := auth.PrincipalFromContext(ctx)
+			assert.Equal(t, &auth.Principal{Subject: "chat:T1:U1", Role: auth.RoleReader}, principal)
+			return models.PickResponse{Cuisine: &models.Cuisine{Name: "Thai"}, Message: models.Message{Status: strconv.Itoa(http.StatusOK)}}
+		})
+	h := Handler{Service: mockFacade, Chat: config.ChatConfig{SigningSecret: "slack-secret", Role: "reader"}}
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"type":    "block_actions",
+		"user":    map[string]string{"id": "U1"},
+		"team":    map[string]string{"id": "T1"},
+		"actions": []map[string]string{{"action_id": "reroll", "value": "[]"}},
+		// the response is refused by the client's address guard, so nothing leaves the test
+		"response_url": "https://127.0.0.1/actions/1",
+	})
+	body := url.Values{"payload": {string(payload)}}.Encode()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/chat/interactions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(chat.SignatureHeader, chat.Sign("slack-secret", timestamp, []byte(body)))
+	req.Header.Set(chat.TimestampHeader, timestamp)
+	rec := httptest.NewRecorder()
+	h.ChatInteraction().ServeHTTP(rec, req)
+	close(acked)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	select {
+	case <-rerolled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the click was never answered")
+	}
+}
@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"food-roulette-api/internal/openapi"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"time"
+)
+
+// OpenAPISpec serves the OpenAPI 3 document the frontend client is generated from.
+func (h Handler) OpenAPISpec() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spec, err := openapi.JSON()
+		if err != nil {
+			logrus.Errorln(err.Error())
+			writeErrorResponse(w, r, http.StatusInternalServerError, "OpenAPI error", err)
+			return
+		}
+		_, _ = writeHeader(w, http.StatusOK).Write(spec)
+	}
+}
+
+// docsContentSecurityPolicy lets the docs page load only the vendored Swagger UI files served
+// from this origin and run its own inline bootstrap script, identified by hash.
+var docsContentSecurityPolicy = fmt.Sprintf("default-src 'none'; script-src 'self' %v; style-src 'self'; "+
+	"img-src 'self' data:; connect-src 'self'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'",
+	inlineScriptHash(openapi.DocsHTML))
+
+// inlineScriptHash returns the CSP source expression for the first inline script in page.
+func inlineScriptHash(page []byte) string {
+	match := regexp.MustCompile(`(?s)<script>(.*?)</script>`).FindSubmatch(page)
+	if match == nil {
+		return "'none'"
+	}
+	sum := sha256.Sum256(match[1])
+	return "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+}
+
+// APIDocs serves a Swagger UI page for the OpenAPI document.
+func (h Handler) APIDocs() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", docsContentSecurityPolicy)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(openapi.DocsHTML)
+	}
+}
+
+// DocsAsset serves the vendored Swagger UI files the docs page loads.
+func (h Handler) DocsAsset() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["asset"]
+		asset, err := fs.ReadFile(openapi.SwaggerUI, path.Join("swagger-ui", name))
+		if err != nil {
+			writeErrorResponse(w, r, http.StatusNotFound, "Not found", fmt.Errorf("no docs asset %v", name))
+			return
+		}
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(asset))
+	}
+}
@@ -0,0 +1,61 @@
+package routes
+
+import (
+	"fmt"
+	"github.com/NYTimes/gziphandler"
+	"github.com/rs/cors"
+	"net/http"
+)
+
+// apiContentSecurityPolicy forbids API responses from loading anything or being framed; only the
+// docs page relaxes it, see docsContentSecurityPolicy.
+const apiContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'"
+
+// Wrap adds the layers that apply to every response, including those for unmatched routes:
+// security headers, CORS from h.CORS and compression from h.Compression.
+func (h Handler) Wrap(router http.Handler) (http.Handler, error) {
+	handler := router
+	if h.Compression.Level > 0 {
+		compress, err := gziphandler.GzipHandlerWithOpts(
+			gziphandler.CompressionLevel(h.Compression.Level),
+			gziphandler.MinSize(h.Compression.MinSize),
+			gziphandler.ContentTypes(h.Compression.ContentTypes),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid compression settings: %v", err.Error())
+		}
+		handler = compress(handler)
+	}
+	handler = cors.New(cors.Options{
+		AllowedOrigins:   h.CORS.AllowedOrigins,
+		AllowedMethods:   h.CORS.AllowedMethods,
+		AllowedHeaders:   h.CORS.AllowedHeaders,
+		ExposedHeaders:   h.CORS.ExposedHeaders,
+		AllowCredentials: h.CORS.AllowCredentials,
+		MaxAge:           h.CORS.MaxAge,
+	}).Handler(handler)
+	return h.SecureHeaders(handler), nil
+}
+
+// SecureHeaders sets the standard hardening headers. Handlers may replace the
+// Content-Security-Policy, as the docs page does.
+func (h Handler) SecureHeaders(next http.Handler) http.Handler {
+	var hsts string
+	if h.Security.HSTSMaxAge > 0 {
+		hsts = fmt.Sprintf("max-age=%v", h.Security.HSTSMaxAge)
+		if h.Security.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		if hsts != "" {
+			header.Set("Strict-Transport-Security", hsts)
+		}
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("X-Frame-Options", "DENY")
+		header.Set("Referrer-Policy", "no-referrer")
+		header.Set("Content-Security-Policy", apiContentSecurityPolicy)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,191 @@
+package routes
+
+import (
+	"compress/gzip"
+	"food-roulette-api/internal/config"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func edgeHandler(t *testing.T, h Handler) http.Handler {
+	defaults := config.Defaults()
+	h.CORS = defaults.CORS
+	h.CORS.AllowedOrigins = []string{"https://meals.example.com"}
+	h.Compression = defaults.Compression
+	h.Security = defaults.Security
+	handler, err := h.Wrap(h.InitializeRoutes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return handler
+}
+
+func TestHandler_Wrap_Preflight(t *testing.T) {
+	tests := []struct {
+		name        string
+		origin      string
+		method      string
+		path        string
+		headers     string
+		wantAllowed bool
+	}{
+		{
+			name:        "PUT rating",
+			origin:      "https://meals.example.com",
+			method:      http.MethodPut,
+			path:        "/api/v1/dishes/abc/rating",
+			headers:     "Content-Type, X-API-Key, X-Household-ID",
+			wantAllowed: true,
+		},
+		{
+			name:        "PATCH cuisine",
+			origin:      "https://meals.example.com",
+			method:      http.MethodPatch,
+			path:        "/api/v1/cuisines/abc",
+			headers:     "Content-Type, Authorization",
+			wantAllowed: true,
+		},
+		{
+			name:        "DELETE key",
+			origin:      "https://meals.example.com",
+			method:      http.MethodDelete,
+			path:        "/api/v1/admin/keys/abc",
+			headers:     "Authorization",
+			wantAllowed: true,
+		},
+		{
+			name:    "Unknown origin",
+			origin:  "https://evil.example.com",
+			method:  http.MethodDelete,
+			path:    "/api/v1/admin/keys/abc",
+			headers: "Authorization",
+		},
+		{
+			name:    "Method not configured",
+			origin:  "https://meals.example.com",
+			method:  "PROPFIND",
+			path:    "/api/v1/cuisines",
+			headers: "Authorization",
+		},
+		{
+			name:    "Header not configured",
+			origin:  "https://meals.example.com",
+			method:  http.MethodPut,
+			path:    "/api/v1/dishes/abc/rating",
+			headers: "X-Forwarded-Host",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			handler := edgeHandler(t, Handler{Service: facade.NewMockServiceI(ctrl)})
+
+			r := httptest.NewRequest(http.MethodOptions, tt.path, nil)
+			r.Header.Set("Origin", tt.origin)
+			r.Header.Set("Access-Control-Request-Method", tt.method)
+			r.Header.Set("Access-Control-Request-Headers", tt.headers)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			// preflights never reach the router, so no facade call is expected
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+			if !tt.wantAllowed {
+				assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+				return
+			}
+			assert.Equal(t, tt.origin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.method, w.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+			assert.NotEmpty(t, w.Header().Get("Access-Control-Allow-Headers"))
+		})
+	}
+}
+
+func TestHandler_Wrap_SecurityHeaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	handler := edgeHandler(t, Handler{Service: facade.NewMockServiceI(ctrl)})
+
+	for _, path := range []string{"/livez", "/api/v1/nothing-here"} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, "max-age=31536000; includeSubDomains", w.Header().Get("Strict-Transport-Security"), path)
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"), path)
+		assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"), path)
+		assert.Equal(t, apiContentSecurityPolicy, w.Header().Get("Content-Security-Policy"), path)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	csp := w.Header().Get("Content-Security-Policy")
+	assert.Contains(t, csp, "script-src 'self' 'sha256-")
+	assert.Contains(t, csp, "style-src 'self';")
+	assert.Contains(t, csp, "connect-src 'self'")
+	assert.NotContains(t, csp, "https:", "the docs page must not load anything from other origins")
+	assert.NotContains(t, csp, "'unsafe-inline'")
+
+	// the hash is taken from the embedded page, so editing its bootstrap script needs no policy change
+	assert.Regexp(t, `^'sha256-[A-Za-z0-9+/]+=*'$`, inlineScriptHash([]byte(`<script>window.onload = function () {}</script>`)))
+	assert.Equal(t, "'none'", inlineScriptHash([]byte(`<script src="x.js"></script>`)))
+}
+
+func TestHandler_Wrap_Compression(t *testing.T) {
+	cuisines := make([]*models.Cuisine, 40)
+	for i := range cuisines {
+		cuisines[i] = &models.Cuisine{Name: "Cuisine " + strings.Repeat("x", 40)}
+	}
+	tests := []struct {
+		name         string
+		cuisines     []*models.Cuisine
+		wantEncoding string
+	}{
+		{
+			name:         "Large responses are compressed",
+			cuisines:     cuisines,
+			wantEncoding: "gzip",
+		},
+		{
+			name:     "Small responses are not",
+			cuisines: cuisines[:1],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFacade := facade.NewMockServiceI(ctrl)
+			handler := edgeHandler(t, Handler{Service: mockFacade})
+			mockFacade.EXPECT().AllCuisines(gomock.Any()).Return(models.AllCuisinesResponse{
+				Cuisines: tt.cuisines,
+				Message:  models.Message{Status: "200"},
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/cuisines", nil)
+			r.Header.Set("Accept-Encoding", "gzip")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r.WithContext(withReader(r)))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantEncoding, w.Header().Get("Content-Encoding"))
+			var body []byte
+			if tt.wantEncoding == "gzip" {
+				reader, err := gzip.NewReader(w.Body)
+				if assert.NoError(t, err) {
+					body, _ = ioutil.ReadAll(reader)
+				}
+			} else {
+				body = w.Body.Bytes()
+			}
+			assert.Contains(t, string(body), `"Cuisines":[`)
+		})
+	}
+}
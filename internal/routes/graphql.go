@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"food-roulette-api/internal/graph"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"net/http"
+)
+
+// GraphQL executes GraphQL over HTTP POST requests. GraphQL errors, including rejected queries,
+// are reported in the result with a 200 status; only unreadable requests are problems.
+func (h Handler) GraphQL() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request graph.Request
+		requestBody, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			writeErrorResponse(w, r, readStatus(readErr), "Unable to read request body", readErr)
+			return
+		}
+		if err := json.Unmarshal(requestBody, &request); err != nil {
+			writeErrorResponse(w, r, http.StatusBadRequest, "Unable to parse request", err)
+			return
+		}
+		if request.Query == "" {
+			writeErrorResponse(w, r, http.StatusBadRequest, "Unable to parse request", fmt.Errorf("query is required"))
+			return
+		}
+
+		result := graph.Execute(r.Context(), h.Service, request, h.GraphQLMaxComplexity)
+		if err := json.NewEncoder(writeHeader(w, http.StatusOK)).Encode(result); err != nil {
+			logrus.Errorln(err.Error())
+		}
+	}
+}
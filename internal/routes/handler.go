@@ -2,8 +2,15 @@ package routes
 
 import (
 	"encoding/json"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/config"
 	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/health"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/metrics"
 	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/ratelimit"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 	"io/ioutil"
@@ -14,26 +21,60 @@ import (
 )
 
 type Handler struct {
-	Service facade.ServiceI
+	Service  facade.ServiceI
+	Verifier auth.TokenVerifier
+	Metrics  *metrics.Metrics
+	// Readiness backs /readyz; nil reports ready without dependency checks.
+	Readiness *health.Readiness
+	// AccessLog receives one line per request; nil disables access logging.
+	AccessLog *logrus.Logger
+	// GraphQLMaxComplexity caps the score of GraphQL queries; zero selects graph.DefaultMaxComplexity.
+	GraphQLMaxComplexity int
+	// Limiter throttles callers per principal and per client IP; nil disables rate limiting.
+	Limiter *ratelimit.Limiter
+	// MaxBodyBytes caps request bodies; zero leaves them unlimited.
+	MaxBodyBytes int64
+	// CORS, Compression and Security configure the layers added by Wrap.
+	CORS        config.CORSConfig
+	Compression config.CompressionConfig
+	Security    config.SecurityConfig
+	// Chat verifies and scopes the slash command endpoints.
+	Chat config.ChatConfig
 }
 
 func (h Handler) InitializeRoutes() *mux.Router {
 	r := mux.NewRouter().StrictSlash(true)
+	r.Use(h.RequestID, h.Trace, h.LogAccess, h.Instrument, h.LimitIP, h.Authenticate, h.RateLimit, h.LimitBody)
 
 	// Health check
 	r.Handle("/api/health", h.HealthCheck()).Methods(http.MethodGet)
 
-	r.Handle("/api/all/cuisines", h.GetAllCuisines()).Methods(http.MethodGet)
+	// Liveness and readiness probes
+	r.Handle("/livez", h.Livez()).Methods(http.MethodGet)
+	r.Handle("/readyz", h.Readyz()).Methods(http.MethodGet)
 
-	r.Handle("/api/add/cuisine", h.AddNewCuisine()).Methods(http.MethodPost)
+	// OpenAPI document and Swagger UI
+	r.Handle("/api/openapi.json", h.OpenAPISpec()).Methods(http.MethodGet)
+	r.Handle("/api/docs", h.APIDocs()).Methods(http.MethodGet)
+	r.Handle("/api/docs/{asset}", h.DocsAsset()).Methods(http.MethodGet)
 
-	r.Handle("/api/add/all/dishes", h.AddDishes()).Methods(http.MethodPost)
+	// Prometheus scrape endpoint
+	r.Handle("/metrics", h.Metrics.Handler()).Methods(http.MethodGet)
+
+	// GraphQL over the same facade, scoped to the caller's household like the REST routes
+	r.Handle("/graphql", h.TenantScoped(auth.RoleReader, h.GraphQL())).Methods(http.MethodPost)
+
+	// Resource routes under /api/v1; the unversioned paths they replaced are deprecated aliases
+	h.registerVersions(r)
+
+	r.NotFoundHandler = h.NotFound()
+	r.MethodNotAllowedHandler = h.MethodNotAllowed(r)
 	return r
 }
 
 func (h Handler) HealthCheck() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		err := json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+		err := json.NewEncoder(writeHeader(w, http.StatusOK)).Encode(map[string]bool{"ok": true})
 		if err != nil {
 			logrus.Errorln(err.Error())
 			return
@@ -49,32 +90,27 @@ func (h Handler) AddNewCuisine() http.HandlerFunc {
 		defer func() {
 			response, status := setInsertResponse(response)
 			response.Message.TimeTaken = time.Since(startTime).String()
-			_ = json.NewEncoder(writeHeader(w, status)).Encode(response)
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
 		}()
 
 		apiRequest := models.AddCuisineRequest{}
 		requestBody, readErr := ioutil.ReadAll(r.Body)
-
 		if readErr != nil {
-			response.Message.ErrorLog = errorLogs([]error{readErr}, "Unable to read request body", http.StatusBadRequest)
+			response.Message.ErrorLog = errorLogs([]error{readErr}, "Unable to read request body", readStatus(readErr))
+			response.Message.Status = strconv.Itoa(readStatus(readErr))
 			return
 		}
-		err := json.Unmarshal(requestBody, &apiRequest)
-		if err != nil {
+		if err := json.Unmarshal(requestBody, &apiRequest); err != nil {
 			response.Message.ErrorLog = errorLogs([]error{err}, "Unable to parse request", http.StatusBadRequest)
-
+			response.Message.Status = strconv.Itoa(http.StatusBadRequest)
+			return
 		}
 
 		response = h.Service.AddCuisine(r.Context(), apiRequest)
 	}
 }
 
-func (h Handler) AddDishes() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-
-	}
-}
-
 func (h Handler) GetAllCuisines() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		startTime := time.Now()
@@ -83,7 +119,8 @@ func (h Handler) GetAllCuisines() http.HandlerFunc {
 		defer func() {
 			response, status := setAllResponse(response)
 			response.Message.TimeTaken = time.Since(startTime).String()
-			_ = json.NewEncoder(writeHeader(w, status)).Encode(response)
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
 		}()
 
 		response = h.Service.AllCuisines(r.Context())
@@ -116,7 +153,8 @@ func errorLogs(errors []error, rootCause string, status int) []models.ErrorLog {
 		errLogs = append(errLogs, models.ErrorLog{
 			RootCause: rootCause,
 			Status:    strconv.Itoa(status),
-			Trace:     err.Error(),
+			Code:      apperrors.CodeFor(err, status),
+			Trace:     apperrors.Detail(err, status),
 		})
 	}
 	return errLogs
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -67,7 +68,7 @@ func TestHandler_AddNewCuisine_Happy_Path(t *testing.T) {
 	defer ctrl.Finish()
 	mockFacade := facade.NewMockServiceI(ctrl)
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest("POST", "/api/add/cuisine", nil)
+	r := httptest.NewRequest("POST", "/api/add/cuisine", strings.NewReader(`{"name":"Thai"}`))
 
 	type Test struct {
 		name       string
@@ -86,7 +87,7 @@ func TestHandler_AddNewCuisine_Happy_Path(t *testing.T) {
 		wantCode:   http.StatusOK,
 		r:          r,
 		w:          w,
-		cuisineReq: models.AddCuisineRequest{},
+		cuisineReq: models.AddCuisineRequest{Name: "Thai"},
 		wantRes: models.CuisineResponse{
 			Cuisine: &models.Cuisine{},
 		},
@@ -191,6 +192,33 @@ func TestHandler_AddNewCuisine_Sad_Path(t *testing.T) {
 	})
 }
 
+func TestHandler_AddNewCuisine_ParseError(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		// encoding/json keeps filling fields after a type error, so the name alone would validate
+		{name: "Type mismatch", body: `{"name":"Thai","tags":"spicy"}`},
+		{name: "Malformed body", body: `{"name":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// the facade must not be called
+			h := Handler{Service: facade.NewMockServiceI(ctrl)}
+
+			w := httptest.NewRecorder()
+			h.AddNewCuisine().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/cuisines", strings.NewReader(tt.body)))
+
+			var problem models.Problem
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, "Unable to parse request", problem.Message.ErrorLog[0].RootCause)
+		})
+	}
+}
+
 func TestHandler_GetAllCuisines_Happy_Path(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
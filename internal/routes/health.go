@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"encoding/json"
+	"food-roulette-api/internal/health"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// Livez reports that the process is up and serving; it never checks dependencies.
+func (h Handler) Livez() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(writeHeader(w, http.StatusOK)).Encode(health.Report{Status: health.StatusOK})
+		if err != nil {
+			logrus.Errorln(err.Error())
+		}
+	}
+}
+
+// Readyz reports whether every dependency is reachable and the server is not draining,
+// answering 503 otherwise so load balancers stop routing traffic here.
+func (h Handler) Readyz() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := h.Readiness.Check(r.Context())
+		status := http.StatusOK
+		if report.Status != health.StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		err := json.NewEncoder(writeHeader(w, status)).Encode(report)
+		if err != nil {
+			logrus.Errorln(err.Error())
+		}
+	}
+}
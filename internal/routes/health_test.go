@@ -0,0 +1,81 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"food-roulette-api/internal/health"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_Livez(t *testing.T) {
+	readiness := health.NewReadiness(time.Second, 0)
+	readiness.Register("mongodb", func(ctx context.Context) error { return fmt.Errorf("down") })
+	router := Handler{Readiness: readiness}.InitializeRoutes()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
+
+func TestHandler_Readyz(t *testing.T) {
+	tests := []struct {
+		name       string
+		pingErr    error
+		drain      bool
+		wantCode   int
+		wantStatus string
+		wantChecks int
+	}{
+		{
+			name:       "Ready",
+			wantCode:   http.StatusOK,
+			wantStatus: health.StatusOK,
+			wantChecks: 1,
+		},
+		{
+			name:       "Mongo unreachable",
+			pingErr:    fmt.Errorf("server selection timeout"),
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: health.StatusUnavailable,
+			wantChecks: 1,
+		},
+		{
+			name:       "Draining",
+			drain:      true,
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: health.StatusDraining,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readiness := health.NewReadiness(time.Second, 0)
+			readiness.Register("mongodb", func(ctx context.Context) error { return tt.pingErr })
+			if tt.drain {
+				readiness.Drain()
+			}
+			router := Handler{Readiness: readiness}.InitializeRoutes()
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			body := w.Body.String()
+			var report health.Report
+			assert.NoError(t, json.Unmarshal([]byte(body), &report))
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Len(t, report.Checks, tt.wantChecks)
+			if tt.pingErr != nil {
+				assert.Equal(t, "mongodb", report.Checks[0].Name)
+				assert.Equal(t, health.ReasonCheckFailed, report.Checks[0].Reason)
+				assert.NotContains(t, body, tt.pingErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package routes
+
+import (
+	"encoding/json"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"github.com/gorilla/mux"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+func (h Handler) CreateHousehold() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.HouseholdResponse
+
+		defer func() {
+			response, status := setHouseholdResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		apiRequest := models.AddHouseholdRequest{}
+		requestBody, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			response.Message.ErrorLog = errorLogs([]error{readErr}, "Unable to read request body", readStatus(readErr))
+			response.Message.Status = strconv.Itoa(readStatus(readErr))
+			return
+		}
+		if err := json.Unmarshal(requestBody, &apiRequest); err != nil {
+			response.Message.ErrorLog = errorLogs([]error{err}, "Unable to parse request", http.StatusBadRequest)
+			response.Message.Status = strconv.Itoa(http.StatusBadRequest)
+			return
+		}
+
+		response = h.Service.CreateHousehold(r.Context(), apiRequest)
+	}
+}
+
+func (h Handler) GetMyHouseholds() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.AllHouseholdsResponse
+
+		defer func() {
+			response, status := setAllHouseholdsResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		response = h.Service.MyHouseholds(r.Context())
+	}
+}
+
+func (h Handler) InviteMember() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.HouseholdResponse
+
+		defer func() {
+			response, status := setHouseholdResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		apiRequest := models.InviteMemberRequest{}
+		requestBody, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			response.Message.ErrorLog = errorLogs([]error{readErr}, "Unable to read request body", readStatus(readErr))
+			response.Message.Status = strconv.Itoa(readStatus(readErr))
+			return
+		}
+		if err := json.Unmarshal(requestBody, &apiRequest); err != nil {
+			response.Message.ErrorLog = errorLogs([]error{err}, "Unable to parse request", http.StatusBadRequest)
+			response.Message.Status = strconv.Itoa(http.StatusBadRequest)
+			return
+		}
+
+		response = h.Service.InviteMember(r.Context(), mux.Vars(r)["id"], apiRequest)
+	}
+}
+
+func (h Handler) RemoveMember() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.HouseholdResponse
+
+		defer func() {
+			response, status := setHouseholdResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		vars := mux.Vars(r)
+		response = h.Service.RemoveMember(r.Context(), vars["id"], vars["subject"])
+	}
+}
+
+func setHouseholdResponse(res models.HouseholdResponse) (models.HouseholdResponse, int) {
+	hn, _ := os.Hostname()
+	status, _ := strconv.Atoi(res.Message.Status)
+	res.Message.HostName = hn
+	return res, status
+}
+
+func setAllHouseholdsResponse(res models.AllHouseholdsResponse) (models.AllHouseholdsResponse, int) {
+	hn, _ := os.Hostname()
+	status, _ := strconv.Atoi(res.Message.Status)
+	res.Message.HostName = hn
+	return res, status
+}
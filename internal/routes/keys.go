@@ -0,0 +1,88 @@
+package routes
+
+import (
+	"encoding/json"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"github.com/gorilla/mux"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+func (h Handler) IssueApiKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.ApiKeyResponse
+
+		defer func() {
+			response, status := setKeyResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		apiRequest := models.IssueKeyRequest{}
+		requestBody, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			response.Message.ErrorLog = errorLogs([]error{readErr}, "Unable to read request body", readStatus(readErr))
+			response.Message.Status = strconv.Itoa(readStatus(readErr))
+			return
+		}
+		if err := json.Unmarshal(requestBody, &apiRequest); err != nil {
+			response.Message.ErrorLog = errorLogs([]error{err}, "Unable to parse request", http.StatusBadRequest)
+			response.Message.Status = strconv.Itoa(http.StatusBadRequest)
+			return
+		}
+
+		response = h.Service.IssueApiKey(r.Context(), apiRequest)
+	}
+}
+
+func (h Handler) GetAllApiKeys() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.AllApiKeysResponse
+
+		defer func() {
+			response, status := setAllKeysResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		response = h.Service.AllApiKeys(r.Context())
+	}
+}
+
+func (h Handler) RevokeApiKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.ApiKeyResponse
+
+		defer func() {
+			response, status := setKeyResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		response = h.Service.RevokeApiKey(r.Context(), mux.Vars(r)["id"])
+	}
+}
+
+func setKeyResponse(res models.ApiKeyResponse) (models.ApiKeyResponse, int) {
+	hn, _ := os.Hostname()
+	status, _ := strconv.Atoi(res.Message.Status)
+	res.Message.HostName = hn
+	return res, status
+}
+
+func setAllKeysResponse(res models.AllApiKeysResponse) (models.AllApiKeysResponse, int) {
+	hn, _ := os.Hostname()
+	status, _ := strconv.Atoi(res.Message.Status)
+	res.Message.HostName = hn
+	return res, status
+}
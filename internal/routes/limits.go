@@ -0,0 +1,154 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/ratelimit"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// unlimitedRoutes are probed by orchestrators and scrapers and are never rate limited.
+var unlimitedRoutes = map[string]bool{
+	"/api/health": true,
+	"/livez":      true,
+	"/readyz":     true,
+	"/metrics":    true,
+}
+
+// LimitIP runs before Authenticate and turns away clients whose IP bucket is empty, so a client
+// flooding the API with bad credentials stops reaching the key lookup once its failures have
+// used up the bucket. It takes no token; RateLimit charges the request afterwards.
+func (h Handler) LimitIP(next http.Handler) http.Handler {
+	if h.Limiter == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unlimitedRoutes[routeTemplate(r)] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		result, limited, err := h.Limiter.PeekIP(r.Context(), clientAddr(r))
+		if err != nil {
+			logrus.Errorf("rate limit store failed: %v", err.Error())
+		}
+		if err != nil || !limited || result.Allowed {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rateLimited(w, r, result)
+	})
+}
+
+// RateLimit counts each request against the caller's bucket: the authenticated principal's when
+// there is one, the client IP's otherwise, which includes requests whose credentials were
+// rejected. Every limited response carries RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset; rejected requests also get Retry-After and a 429.
+func (h Handler) RateLimit(next http.Handler) http.Handler {
+	if h.Limiter == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unlimitedRoutes[routeTemplate(r)] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var subject string
+		if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+			subject = principal.Subject
+		}
+
+		result, limited, err := h.Limiter.Take(r.Context(), subject, clientAddr(r))
+		if err != nil {
+			// an unreachable store must not take the API down with it
+			logrus.Errorf("rate limit store failed: %v", err.Error())
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !limited {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !result.Allowed {
+			rateLimited(w, r, result)
+			return
+		}
+		setRateLimitHeaders(w, result)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func setRateLimitHeaders(w http.ResponseWriter, result ratelimit.Result) {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(ratelimit.Seconds(result.Reset)))
+}
+
+// rateLimited answers a request whose bucket is empty with a 429 and Retry-After.
+func rateLimited(w http.ResponseWriter, r *http.Request, result ratelimit.Result) {
+	setRateLimitHeaders(w, result)
+	retryAfter := ratelimit.Seconds(result.RetryAfter)
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	writeErrorResponse(w, r, http.StatusTooManyRequests, "Rate limit exceeded",
+		apperrors.New(apperrors.ErrRateLimited, apperrors.CodeRateLimited,
+			fmt.Sprintf("too many requests; retry in %v seconds", retryAfter)))
+}
+
+// LimitBody rejects request bodies larger than MaxBodyBytes with a 413. Bodies that declare their
+// length are refused up front; others fail when a handler reads past the limit.
+func (h Handler) LimitBody(next http.Handler) http.Handler {
+	if h.MaxBodyBytes <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > h.MaxBodyBytes {
+			writeErrorResponse(w, r, http.StatusRequestEntityTooLarge, "Request body too large", bodyTooLarge(h.MaxBodyBytes))
+			return
+		}
+		r.Body = &limitedBody{ReadCloser: r.Body, remaining: h.MaxBodyBytes, limit: h.MaxBodyBytes}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limitedBody fails reads once more than limit bytes have been read from the body.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, bodyTooLarge(b.limit)
+	}
+	// read one byte past the limit to tell a body of exactly limit bytes from a larger one
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), bodyTooLarge(b.limit)
+	}
+	return n, err
+}
+
+func bodyTooLarge(limit int64) error {
+	return apperrors.New(apperrors.ErrTooLarge, apperrors.CodePayloadTooLarge,
+		fmt.Sprintf("request body exceeds %v bytes", limit))
+}
+
+// readStatus is the status reported when a request body cannot be read.
+func readStatus(err error) int {
+	if errors.Is(err, apperrors.ErrTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
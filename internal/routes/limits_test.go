@@ -0,0 +1,155 @@
+package routes
+
+import (
+	"encoding/json"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/ratelimit"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestHandler_RateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	router := Handler{
+		Service: mockFacade,
+		Limiter: &ratelimit.Limiter{
+			Store:  ratelimit.NewMemoryStore(),
+			PerKey: ratelimit.Limit{Rate: 1, Burst: 5},
+			PerIP:  ratelimit.Limit{Rate: 0.5, Burst: 2},
+		},
+	}.InitializeRoutes()
+	mockFacade.EXPECT().AllCuisines(gomock.Any()).Return(models.AllCuisinesResponse{
+		Message: models.Message{Status: "200"},
+	}).Times(3)
+
+	anonymous := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/cuisines", nil))
+		return w
+	}
+	for remaining := 1; remaining >= 0; remaining-- {
+		w := anonymous()
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, strconv.Itoa(remaining), w.Header().Get("RateLimit-Remaining"))
+	}
+
+	w := anonymous()
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "4", w.Header().Get("RateLimit-Reset"))
+	var problem models.Problem
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+	assert.Equal(t, "rate_limited", problem.Code)
+
+	// authenticated callers are counted against their own bucket, not the client IP's
+	for i := 0; i < 3; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/cuisines", nil)
+		r.RemoteAddr = "198.51.100.7:4321"
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, r.WithContext(withReader(r)))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "5", w.Header().Get("RateLimit-Limit"))
+	}
+
+	// probes are never limited
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestHandler_LimitIP_InvalidKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	router := Handler{
+		Service: mockFacade,
+		Limiter: &ratelimit.Limiter{
+			Store:  ratelimit.NewMemoryStore(),
+			PerKey: ratelimit.Limit{Rate: 1, Burst: 5},
+			PerIP:  ratelimit.Limit{Rate: 0.5, Burst: 2},
+		},
+	}.InitializeRoutes()
+	// failed lookups are charged to the IP; once its bucket is empty the lookup is skipped
+	mockFacade.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(nil, facade.ErrInvalidApiKey).Times(2)
+
+	for i := 0; i < 20; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/cuisines", nil)
+		r.Header.Set(apiKeyHeader, "mp_guess"+strconv.Itoa(i))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		if i < 2 {
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			continue
+		}
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	}
+}
+
+func TestHandler_LimitBody(t *testing.T) {
+	const maxBodyBytes = 64
+	small := `{"Name":"Thai","Dishes":[{"Name":"Pad Thai"}]}`
+	large := `{"Name":"Thai","Dishes":[{"Name":"` + strings.Repeat("x", maxBodyBytes) + `"}]}`
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		wantStatus    int
+	}{
+		{
+			name:          "Body within the limit",
+			body:          small,
+			contentLength: int64(len(small)),
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:          "Declared length over the limit",
+			body:          large,
+			contentLength: int64(len(large)),
+			wantStatus:    http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:          "Chunked body over the limit",
+			body:          large,
+			contentLength: -1,
+			wantStatus:    http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFacade := facade.NewMockServiceI(ctrl)
+			router := Handler{Service: mockFacade, MaxBodyBytes: maxBodyBytes}.InitializeRoutes()
+			if tt.wantStatus == http.StatusOK {
+				mockFacade.EXPECT().AddCuisine(gomock.Any(), gomock.Any()).Return(models.CuisineResponse{
+					Message: models.Message{Status: "200"},
+				})
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/cuisines", strings.NewReader(tt.body))
+			r.ContentLength = tt.contentLength
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r.WithContext(withEditor(r)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusRequestEntityTooLarge {
+				var problem models.Problem
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+				assert.Equal(t, "payload_too_large", problem.Code)
+				assert.Equal(t, "request body exceeds 64 bytes", problem.Detail)
+			}
+		})
+	}
+}
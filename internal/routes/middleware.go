@@ -0,0 +1,157 @@
+package routes
+
+import (
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/tracing"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"net"
+	"net/http"
+	"time"
+)
+
+// statusRecorder captures the status code and body size written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Instrument records request count and latency per route template, method and status.
+func (h Handler) Instrument(next http.Handler) http.Handler {
+	if h.Metrics == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(recorder, r)
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+		h.Metrics.ObserveRequest(routeTemplate(r), r.Method, recorder.status, time.Since(startTime))
+	})
+}
+
+// routeTemplate returns the matched mux path template so labels stay bounded, e.g. /api/dishes/{id}/rating.
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return "unmatched"
+}
+
+// Trace starts a server span per request, continuing any W3C traceparent sent by the caller.
+func (h Handler) Trace(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		route := routeTemplate(r)
+		ctx, span := tracing.Tracer().Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethodKey.String(r.Method),
+				semconv.HTTPRouteKey.String(route),
+				semconv.HTTPTargetKey.String(r.URL.RequestURI()),
+			))
+		defer span.End()
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(recorder.status))
+		if recorder.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(recorder.status))
+		}
+	})
+}
+
+// RequestID propagates a well-formed X-Request-ID from the caller or assigns a new one, echoing it
+// on the response and storing it in the request context for log lines and response messages.
+func (h Handler) RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := r.Header.Get(logging.RequestIDHeader)
+		if !logging.ValidRequestID(requestId) {
+			requestId = logging.NewRequestID()
+		}
+		w.Header().Set(logging.RequestIDHeader, requestId)
+		next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), requestId)))
+	})
+}
+
+// LogAccess writes one structured access log line per request to the AccessLog logger.
+func (h Handler) LogAccess(next http.Handler) http.Handler {
+	if h.AccessLog == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(recorder, r)
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+		fields := logrus.Fields{
+			"method":     r.Method,
+			"route":      routeTemplate(r),
+			"path":       r.URL.Path,
+			"status":     recorder.status,
+			"latency_ms": float64(time.Since(startTime).Microseconds()) / 1000,
+			"bytes":      recorder.bytes,
+			"client":     clientAddr(r),
+			"user_agent": r.UserAgent(),
+		}
+		if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+			fields["forwarded_for"] = forwardedFor
+		}
+		entry := h.AccessLog.WithFields(fields)
+		if requestId := logging.RequestID(r.Context()); requestId != "" {
+			entry = entry.WithField("request_id", requestId)
+		}
+		if spanContext := trace.SpanContextFromContext(r.Context()); spanContext.HasTraceID() {
+			entry = entry.WithField("trace_id", spanContext.TraceID().String())
+		}
+		entry.Info("request completed")
+	})
+}
+
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
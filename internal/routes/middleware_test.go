@@ -0,0 +1,135 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/metrics"
+	"food-roulette-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_Instrument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	h := Handler{
+		Service: mockFacade,
+		Metrics: metrics.New(),
+	}
+	router := h.InitializeRoutes()
+
+	mockFacade.EXPECT().DishRatings(gomock.Any(), "abc").Return(models.AllRatingsResponse{
+		Message: models.Message{Status: "400"},
+	})
+	r := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	router.ServeHTTP(httptest.NewRecorder(), r)
+	r = httptest.NewRequest(http.MethodGet, "/api/dishes/abc/ratings", nil)
+	r = r.WithContext(withReader(r))
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := ioutil.ReadAll(w.Result().Body)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, string(body), `meal_picker_http_requests_total{method="GET",route="/api/health",status="200"} 1`)
+	assert.Contains(t, string(body), `meal_picker_http_requests_total{method="GET",route="/api/dishes/{id}/ratings",status="400"} 1`)
+}
+
+func TestHandler_RequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		wantSame bool
+	}{
+		{
+			name:     "Propagates caller id",
+			incoming: "req-1234.abc",
+			wantSame: true,
+		},
+		{
+			name:     "Assigns id when missing",
+			incoming: "",
+		},
+		{
+			name:     "Replaces malformed id",
+			incoming: "bad id\r\ninjected",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFacade := facade.NewMockServiceI(ctrl)
+			router := Handler{Service: mockFacade}.InitializeRoutes()
+
+			mockFacade.EXPECT().DishRatings(gomock.Any(), "abc").Return(models.AllRatingsResponse{
+				Message: models.Message{Status: "200"},
+			})
+			r := httptest.NewRequest(http.MethodGet, "/api/dishes/abc/ratings", nil)
+			if tt.incoming != "" {
+				r.Header.Set(logging.RequestIDHeader, tt.incoming)
+			}
+			r = r.WithContext(withReader(r))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			requestId := w.Header().Get(logging.RequestIDHeader)
+			if tt.wantSame {
+				assert.Equal(t, tt.incoming, requestId)
+			} else {
+				assert.Len(t, requestId, 32)
+			}
+			var response models.AllRatingsResponse
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+			assert.Equal(t, requestId, response.Message.RequestID)
+		})
+	}
+}
+
+func TestHandler_LogAccess(t *testing.T) {
+	var buf bytes.Buffer
+	accessLog := logrus.New()
+	accessLog.SetOutput(&buf)
+	accessLog.SetFormatter(&logrus.JSONFormatter{})
+	router := Handler{AccessLog: accessLog}.InitializeRoutes()
+
+	r := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	r.RemoteAddr = "10.0.0.7:51234"
+	r.Header.Set(logging.RequestIDHeader, "req-42")
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	var line map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "GET", line["method"])
+	assert.Equal(t, "/api/health", line["route"])
+	assert.Equal(t, float64(http.StatusOK), line["status"])
+	assert.Equal(t, "10.0.0.7", line["client"])
+	assert.Equal(t, "req-42", line["request_id"])
+	assert.Greater(t, line["bytes"], float64(0))
+	assert.Contains(t, line, "latency_ms")
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("\n")))
+}
+
+func TestHandler_RequestID_ErrorResponse(t *testing.T) {
+	router := Handler{}.InitializeRoutes()
+
+	r := httptest.NewRequest(http.MethodGet, "/api/admin/keys", nil)
+	r.Header.Set(logging.RequestIDHeader, "req-401")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	var response models.Problem
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "req-401", response.RequestID)
+	assert.Equal(t, "req-401", response.Message.RequestID)
+}
@@ -0,0 +1,259 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/metrics"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/openapi"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func init() {
+	// the docs page is the only html response; validate it like any other string body
+	openapi3filter.RegisterBodyDecoder("text/html", openapi3filter.FileBodyDecoder)
+}
+
+func loadSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData(openapi.YAML)
+	if err != nil {
+		t.Fatalf("failed to load openapi document: %v", err)
+	}
+	if err = doc.Validate(context.Background()); err != nil {
+		t.Fatalf("openapi document is invalid: %v", err)
+	}
+	return doc
+}
+
+func TestOpenAPI_RoutesMatchSpec(t *testing.T) {
+	doc := loadSpec(t)
+	router := Handler{}.InitializeRoutes()
+
+	var registered []string
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// version prefixes only group their subrouter's routes
+			return nil
+		}
+		for _, method := range methods {
+			registered = append(registered, method+" "+template)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	var documented []string
+	for path, item := range doc.Paths {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	sort.Strings(registered)
+	sort.Strings(documented)
+	assert.Equal(t, documented, registered)
+}
+
+func TestOpenAPI_ServesDocument(t *testing.T) {
+	router := Handler{}.InitializeRoutes()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	doc, err := openapi3.NewLoader().LoadFromData(w.Body.Bytes())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "3.0.3", doc.OpenAPI)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `url: "/api/openapi.json"`)
+	page := w.Body.String()
+	assert.NotContains(t, page, "https://", "the docs page must only load vendored assets")
+
+	for asset, contentType := range map[string]string{
+		"swagger-ui-bundle.js": "javascript",
+		"swagger-ui.css":       "text/css",
+	} {
+		assert.Contains(t, page, `"/api/docs/`+asset+`"`)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs/"+asset, nil))
+		assert.Equal(t, http.StatusOK, w.Code, asset)
+		assert.Contains(t, w.Header().Get("Content-Type"), contentType, asset)
+		assert.NotEmpty(t, w.Body.Bytes(), asset)
+	}
+
+	// only the files the page loads are served
+	for _, asset := range []string{"missing.js", "LICENSE"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs/"+asset, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, asset)
+	}
+}
+
+func TestOpenAPI_ResponsesMatchSchemas(t *testing.T) {
+	doc := loadSpec(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+
+	now := time.Now().UTC()
+	ok := models.Message{Status: strconv.Itoa(http.StatusOK)}
+	created := models.Message{Status: strconv.Itoa(http.StatusCreated)}
+	rating := &models.RatingSummary{Average: 4.5, Count: 2}
+	dish := models.Dish{ID: primitive.NewObjectID(), Cuisine: primitive.NewObjectID(), Name: "Pad Thai", Tags: []string{"noodles"}, Rating: rating}
+	cuisine := &models.Cuisine{ID: dish.Cuisine, Name: "Thai", Dishes: []models.Dish{dish}, Tags: []string{"spicy"}, CreatedBy: "user-123", CreatedAt: &now, Rating: rating}
+	household := &models.Household{ID: primitive.NewObjectID(), Name: "Home", CreatedBy: "user-123", CreatedAt: &now,
+		Members: []models.Member{{Subject: "user-123", Role: "admin", JoinedAt: &now}}}
+	apiKey := &models.ApiKey{ID: primitive.NewObjectID(), Name: "frontend", Prefix: "mp_abcdef", Role: "reader", CreatedAt: now}
+	dishRating := &models.Rating{ID: primitive.NewObjectID(), Dish: dish.ID, Subject: "user-123", Score: 5, Note: "great", CreatedAt: &now, UpdatedAt: &now}
+	user := &models.User{Subject: "user-123", FavoriteCuisines: []primitive.ObjectID{cuisine.ID}, UpdatedAt: &now}
+	conflict := models.Message{
+		Status: strconv.Itoa(http.StatusConflict),
+		ErrorLog: []models.ErrorLog{{Status: strconv.Itoa(http.StatusConflict), Code: "cuisine_already_exists",
+			RootCause: "Insertion error", Trace: "cuisine Thai already exists"}},
+	}
+
+	mockFacade.EXPECT().AllCuisines(gomock.Any()).Return(models.AllCuisinesResponse{Cuisines: []*models.Cuisine{cuisine}, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().AddCuisine(gomock.Any(), gomock.Any()).Return(models.CuisineResponse{Cuisine: cuisine, Message: ok}).Times(1)
+	mockFacade.EXPECT().AddCuisine(gomock.Any(), gomock.Any()).Return(models.CuisineResponse{Message: conflict}).Times(1)
+	mockFacade.EXPECT().AddDishes(gomock.Any(), gomock.Any()).Return(models.AllDishesResponse{Dishes: []models.Dish{dish}, Message: created}).AnyTimes()
+	mockFacade.EXPECT().RenameCuisine(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.CuisineResponse{Cuisine: cuisine, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().DeleteCuisine(gomock.Any(), gomock.Any()).Return(models.CuisineResponse{Cuisine: cuisine, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().RenameDish(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.DishResponse{Dish: &dish, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().DeleteDish(gomock.Any(), gomock.Any()).Return(models.DishResponse{Dish: &dish, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().RandomPick(gomock.Any(), gomock.Any()).Return(models.PickResponse{Cuisine: cuisine, Dish: &dish, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().DishRatings(gomock.Any(), gomock.Any()).Return(models.AllRatingsResponse{Ratings: []*models.Rating{dishRating}, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().RatingsForDishes(gomock.Any(), gomock.Any()).Return(models.AllRatingsResponse{Ratings: []*models.Rating{dishRating}, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().RateDish(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.RatingResponse{Rating: dishRating, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().CurrentUser(gomock.Any()).Return(models.UserResponse{User: user, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().SetPreference(gomock.Any(), gomock.Any()).Return(models.UserResponse{User: user, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().MyHouseholds(gomock.Any()).Return(models.AllHouseholdsResponse{Households: []*models.Household{household}, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().CreateHousehold(gomock.Any(), gomock.Any()).Return(models.HouseholdResponse{Household: household, Message: created}).AnyTimes()
+	mockFacade.EXPECT().InviteMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.HouseholdResponse{Household: household, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().RemoveMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.HouseholdResponse{Household: household, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().AllApiKeys(gomock.Any()).Return(models.AllApiKeysResponse{ApiKeys: []*models.ApiKey{apiKey}, Message: ok}).AnyTimes()
+	mockFacade.EXPECT().IssueApiKey(gomock.Any(), gomock.Any()).Return(models.ApiKeyResponse{ApiKey: apiKey, Key: "mp_secret", Message: created}).AnyTimes()
+	mockFacade.EXPECT().RevokeApiKey(gomock.Any(), gomock.Any()).Return(models.ApiKeyResponse{ApiKey: apiKey, Message: ok}).AnyTimes()
+
+	router := Handler{Service: mockFacade, Metrics: metrics.New()}.InitializeRoutes()
+	admin := &auth.Principal{Subject: "user-123", Role: auth.RoleAdmin}
+
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		principal *auth.Principal
+		wantCode  int
+	}{
+		{name: "Health", method: http.MethodGet, target: "/api/health", wantCode: http.StatusOK},
+		{name: "Livez", method: http.MethodGet, target: "/livez", wantCode: http.StatusOK},
+		{name: "Readyz", method: http.MethodGet, target: "/readyz", wantCode: http.StatusOK},
+		{name: "Metrics", method: http.MethodGet, target: "/metrics", wantCode: http.StatusOK},
+		{name: "List cuisines", method: http.MethodGet, target: "/api/v1/cuisines", principal: admin, wantCode: http.StatusOK},
+		{name: "Add cuisine", method: http.MethodPost, target: "/api/v1/cuisines", body: `{"name":"Thai","tags":["spicy"],"dishes":[{"name":"Pad Thai"}]}`, principal: admin, wantCode: http.StatusOK},
+		{name: "Add duplicate cuisine", method: http.MethodPost, target: "/api/v1/cuisines", body: `{"name":"Thai"}`, principal: admin, wantCode: http.StatusConflict},
+		{name: "Unauthorized", method: http.MethodGet, target: "/api/v1/cuisines", wantCode: http.StatusUnauthorized},
+		{name: "Rename cuisine", method: http.MethodPatch, target: "/api/v1/cuisines/" + cuisine.ID.Hex(), body: `{"name":"Isan"}`, principal: admin, wantCode: http.StatusOK},
+		{name: "Delete cuisine", method: http.MethodDelete, target: "/api/v1/cuisines/" + cuisine.ID.Hex(), principal: admin, wantCode: http.StatusOK},
+		{name: "Add dishes", method: http.MethodPost, target: "/api/v1/dishes", body: `{"cuisine":"` + cuisine.ID.Hex() + `","dishes":[{"name":"Pad Thai"}]}`, principal: admin, wantCode: http.StatusCreated},
+		{name: "Rename dish", method: http.MethodPatch, target: "/api/v1/dishes/" + dish.ID.Hex(), body: `{"name":"Pad See Ew"}`, principal: admin, wantCode: http.StatusOK},
+		{name: "Delete dish", method: http.MethodDelete, target: "/api/v1/dishes/" + dish.ID.Hex(), principal: admin, wantCode: http.StatusOK},
+		{name: "Legacy add dishes", method: http.MethodPost, target: "/api/add/all/dishes", body: `{"cuisine":"` + cuisine.ID.Hex() + `","dishes":[{"name":"Pad Thai"}]}`, principal: admin, wantCode: http.StatusCreated},
+		{name: "Pick", method: http.MethodGet, target: "/api/v1/pick?tag=spicy&tag=noodles&minRating=3", principal: admin, wantCode: http.StatusOK},
+		{name: "Dish ratings", method: http.MethodGet, target: "/api/v1/dishes/" + dish.ID.Hex() + "/ratings", principal: admin, wantCode: http.StatusOK},
+		{name: "Rate dish", method: http.MethodPut, target: "/api/v1/dishes/" + dish.ID.Hex() + "/rating", body: `{"score":5,"note":"great"}`, principal: admin, wantCode: http.StatusOK},
+		{name: "Current user", method: http.MethodGet, target: "/api/v1/users/me", principal: admin, wantCode: http.StatusOK},
+		{name: "Set preference", method: http.MethodPut, target: "/api/v1/users/me/preferences", body: `{"target":"cuisine","id":"` + cuisine.ID.Hex() + `","preference":"favorite"}`, principal: admin, wantCode: http.StatusOK},
+		{name: "My households", method: http.MethodGet, target: "/api/v1/households", principal: admin, wantCode: http.StatusOK},
+		{name: "Create household", method: http.MethodPost, target: "/api/v1/households", body: `{"name":"Home"}`, principal: admin, wantCode: http.StatusCreated},
+		{name: "Invite member", method: http.MethodPost, target: "/api/v1/households/" + household.ID.Hex() + "/members", body: `{"subject":"user-456","role":"editor"}`, principal: admin, wantCode: http.StatusOK},
+		{name: "Remove member", method: http.MethodDelete, target: "/api/v1/households/" + household.ID.Hex() + "/members/user-456", principal: admin, wantCode: http.StatusOK},
+		{name: "List keys", method: http.MethodGet, target: "/api/v1/admin/keys", principal: admin, wantCode: http.StatusOK},
+		{name: "Issue key", method: http.MethodPost, target: "/api/v1/admin/keys", body: `{"name":"frontend","role":"reader"}`, principal: admin, wantCode: http.StatusCreated},
+		{name: "Revoke key", method: http.MethodDelete, target: "/api/v1/admin/keys/" + apiKey.ID.Hex(), principal: admin, wantCode: http.StatusOK},
+		{name: "Legacy list cuisines", method: http.MethodGet, target: "/api/all/cuisines", principal: admin, wantCode: http.StatusOK},
+		{name: "Legacy rate dish", method: http.MethodPut, target: "/api/dishes/" + dish.ID.Hex() + "/rating", body: `{"score":4}`, principal: admin, wantCode: http.StatusOK},
+		{name: "GraphQL", method: http.MethodPost, target: "/graphql", body: `{"query":"{ cuisines { id name dishes { name ratings { score } } } }"}`, principal: admin, wantCode: http.StatusOK},
+		{name: "GraphQL error", method: http.MethodPost, target: "/graphql", body: `{"query":"{ cuisines { calories } }"}`, principal: admin, wantCode: http.StatusOK},
+		{name: "GraphQL empty query", method: http.MethodPost, target: "/graphql", body: `{"query":""}`, principal: admin, wantCode: http.StatusBadRequest},
+		{name: "OpenAPI document", method: http.MethodGet, target: "/api/openapi.json", wantCode: http.StatusOK},
+		{name: "Docs", method: http.MethodGet, target: "/api/docs", wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			if tt.principal != nil {
+				r = r.WithContext(auth.WithPrincipal(r.Context(), tt.principal))
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			var match mux.RouteMatch
+			if !assert.True(t, router.Match(r, &match)) {
+				return
+			}
+			template, _ := match.Route.GetPathTemplate()
+			pathItem := doc.Paths.Find(template)
+			if !assert.NotNil(t, pathItem, "path %v is not documented", template) {
+				return
+			}
+			requestInput := &openapi3filter.RequestValidationInput{
+				Request:    httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)),
+				PathParams: match.Vars,
+				Route: &routers.Route{
+					Spec:      doc,
+					Path:      template,
+					PathItem:  pathItem,
+					Method:    tt.method,
+					Operation: pathItem.GetOperation(tt.method),
+				},
+				Options: &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+			}
+			if tt.body != "" {
+				requestInput.Request.Header.Set("Content-Type", "application/json")
+			}
+			assert.NoError(t, openapi3filter.ValidateRequest(context.Background(), requestInput))
+
+			body, _ := ioutil.ReadAll(w.Result().Body)
+			err := openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: requestInput,
+				Status:                 w.Code,
+				Header:                 w.Header(),
+				Body:                   io.NopCloser(bytes.NewReader(body)),
+				Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+			})
+			assert.NoError(t, err, string(body))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+func (h Handler) RandomPick() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.PickResponse
+
+		defer func() {
+			response, status := setPickResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		apiRequest := models.PickRequest{
+			Tags: r.URL.Query()["tag"],
+		}
+		if minRating := r.URL.Query().Get("minRating"); minRating != "" {
+			value, err := strconv.ParseFloat(minRating, 64)
+			if err != nil {
+				response.Message.ErrorLog = errorLogs([]error{err}, "Unable to parse minRating", http.StatusBadRequest)
+				response.Message.Status = strconv.Itoa(http.StatusBadRequest)
+				return
+			}
+			apiRequest.MinRating = value
+		}
+
+		response = h.Service.RandomPick(r.Context(), apiRequest)
+	}
+}
+
+func setPickResponse(res models.PickResponse) (models.PickResponse, int) {
+	hn, _ := os.Hostname()
+	status, _ := strconv.Atoi(res.Message.Status)
+	res.Message.HostName = hn
+	return res, status
+}
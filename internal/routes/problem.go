@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"net/http"
+	"strconv"
+)
+
+const problemContentType = "application/problem+json"
+
+// writeResponse encodes response on success and an RFC 7807 problem built from message when the
+// status is an error.
+func writeResponse(w http.ResponseWriter, r *http.Request, status int, message models.Message, response interface{}) {
+	if status == 0 {
+		status = statusFromErrorLog(message.ErrorLog)
+		message.Status = strconv.Itoa(status)
+	}
+	if status >= http.StatusBadRequest {
+		writeProblem(w, r, status, message)
+		return
+	}
+	_ = json.NewEncoder(writeHeader(w, status)).Encode(response)
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, message models.Message) {
+	problem := models.Problem{
+		Title:     http.StatusText(status),
+		Status:    status,
+		Instance:  r.URL.Path,
+		Code:      apperrors.CodeForStatus(status),
+		RequestID: logging.RequestID(r.Context()),
+		Message:   message,
+	}
+	if len(message.ErrorLog) > 0 {
+		problem.Detail = message.ErrorLog[0].Trace
+		if message.ErrorLog[0].Code != "" {
+			problem.Code = message.ErrorLog[0].Code
+		}
+	}
+	for _, errLog := range message.ErrorLog {
+		if errLog.Field != "" {
+			problem.InvalidParams = append(problem.InvalidParams, models.InvalidParam{
+				Name:   errLog.Field,
+				Reason: errLog.Trace,
+			})
+		}
+	}
+	if len(problem.InvalidParams) > 0 {
+		problem.Detail = fmt.Sprintf("%v field(s) failed validation", len(problem.InvalidParams))
+	}
+	problem.Type = apperrors.TypeURI(problem.Code)
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// statusFromErrorLog recovers the status of handlers that failed before reaching the facade.
+func statusFromErrorLog(errLogs []models.ErrorLog) int {
+	for _, errLog := range errLogs {
+		if status, err := strconv.Atoi(errLog.Status); err == nil && status > 0 {
+			return status
+		}
+	}
+	if len(errLogs) > 0 {
+		return http.StatusInternalServerError
+	}
+	return http.StatusOK
+}
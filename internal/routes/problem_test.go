@@ -0,0 +1,156 @@
+package routes
+
+import (
+	"encoding/json"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestHandler_ProblemResponses(t *testing.T) {
+	tests := []struct {
+		name        string
+		facadeRes   models.CuisineResponse
+		wantCode    int
+		wantType    string
+		wantProblem models.Problem
+	}{
+		{
+			name: "Duplicate cuisine is a conflict",
+			facadeRes: models.CuisineResponse{
+				Message: models.Message{
+					Status: strconv.Itoa(http.StatusConflict),
+					ErrorLog: []models.ErrorLog{{
+						Status:    strconv.Itoa(http.StatusConflict),
+						Code:      "cuisine_already_exists",
+						RootCause: "Insertion error",
+						Trace:     "cuisine Thai already exists",
+					}},
+				},
+			},
+			wantCode: http.StatusConflict,
+			wantType: problemContentType,
+			wantProblem: models.Problem{
+				Type:     "urn:food-roulette-api:problem:cuisine_already_exists",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "cuisine Thai already exists",
+				Instance: "/api/add/cuisine",
+				Code:     "cuisine_already_exists",
+			},
+		},
+		{
+			name: "Untyped server error falls back to the generic code",
+			facadeRes: models.CuisineResponse{
+				Message: models.Message{
+					Status: strconv.Itoa(http.StatusInternalServerError),
+					ErrorLog: []models.ErrorLog{{
+						Status:    strconv.Itoa(http.StatusInternalServerError),
+						RootCause: "Insertion error",
+						Trace:     "Internal Server Error",
+					}},
+				},
+			},
+			wantCode: http.StatusInternalServerError,
+			wantType: problemContentType,
+			wantProblem: models.Problem{
+				Type:     "urn:food-roulette-api:problem:internal_error",
+				Title:    "Internal Server Error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "Internal Server Error",
+				Instance: "/api/add/cuisine",
+				Code:     "internal_error",
+			},
+		},
+		{
+			name: "Success stays a plain json response",
+			facadeRes: models.CuisineResponse{
+				Cuisine: &models.Cuisine{Name: "Thai"},
+				Message: models.Message{Status: strconv.Itoa(http.StatusOK)},
+			},
+			wantCode: http.StatusOK,
+			wantType: "application/json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFacade := facade.NewMockServiceI(ctrl)
+			router := Handler{Service: mockFacade}.InitializeRoutes()
+
+			mockFacade.EXPECT().AddCuisine(gomock.Any(), gomock.Any()).Return(tt.facadeRes)
+			r := httptest.NewRequest(http.MethodPost, "/api/add/cuisine", strings.NewReader(`{"name":"Thai"}`))
+			r = r.WithContext(withEditor(r))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantType, w.Header().Get("Content-Type"))
+			if tt.wantCode < http.StatusBadRequest {
+				return
+			}
+			var problem models.Problem
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+			assert.NotEmpty(t, problem.RequestID)
+			// the legacy envelope is kept for existing clients
+			assert.Equal(t, tt.facadeRes.Message.ErrorLog, problem.Message.ErrorLog)
+			problem.RequestID = ""
+			problem.Message = models.Message{}
+			assert.Equal(t, tt.wantProblem, problem)
+		})
+	}
+}
+
+func TestHandler_ProblemResponses_RequestErrors(t *testing.T) {
+	router := Handler{}.InitializeRoutes()
+
+	r := httptest.NewRequest(http.MethodPost, "/api/add/cuisine", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	var problem models.Problem
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, problemContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "unauthorized", problem.Code)
+	assert.Equal(t, "missing or invalid credentials", problem.Detail)
+}
+
+func TestHandler_ProblemResponses_InvalidParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	router := Handler{Service: mockFacade}.InitializeRoutes()
+
+	status := strconv.Itoa(http.StatusBadRequest)
+	mockFacade.EXPECT().AddCuisine(gomock.Any(), gomock.Any()).Return(models.CuisineResponse{
+		Message: models.Message{
+			Status: status,
+			ErrorLog: []models.ErrorLog{
+				{Status: status, Code: "validation_failed", Field: "name", RootCause: "Validation error", Trace: "is required"},
+				{Status: status, Code: "validation_failed", Field: "dishes[1].tags[0]", RootCause: "Validation error", Trace: "must be lowercase words joined by hyphens and at most 32 characters"},
+			},
+		},
+	})
+	r := httptest.NewRequest(http.MethodPost, "/api/add/cuisine", strings.NewReader(`{}`))
+	r = r.WithContext(withEditor(r))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	var problem models.Problem
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "validation_failed", problem.Code)
+	assert.Equal(t, "2 field(s) failed validation", problem.Detail)
+	assert.Equal(t, []models.InvalidParam{
+		{Name: "name", Reason: "is required"},
+		{Name: "dishes[1].tags[0]", Reason: "must be lowercase words joined by hyphens and at most 32 characters"},
+	}, problem.InvalidParams)
+}
@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"encoding/json"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"github.com/gorilla/mux"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+func (h Handler) RateDish() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.RatingResponse
+
+		defer func() {
+			response, status := setRatingResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		apiRequest := models.RateDishRequest{}
+		requestBody, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			response.Message.ErrorLog = errorLogs([]error{readErr}, "Unable to read request body", readStatus(readErr))
+			response.Message.Status = strconv.Itoa(readStatus(readErr))
+			return
+		}
+		if err := json.Unmarshal(requestBody, &apiRequest); err != nil {
+			response.Message.ErrorLog = errorLogs([]error{err}, "Unable to parse request", http.StatusBadRequest)
+			response.Message.Status = strconv.Itoa(http.StatusBadRequest)
+			return
+		}
+
+		response = h.Service.RateDish(r.Context(), mux.Vars(r)["id"], apiRequest)
+	}
+}
+
+func (h Handler) GetDishRatings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.AllRatingsResponse
+
+		defer func() {
+			response, status := setAllRatingsResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		response = h.Service.DishRatings(r.Context(), mux.Vars(r)["id"])
+	}
+}
+
+func setRatingResponse(res models.RatingResponse) (models.RatingResponse, int) {
+	hn, _ := os.Hostname()
+	status, _ := strconv.Atoi(res.Message.Status)
+	res.Message.HostName = hn
+	return res, status
+}
+
+func setAllRatingsResponse(res models.AllRatingsResponse) (models.AllRatingsResponse, int) {
+	hn, _ := os.Hostname()
+	status, _ := strconv.Atoi(res.Message.Status)
+	res.Message.HostName = hn
+	return res, status
+}
@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/tenant"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// TenantScoped protects routes over household owned data: the household is resolved first so
+// that role is checked against the caller's household role rather than their service role.
+func (h Handler) TenantScoped(role auth.Role, next http.Handler) http.Handler {
+	return h.ResolveTenant(h.RequireRole(role, next))
+}
+
+// ResolveTenant scopes the request to the household named in the X-Household-ID header after
+// checking the caller is a member, and replaces the caller's role with their household role.
+// Requests without the header stay on the default tenant.
+func (h Handler) ResolveTenant(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		householdId := r.Header.Get(tenant.Header)
+		if householdId == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, ok := auth.PrincipalFromContext(r.Context())
+		if !ok {
+			writeErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", fmt.Errorf("households require an authenticated caller"))
+			return
+		}
+
+		id, role, err := h.Service.HouseholdRole(r.Context(), householdId)
+		switch {
+		case errors.Is(err, facade.ErrInvalidHousehold):
+			writeErrorResponse(w, r, http.StatusBadRequest, "Validation error", err)
+			return
+		case errors.Is(err, facade.ErrNotMember):
+			writeErrorResponse(w, r, http.StatusForbidden, "Forbidden", err)
+			return
+		case err != nil:
+			logrus.Errorf("household lookup failed: %v", err.Error())
+			writeErrorResponse(w, r, http.StatusInternalServerError, "Membership error", err)
+			return
+		}
+
+		scoped := *principal
+		scoped.Role = role
+		ctx := tenant.WithHousehold(auth.WithPrincipal(r.Context(), &scoped), id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_TenantScoped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	householdId := primitive.NewObjectID()
+
+	tests := []struct {
+		name          string
+		householdId   string
+		principal     *auth.Principal
+		householdRole auth.Role
+		roleErr       error
+		wantCode      int
+		wantHousehold bool
+	}{
+		{
+			name:      "No header uses the default tenant",
+			principal: &auth.Principal{Subject: "user-123", Role: auth.RoleEditor},
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:          "Household editor may write",
+			householdId:   householdId.Hex(),
+			principal:     &auth.Principal{Subject: "user-123", Role: auth.RoleReader},
+			householdRole: auth.RoleEditor,
+			wantCode:      http.StatusOK,
+			wantHousehold: true,
+		},
+		{
+			name:          "Household reader may not write",
+			householdId:   householdId.Hex(),
+			principal:     &auth.Principal{Subject: "user-123", Role: auth.RoleEditor},
+			householdRole: auth.RoleReader,
+			wantCode:      http.StatusForbidden,
+		},
+		{
+			name:        "Non member is forbidden",
+			householdId: householdId.Hex(),
+			principal:   &auth.Principal{Subject: "user-123", Role: auth.RoleEditor},
+			roleErr:     facade.ErrNotMember,
+			wantCode:    http.StatusForbidden,
+		},
+		{
+			name:        "Anonymous caller is unauthorized",
+			householdId: householdId.Hex(),
+			wantCode:    http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Handler{
+				Service: mockFacade,
+			}
+			mockFacade.EXPECT().HouseholdRole(gomock.Any(), tt.householdId).Return(householdId, tt.householdRole, tt.roleErr).MaxTimes(1)
+
+			var gotHousehold bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, gotHousehold = tenant.HouseholdFromContext(r.Context())
+			})
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/api/add/cuisine", nil)
+			if tt.principal != nil {
+				r = r.WithContext(auth.WithPrincipal(r.Context(), tt.principal))
+			}
+			if tt.householdId != "" {
+				r.Header.Set(tenant.Header, tt.householdId)
+			}
+			h.TenantScoped(auth.RoleEditor, next).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantHousehold, gotHousehold)
+		})
+	}
+}
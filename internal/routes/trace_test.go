@@ -0,0 +1,64 @@
+package routes
+
+import (
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	"food-roulette-api/internal/tracing"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_Trace_SpanTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	mockMongoSvc := mongodb.NewMockServiceI(ctrl)
+	mockMongoSvc.EXPECT().GetAllCuisines(gomock.Any()).Return([]*models.Cuisine{{Name: "thai"}}, nil)
+	service := &facade.Service{MongoService: mockMongoSvc}
+	service.Instrument(tracing.StorageObserver{})
+	h := Handler{
+		Service: &facade.TracedService{Next: service},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/all/cuisines", nil)
+	r = r.WithContext(withReader(r))
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	h.InitializeRoutes().ServeHTTP(w, r)
+
+	spans := exporter.GetSpans()
+	byName := map[string]tracetest.SpanStub{}
+	for _, span := range spans {
+		byName[span.Name] = span
+	}
+	server, ok := byName["GET /api/all/cuisines"]
+	assert.True(t, ok, "expected a server span")
+	facadeSpan, ok := byName["facade.AllCuisines"]
+	assert.True(t, ok, "expected a facade span")
+	storageSpan, ok := byName["storage.GetAllCuisines"]
+	assert.True(t, ok, "expected a storage span")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, spans, 3)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	assert.Equal(t, server.SpanContext.SpanID(), facadeSpan.Parent.SpanID())
+	assert.Equal(t, facadeSpan.SpanContext.SpanID(), storageSpan.Parent.SpanID())
+}
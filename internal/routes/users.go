@@ -0,0 +1,64 @@
+package routes
+
+import (
+	"encoding/json"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+func (h Handler) GetCurrentUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.UserResponse
+
+		defer func() {
+			response, status := setUserResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		response = h.Service.CurrentUser(r.Context())
+	}
+}
+
+func (h Handler) SetPreference() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var response models.UserResponse
+
+		defer func() {
+			response, status := setUserResponse(response)
+			response.Message.TimeTaken = time.Since(startTime).String()
+			response.Message.RequestID = logging.RequestID(r.Context())
+			writeResponse(w, r, status, response.Message, response)
+		}()
+
+		apiRequest := models.SetPreferenceRequest{}
+		requestBody, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			response.Message.ErrorLog = errorLogs([]error{readErr}, "Unable to read request body", readStatus(readErr))
+			response.Message.Status = strconv.Itoa(readStatus(readErr))
+			return
+		}
+		if err := json.Unmarshal(requestBody, &apiRequest); err != nil {
+			response.Message.ErrorLog = errorLogs([]error{err}, "Unable to parse request", http.StatusBadRequest)
+			response.Message.Status = strconv.Itoa(http.StatusBadRequest)
+			return
+		}
+
+		response = h.Service.SetPreference(r.Context(), apiRequest)
+	}
+}
+
+func setUserResponse(res models.UserResponse) (models.UserResponse, int) {
+	hn, _ := os.Hostname()
+	status, _ := strconv.Atoi(res.Message.Status)
+	res.Message.HostName = hn
+	return res, status
+}
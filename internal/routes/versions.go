@@ -0,0 +1,240 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"github.com/gorilla/mux"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// versionHeader names the version that served a response. Requests to unversioned legacy
+	// paths may send it to pick the version they are served by.
+	versionHeader  = "API-Version"
+	defaultVersion = "v1"
+)
+
+// Legacy unversioned paths were deprecated on legacyDeprecatedAt and are removed at legacySunset.
+var (
+	legacyDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	legacySunset       = time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)
+)
+
+// route is one operation of a versioned API. path is relative to the version prefix and legacy is
+// the unversioned path the operation was served at before versioning, if any.
+type route struct {
+	method  string
+	path    string
+	handler http.Handler
+	legacy  string
+}
+
+// apiVersion is one major version of the resource API, served under /api/{name}. A new version is
+// added by appending it here with its own route table, reusing the handlers that did not change.
+type apiVersion struct {
+	name   string
+	routes func(h Handler) []route
+}
+
+var apiVersions = []apiVersion{
+	{name: "v1", routes: Handler.v1Routes},
+}
+
+var versionPath = regexp.MustCompile(`^/api/(v[0-9]+)(/|$)`)
+
+type versionKey struct{}
+
+// APIVersion returns the API version a request is being served by.
+func APIVersion(ctx context.Context) string {
+	if version, ok := ctx.Value(versionKey{}).(string); ok {
+		return version
+	}
+	return defaultVersion
+}
+
+func (h Handler) v1Routes() []route {
+	return []route{
+		{method: http.MethodGet, path: "/cuisines", handler: h.TenantScoped(auth.RoleReader, h.GetAllCuisines()), legacy: "/api/all/cuisines"},
+		{method: http.MethodPost, path: "/cuisines", handler: h.TenantScoped(auth.RoleEditor, h.AddNewCuisine()), legacy: "/api/add/cuisine"},
+		{method: http.MethodPatch, path: "/cuisines/{id}", handler: h.TenantScoped(auth.RoleEditor, h.RenameCuisine())},
+		{method: http.MethodDelete, path: "/cuisines/{id}", handler: h.TenantScoped(auth.RoleEditor, h.DeleteCuisine())},
+		{method: http.MethodPost, path: "/dishes", handler: h.TenantScoped(auth.RoleEditor, h.AddDishes()), legacy: "/api/add/all/dishes"},
+		{method: http.MethodPatch, path: "/dishes/{id}", handler: h.TenantScoped(auth.RoleEditor, h.RenameDish())},
+		{method: http.MethodDelete, path: "/dishes/{id}", handler: h.TenantScoped(auth.RoleEditor, h.DeleteDish())},
+		{method: http.MethodGet, path: "/pick", handler: h.TenantScoped(auth.RoleReader, h.RandomPick()), legacy: "/api/pick"},
+
+		{method: http.MethodGet, path: "/dishes/{id}/ratings", handler: h.TenantScoped(auth.RoleReader, h.GetDishRatings()), legacy: "/api/dishes/{id}/ratings"},
+		{method: http.MethodPut, path: "/dishes/{id}/rating", handler: h.TenantScoped(auth.RoleReader, h.RateDish()), legacy: "/api/dishes/{id}/rating"},
+
+		// Personal favorites and dislikes of the authenticated caller
+		{method: http.MethodGet, path: "/users/me", handler: h.RequireRole(auth.RoleReader, h.GetCurrentUser()), legacy: "/api/users/me"},
+		{method: http.MethodPut, path: "/users/me/preferences", handler: h.RequireRole(auth.RoleReader, h.SetPreference()), legacy: "/api/users/me/preferences"},
+
+		// Households own their cuisines and dishes; members are invited with a household role
+		{method: http.MethodGet, path: "/households", handler: h.RequireRole(auth.RoleReader, h.GetMyHouseholds()), legacy: "/api/households"},
+		{method: http.MethodPost, path: "/households", handler: h.RequireRole(auth.RoleReader, h.CreateHousehold()), legacy: "/api/households"},
+		{method: http.MethodPost, path: "/households/{id}/members", handler: h.RequireRole(auth.RoleReader, h.InviteMember()), legacy: "/api/households/{id}/members"},
+		{method: http.MethodDelete, path: "/households/{id}/members/{subject}", handler: h.RequireRole(auth.RoleReader, h.RemoveMember()), legacy: "/api/households/{id}/members/{subject}"},
+
+		// Webhooks of the household, signed with the secret returned when they are registered
+		{method: http.MethodGet, path: "/webhooks", handler: h.TenantScoped(auth.RoleEditor, h.GetAllWebhooks())},
+		{method: http.MethodPost, path: "/webhooks", handler: h.TenantScoped(auth.RoleEditor, h.AddWebhook())},
+		{method: http.MethodDelete, path: "/webhooks/{id}", handler: h.TenantScoped(auth.RoleEditor, h.DeleteWebhook())},
+		{method: http.MethodGet, path: "/webhooks/{id}/deliveries", handler: h.TenantScoped(auth.RoleEditor, h.GetWebhookDeliveries())},
+		{method: http.MethodPost, path: "/webhooks/{id}/deliveries/{delivery}/replay", handler: h.TenantScoped(auth.RoleEditor, h.ReplayDelivery())},
+
+		// Slack and Mattermost slash commands, verified by their signature or token instead of credentials
+		{method: http.MethodPost, path: "/chat/commands", handler: h.ChatCommand()},
+		{method: http.MethodPost, path: "/chat/interactions", handler: h.ChatInteraction()},
+
+		// API key administration
+		{method: http.MethodGet, path: "/admin/keys", handler: h.RequireRole(auth.RoleAdmin, h.GetAllApiKeys()), legacy: "/api/admin/keys"},
+		{method: http.MethodPost, path: "/admin/keys", handler: h.RequireRole(auth.RoleAdmin, h.IssueApiKey()), legacy: "/api/admin/keys"},
+		{method: http.MethodDelete, path: "/admin/keys/{id}", handler: h.RequireRole(auth.RoleAdmin, h.RevokeApiKey()), legacy: "/api/admin/keys/{id}"},
+	}
+}
+
+// registerVersions serves every API version under its prefix and keeps each legacy path as a
+// deprecated alias that negotiates which version serves it.
+func (h Handler) registerVersions(r *mux.Router) {
+	type legacyKey struct{ method, path string }
+	var legacyOrder []legacyKey
+	successors := make(map[legacyKey]map[string]route)
+
+	for _, version := range apiVersions {
+		sub := r.PathPrefix("/api/" + version.name).Subrouter()
+		sub.Use(withVersion(version.name))
+		for _, rt := range version.routes(h) {
+			sub.Handle(rt.path, rt.handler).Methods(rt.method)
+			if rt.legacy == "" {
+				continue
+			}
+			key := legacyKey{method: rt.method, path: rt.legacy}
+			if successors[key] == nil {
+				successors[key] = make(map[string]route)
+				legacyOrder = append(legacyOrder, key)
+			}
+			successors[key][version.name] = rt
+		}
+	}
+
+	for _, key := range legacyOrder {
+		r.Handle(key.path, legacyAlias(successors[key])).Methods(key.method)
+	}
+}
+
+// withVersion records the version serving a request and echoes it in the API-Version header.
+func withVersion(version string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(versionHeader, version)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), versionKey{}, version)))
+		})
+	}
+}
+
+// legacyAlias serves a deprecated unversioned path with the route of the version named by the
+// API-Version request header, or of the default version when the header is absent.
+func legacyAlias(successors map[string]route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version := r.Header.Get(versionHeader)
+		if version == "" {
+			version = defaultVersion
+		}
+		rt, ok := successors[version]
+		if !ok {
+			writeErrorResponse(w, r, http.StatusBadRequest, "Unsupported API version", apperrors.New(apperrors.ErrValidation, apperrors.CodeUnsupportedVersion,
+				fmt.Sprintf("API version %v does not serve %v %v", version, r.Method, r.URL.Path)))
+			return
+		}
+		deprecate(w, successorPath(version, rt.path, mux.Vars(r)))
+		withVersion(version)(rt.handler).ServeHTTP(w, r)
+	})
+}
+
+// deprecated serves a legacy path that has no successor in any version.
+func deprecated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deprecate(w, "")
+		withVersion(defaultVersion)(next).ServeHTTP(w, r)
+	})
+}
+
+// deprecate sets the RFC 9745 Deprecation and RFC 8594 Sunset headers, linking the successor
+// path when there is one.
+func deprecate(w http.ResponseWriter, successor string) {
+	w.Header().Set("Deprecation", "@"+strconv.FormatInt(legacyDeprecatedAt.Unix(), 10))
+	w.Header().Set("Sunset", legacySunset.Format(http.TimeFormat))
+	if successor != "" {
+		w.Header().Set("Link", fmt.Sprintf(`<%v>; rel="successor-version"`, successor))
+	}
+}
+
+// successorPath expands the versioned template of a route with the variables of the legacy match.
+func successorPath(version string, template string, vars map[string]string) string {
+	path := "/api/" + version + template
+	for name, value := range vars {
+		path = strings.ReplaceAll(path, "{"+name+"}", value)
+	}
+	return path
+}
+
+func supportedVersions() []string {
+	names := make([]string, len(apiVersions))
+	for i, version := range apiVersions {
+		names[i] = version.name
+	}
+	return names
+}
+
+func supportedVersion(name string) bool {
+	for _, version := range apiVersions {
+		if version.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NotFound answers unmatched paths with a problem, naming the supported versions when the path
+// asks for a version that does not exist.
+func (h Handler) NotFound() http.Handler {
+	return h.RequestID(h.LogAccess(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if match := versionPath.FindStringSubmatch(r.URL.Path); match != nil && !supportedVersion(match[1]) {
+			writeErrorResponse(w, r, http.StatusNotFound, "Unsupported API version", apperrors.New(apperrors.ErrNotFound, apperrors.CodeUnsupportedVersion,
+				fmt.Sprintf("API version %v is not supported, use one of: %v", match[1], strings.Join(supportedVersions(), ", "))))
+			return
+		}
+		writeErrorResponse(w, r, http.StatusNotFound, "Not found", fmt.Errorf("no route for %v", r.URL.Path))
+	})))
+}
+
+// MethodNotAllowed answers known paths requested with an unsupported method with a problem and an
+// Allow header listing the methods router accepts for the path.
+func (h Handler) MethodNotAllowed(router *mux.Router) http.Handler {
+	return h.RequestID(h.LogAccess(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", strings.Join(allowedMethods(router, r), ", "))
+		writeErrorResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed", fmt.Errorf("method %v is not allowed for %v", r.Method, r.URL.Path))
+	})))
+}
+
+// allowedMethods lists the methods router has a route for at the path of r, by matching r again
+// with each method in turn so versioned and legacy paths resolve as they would for a real request.
+func allowedMethods(router *mux.Router, r *http.Request) []string {
+	var allowed []string
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions} {
+		probe := r.Clone(r.Context())
+		probe.Method = method
+		var match mux.RouteMatch
+		if router.Match(probe, &match) && match.MatchErr == nil {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,143 @@
+package routes
+
+import (
+	"encoding/json"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestHandler_Versions(t *testing.T) {
+	const dishId = "63a0f0c2e4b0a1b2c3d4e5f6"
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		version         string
+		wantCode        int
+		wantVersion     string
+		wantDeprecation bool
+		wantLink        string
+		wantProblemCode string
+		wantAllow       string
+	}{
+		{
+			name:        "Versioned path",
+			method:      http.MethodGet,
+			target:      "/api/v1/cuisines",
+			wantCode:    http.StatusOK,
+			wantVersion: "v1",
+		},
+		{
+			name:            "Legacy path is a deprecated alias",
+			method:          http.MethodGet,
+			target:          "/api/all/cuisines",
+			wantCode:        http.StatusOK,
+			wantVersion:     "v1",
+			wantDeprecation: true,
+			wantLink:        `</api/v1/cuisines>; rel="successor-version"`,
+		},
+		{
+			name:            "Legacy path links its expanded successor",
+			method:          http.MethodGet,
+			target:          "/api/dishes/" + dishId + "/ratings",
+			wantCode:        http.StatusOK,
+			wantVersion:     "v1",
+			wantDeprecation: true,
+			wantLink:        `</api/v1/dishes/` + dishId + `/ratings>; rel="successor-version"`,
+		},
+		{
+			name:            "Legacy path negotiates the requested version",
+			method:          http.MethodGet,
+			target:          "/api/all/cuisines",
+			version:         "v1",
+			wantCode:        http.StatusOK,
+			wantVersion:     "v1",
+			wantDeprecation: true,
+			wantLink:        `</api/v1/cuisines>; rel="successor-version"`,
+		},
+		{
+			name:            "Legacy path rejects an unknown requested version",
+			method:          http.MethodGet,
+			target:          "/api/all/cuisines",
+			version:         "v9",
+			wantCode:        http.StatusBadRequest,
+			wantProblemCode: "unsupported_api_version",
+		},
+		{
+			name:            "Unknown version in the path",
+			method:          http.MethodGet,
+			target:          "/api/v2/cuisines",
+			wantCode:        http.StatusNotFound,
+			wantProblemCode: "unsupported_api_version",
+		},
+		{
+			name:            "Unknown resource in a known version",
+			method:          http.MethodGet,
+			target:          "/api/v1/menus",
+			wantCode:        http.StatusNotFound,
+			wantProblemCode: "not_found",
+		},
+		{
+			name:            "Unsupported method",
+			method:          http.MethodDelete,
+			target:          "/api/v1/cuisines",
+			wantCode:        http.StatusMethodNotAllowed,
+			wantProblemCode: "method_not_allowed",
+			wantAllow:       "GET, POST",
+		},
+		{
+			name:            "Unsupported method on a legacy path",
+			method:          http.MethodPost,
+			target:          "/api/all/cuisines",
+			wantCode:        http.StatusMethodNotAllowed,
+			wantProblemCode: "method_not_allowed",
+			wantAllow:       "GET",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFacade := facade.NewMockServiceI(ctrl)
+			router := Handler{Service: mockFacade}.InitializeRoutes()
+
+			ok := models.Message{Status: strconv.Itoa(http.StatusOK)}
+			mockFacade.EXPECT().AllCuisines(gomock.Any()).Return(models.AllCuisinesResponse{Message: ok}).AnyTimes()
+			mockFacade.EXPECT().DishRatings(gomock.Any(), dishId).Return(models.AllRatingsResponse{Message: ok}).AnyTimes()
+
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.version != "" {
+				r.Header.Set(versionHeader, tt.version)
+			}
+			r = r.WithContext(withReader(r))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantVersion, w.Header().Get(versionHeader))
+			if tt.wantDeprecation {
+				assert.Equal(t, "@1792368000", w.Header().Get("Deprecation"))
+				assert.Equal(t, "Mon, 19 Apr 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+			} else {
+				assert.Empty(t, w.Header().Get("Deprecation"))
+				assert.Empty(t, w.Header().Get("Sunset"))
+			}
+			assert.Equal(t, tt.wantLink, w.Header().Get("Link"))
+			assert.Equal(t, tt.wantAllow, w.Header().Get("Allow"))
+			if tt.wantProblemCode == "" {
+				return
+			}
+			var problem models.Problem
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+			assert.Equal(t, problemContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantProblemCode, problem.Code)
+			assert.NotEmpty(t, problem.RequestID)
+		})
+	}
+}
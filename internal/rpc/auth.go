@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/rpc/pickerpb"
+	"food-roulette-api/internal/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"strings"
+)
+
+// Metadata keys mirror the HTTP headers, lowercased as gRPC requires.
+const (
+	apiKeyMetadata    = "x-api-key"
+	requestIdMetadata = "x-request-id"
+	householdMetadata = "x-household-id"
+)
+
+// methodRoles is the role each picker method requires. Methods not listed, such as health
+// checks and reflection, are served without credentials; reflection is only registered when
+// Options.Reflection is set.
+var methodRoles = map[string]auth.Role{
+	pickerpb.PickerService_AddCuisine_FullMethodName:   auth.RoleEditor,
+	pickerpb.PickerService_ListCuisines_FullMethodName: auth.RoleReader,
+	pickerpb.PickerService_AddDishes_FullMethodName:    auth.RoleEditor,
+	pickerpb.PickerService_RandomPick_FullMethodName:   auth.RoleReader,
+}
+
+type authenticator struct {
+	service  facade.ServiceI
+	verifier auth.TokenVerifier
+}
+
+// Unary propagates the request ID, then authenticates the caller, scopes the call to the
+// requested household and checks the method's role, like the HTTP middleware chain.
+func (a authenticator) Unary(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestId := first(md, requestIdMetadata)
+	if !logging.ValidRequestID(requestId) {
+		requestId = logging.NewRequestID()
+	}
+	ctx = logging.WithRequestID(ctx, requestId)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIdMetadata, requestId))
+
+	role, protected := methodRoles[info.FullMethod]
+	if !protected {
+		return handler(ctx, request)
+	}
+
+	principal := a.principal(ctx, md)
+	if principal == nil {
+		return nil, newStatus(codes.Unauthenticated, apperrors.CodeUnauthorized, "missing or invalid credentials")
+	}
+	ctx = auth.WithPrincipal(ctx, principal)
+	if householdId := first(md, householdMetadata); householdId != "" {
+		id, householdRole, err := a.service.HouseholdRole(ctx, householdId)
+		switch {
+		case errors.Is(err, facade.ErrInvalidHousehold):
+			return nil, newStatus(codes.InvalidArgument, apperrors.Code(err), apperrors.Detail(err, http.StatusBadRequest))
+		case errors.Is(err, facade.ErrNotMember):
+			return nil, newStatus(codes.PermissionDenied, apperrors.Code(err), apperrors.Detail(err, http.StatusForbidden))
+		case err != nil:
+			logging.FromContext(ctx).Errorf("household lookup failed: %v", err.Error())
+			return nil, newStatus(codes.Internal, apperrors.CodeInternal, "household lookup failed")
+		}
+		scoped := *principal
+		scoped.Role = householdRole
+		principal = &scoped
+		ctx = tenant.WithHousehold(auth.WithPrincipal(ctx, principal), id)
+	}
+	if !principal.Role.Allows(role) {
+		return nil, newStatus(codes.PermissionDenied, apperrors.CodeForbidden, fmt.Sprintf("role %v is required for this method", role))
+	}
+
+	return handler(ctx, request)
+}
+
+// principal resolves the caller from a bearer token or an API key; nil means unauthenticated.
+func (a authenticator) principal(ctx context.Context, md metadata.MD) *auth.Principal {
+	scheme, value, found := strings.Cut(first(md, "authorization"), " ")
+	if found && strings.EqualFold(scheme, "Bearer") && a.verifier != nil {
+		principal, err := a.verifier.Verify(ctx, strings.TrimSpace(value))
+		if err != nil {
+			logging.FromContext(ctx).Debugf("bearer token rejected: %v", err.Error())
+			return nil
+		}
+		return principal
+	}
+
+	key := first(md, apiKeyMetadata)
+	if key == "" && found && strings.EqualFold(scheme, "ApiKey") {
+		key = strings.TrimSpace(value)
+	}
+	if key == "" {
+		return nil
+	}
+	principal, err := a.service.Authenticate(ctx, key)
+	if err != nil {
+		if !errors.Is(err, facade.ErrInvalidApiKey) {
+			logging.FromContext(ctx).Errorf("api key lookup failed: %v", err.Error())
+		}
+		return nil
+	}
+	return principal
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/rpc/pickerpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toCuisine(cuisine *models.Cuisine) *pickerpb.Cuisine {
+	if cuisine == nil {
+		return nil
+	}
+	result := &pickerpb.Cuisine{
+		Id:          cuisine.ID.Hex(),
+		Name:        cuisine.Name,
+		Tags:        cuisine.Tags,
+		HouseholdId: hexOrEmpty(cuisine.HouseholdID),
+		CreatedBy:   cuisine.CreatedBy,
+		Rating:      toRatingSummary(cuisine.Rating),
+	}
+	if cuisine.CreatedAt != nil {
+		result.CreatedAt = timestamppb.New(*cuisine.CreatedAt)
+	}
+	for i := range cuisine.Dishes {
+		result.Dishes = append(result.Dishes, toDish(&cuisine.Dishes[i]))
+	}
+	return result
+}
+
+func toDish(dish *models.Dish) *pickerpb.Dish {
+	if dish == nil {
+		return nil
+	}
+	return &pickerpb.Dish{
+		Id:          dish.ID.Hex(),
+		CuisineId:   hexOrEmpty(dish.Cuisine),
+		Name:        dish.Name,
+		Tags:        dish.Tags,
+		HouseholdId: hexOrEmpty(dish.HouseholdID),
+		Rating:      toRatingSummary(dish.Rating),
+	}
+}
+
+func toRatingSummary(summary *models.RatingSummary) *pickerpb.RatingSummary {
+	if summary == nil {
+		return nil
+	}
+	return &pickerpb.RatingSummary{Average: summary.Average, Count: int32(summary.Count)}
+}
+
+func fromNewDishes(dishes []*pickerpb.NewDish) []models.Dish {
+	var results []models.Dish
+	for _, dish := range dishes {
+		results = append(results, models.Dish{Name: dish.GetName(), Tags: dish.GetTags()})
+	}
+	return results
+}
+
+// hexOrEmpty leaves unset references empty rather than sending the zero ObjectID.
+func hexOrEmpty(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.Hex()
+}
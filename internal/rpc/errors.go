@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strconv"
+)
+
+// errorDomain scopes the stable problem codes sent as ErrorInfo reasons.
+const errorDomain = "food-roulette-api"
+
+// statusFromMessage converts a failed facade response into a gRPC status carrying the problem
+// code as ErrorInfo and field violations as BadRequest details, or returns nil on success.
+func statusFromMessage(message models.Message) error {
+	httpStatus, _ := strconv.Atoi(message.Status)
+	if httpStatus < http.StatusBadRequest && len(message.ErrorLog) == 0 {
+		return nil
+	}
+	if httpStatus == 0 {
+		httpStatus = http.StatusInternalServerError
+	}
+
+	code := apperrors.CodeForStatus(httpStatus)
+	detail := http.StatusText(httpStatus)
+	if len(message.ErrorLog) > 0 {
+		detail = message.ErrorLog[0].Trace
+		if message.ErrorLog[0].Code != "" {
+			code = message.ErrorLog[0].Code
+		}
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, errLog := range message.ErrorLog {
+		if errLog.Field != "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: errLog.Field, Description: errLog.Trace})
+		}
+	}
+	if len(violations) > 0 {
+		detail = fmt.Sprintf("%v field(s) failed validation", len(violations))
+	}
+
+	return newStatus(grpcCode(httpStatus), code, detail, violations...)
+}
+
+func newStatus(grpcCode codes.Code, code string, detail string, violations ...*errdetails.BadRequest_FieldViolation) error {
+	st := status.New(grpcCode, detail)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{Reason: code, Domain: errorDomain})
+	if err != nil {
+		return st.Err()
+	}
+	if len(violations) > 0 {
+		if withViolations, err := withDetails.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+			withDetails = withViolations
+		}
+	}
+	return withDetails.Err()
+}
+
+// grpcCode maps the HTTP status chosen by the facade to the equivalent gRPC code.
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
@@ -0,0 +1,115 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// limits applies the HTTP API's rate limits, from the same store, to unary calls. Health checks
+// are probed by orchestrators and are never limited.
+type limits struct {
+	limiter *ratelimit.Limiter
+}
+
+// LimitIP runs before the authenticator and turns away clients whose IP bucket is empty. Calls
+// whose credentials are rejected are charged to the IP bucket afterwards, so a client guessing
+// keys stops reaching the key lookup once its failures have used up the bucket.
+func (l limits) LimitIP(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if unlimitedMethod(info.FullMethod) {
+		return handler(ctx, request)
+	}
+	ip := peerAddr(ctx)
+	result, limited, err := l.limiter.PeekIP(ctx, ip)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("rate limit store failed: %v", err.Error())
+	}
+	if err == nil && limited && !result.Allowed {
+		return nil, rateLimited(ctx, result)
+	}
+
+	response, err := handler(ctx, request)
+	if status.Code(err) == codes.Unauthenticated {
+		if _, _, takeErr := l.limiter.Take(ctx, "", ip); takeErr != nil {
+			logging.FromContext(ctx).Errorf("rate limit store failed: %v", takeErr.Error())
+		}
+	}
+	return response, err
+}
+
+// Take runs after the authenticator and counts each call against the caller's bucket: the
+// authenticated principal's when there is one, the client IP's otherwise. Every limited call
+// carries the ratelimit-* headers; rejected calls also get retry-after and RESOURCE_EXHAUSTED.
+func (l limits) Take(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if unlimitedMethod(info.FullMethod) {
+		return handler(ctx, request)
+	}
+	var subject string
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		subject = principal.Subject
+	}
+
+	result, limited, err := l.limiter.Take(ctx, subject, peerAddr(ctx))
+	if err != nil {
+		// an unreachable store must not take the listener down with it
+		logging.FromContext(ctx).Errorf("rate limit store failed: %v", err.Error())
+		return handler(ctx, request)
+	}
+	if !limited {
+		return handler(ctx, request)
+	}
+	if !result.Allowed {
+		return nil, rateLimited(ctx, result)
+	}
+	setRateLimitHeaders(ctx, result)
+	return handler(ctx, request)
+}
+
+func unlimitedMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
+
+func setRateLimitHeaders(ctx context.Context, result ratelimit.Result) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(result.Limit),
+		"ratelimit-remaining", strconv.Itoa(result.Remaining),
+		"ratelimit-reset", strconv.Itoa(ratelimit.Seconds(result.Reset)),
+	))
+}
+
+// rateLimited answers a call whose bucket is empty with RESOURCE_EXHAUSTED and retry-after.
+func rateLimited(ctx context.Context, result ratelimit.Result) error {
+	setRateLimitHeaders(ctx, result)
+	retryAfter := ratelimit.Seconds(result.RetryAfter)
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryAfter)))
+	return newStatus(codes.ResourceExhausted, apperrors.CodeRateLimited,
+		fmt.Sprintf("too many requests; retry in %v seconds", retryAfter))
+}
+
+// peerAddr is the client IP without its port, the key the HTTP API limits anonymous callers by.
+func peerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
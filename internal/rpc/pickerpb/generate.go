@@ -0,0 +1,4 @@
+// Package pickerpb holds the protobuf messages and gRPC stubs generated from picker.proto.
+package pickerpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative picker.proto
@@ -0,0 +1,1064 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: picker.proto
+
+package pickerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RatingSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Average float64 `protobuf:"fixed64,1,opt,name=average,proto3" json:"average,omitempty"`
+	Count   int32   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *RatingSummary) Reset() {
+	*x = RatingSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RatingSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingSummary) ProtoMessage() {}
+
+func (x *RatingSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingSummary.ProtoReflect.Descriptor instead.
+func (*RatingSummary) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RatingSummary) GetAverage() float64 {
+	if x != nil {
+		return x.Average
+	}
+	return 0
+}
+
+func (x *RatingSummary) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type Dish struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CuisineId   string         `protobuf:"bytes,2,opt,name=cuisine_id,json=cuisineId,proto3" json:"cuisine_id,omitempty"`
+	Name        string         `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Tags        []string       `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	HouseholdId string         `protobuf:"bytes,5,opt,name=household_id,json=householdId,proto3" json:"household_id,omitempty"`
+	Rating      *RatingSummary `protobuf:"bytes,6,opt,name=rating,proto3" json:"rating,omitempty"`
+}
+
+func (x *Dish) Reset() {
+	*x = Dish{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Dish) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dish) ProtoMessage() {}
+
+func (x *Dish) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dish.ProtoReflect.Descriptor instead.
+func (*Dish) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Dish) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Dish) GetCuisineId() string {
+	if x != nil {
+		return x.CuisineId
+	}
+	return ""
+}
+
+func (x *Dish) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Dish) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Dish) GetHouseholdId() string {
+	if x != nil {
+		return x.HouseholdId
+	}
+	return ""
+}
+
+func (x *Dish) GetRating() *RatingSummary {
+	if x != nil {
+		return x.Rating
+	}
+	return nil
+}
+
+type Cuisine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Dishes      []*Dish                `protobuf:"bytes,3,rep,name=dishes,proto3" json:"dishes,omitempty"`
+	Tags        []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	HouseholdId string                 `protobuf:"bytes,5,opt,name=household_id,json=householdId,proto3" json:"household_id,omitempty"`
+	CreatedBy   string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Rating      *RatingSummary         `protobuf:"bytes,8,opt,name=rating,proto3" json:"rating,omitempty"`
+}
+
+func (x *Cuisine) Reset() {
+	*x = Cuisine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cuisine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cuisine) ProtoMessage() {}
+
+func (x *Cuisine) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cuisine.ProtoReflect.Descriptor instead.
+func (*Cuisine) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Cuisine) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Cuisine) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Cuisine) GetDishes() []*Dish {
+	if x != nil {
+		return x.Dishes
+	}
+	return nil
+}
+
+func (x *Cuisine) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Cuisine) GetHouseholdId() string {
+	if x != nil {
+		return x.HouseholdId
+	}
+	return ""
+}
+
+func (x *Cuisine) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Cuisine) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Cuisine) GetRating() *RatingSummary {
+	if x != nil {
+		return x.Rating
+	}
+	return nil
+}
+
+// NewDish is a dish to be added; its id is assigned by the server.
+type NewDish struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tags []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+func (x *NewDish) Reset() {
+	*x = NewDish{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewDish) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewDish) ProtoMessage() {}
+
+func (x *NewDish) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewDish.ProtoReflect.Descriptor instead.
+func (*NewDish) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NewDish) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NewDish) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type AddCuisineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Dishes []*NewDish `protobuf:"bytes,2,rep,name=dishes,proto3" json:"dishes,omitempty"`
+	Tags   []string   `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+func (x *AddCuisineRequest) Reset() {
+	*x = AddCuisineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddCuisineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCuisineRequest) ProtoMessage() {}
+
+func (x *AddCuisineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCuisineRequest.ProtoReflect.Descriptor instead.
+func (*AddCuisineRequest) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AddCuisineRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddCuisineRequest) GetDishes() []*NewDish {
+	if x != nil {
+		return x.Dishes
+	}
+	return nil
+}
+
+func (x *AddCuisineRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type AddCuisineResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cuisine *Cuisine `protobuf:"bytes,1,opt,name=cuisine,proto3" json:"cuisine,omitempty"`
+}
+
+func (x *AddCuisineResponse) Reset() {
+	*x = AddCuisineResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddCuisineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCuisineResponse) ProtoMessage() {}
+
+func (x *AddCuisineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCuisineResponse.ProtoReflect.Descriptor instead.
+func (*AddCuisineResponse) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AddCuisineResponse) GetCuisine() *Cuisine {
+	if x != nil {
+		return x.Cuisine
+	}
+	return nil
+}
+
+type ListCuisinesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListCuisinesRequest) Reset() {
+	*x = ListCuisinesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCuisinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCuisinesRequest) ProtoMessage() {}
+
+func (x *ListCuisinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCuisinesRequest.ProtoReflect.Descriptor instead.
+func (*ListCuisinesRequest) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{6}
+}
+
+type ListCuisinesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cuisines []*Cuisine `protobuf:"bytes,1,rep,name=cuisines,proto3" json:"cuisines,omitempty"`
+}
+
+func (x *ListCuisinesResponse) Reset() {
+	*x = ListCuisinesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCuisinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCuisinesResponse) ProtoMessage() {}
+
+func (x *ListCuisinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCuisinesResponse.ProtoReflect.Descriptor instead.
+func (*ListCuisinesResponse) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListCuisinesResponse) GetCuisines() []*Cuisine {
+	if x != nil {
+		return x.Cuisines
+	}
+	return nil
+}
+
+type AddDishesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CuisineId string     `protobuf:"bytes,1,opt,name=cuisine_id,json=cuisineId,proto3" json:"cuisine_id,omitempty"`
+	Dishes    []*NewDish `protobuf:"bytes,2,rep,name=dishes,proto3" json:"dishes,omitempty"`
+}
+
+func (x *AddDishesRequest) Reset() {
+	*x = AddDishesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddDishesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDishesRequest) ProtoMessage() {}
+
+func (x *AddDishesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDishesRequest.ProtoReflect.Descriptor instead.
+func (*AddDishesRequest) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AddDishesRequest) GetCuisineId() string {
+	if x != nil {
+		return x.CuisineId
+	}
+	return ""
+}
+
+func (x *AddDishesRequest) GetDishes() []*NewDish {
+	if x != nil {
+		return x.Dishes
+	}
+	return nil
+}
+
+type AddDishesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dishes []*Dish `protobuf:"bytes,1,rep,name=dishes,proto3" json:"dishes,omitempty"`
+}
+
+func (x *AddDishesResponse) Reset() {
+	*x = AddDishesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddDishesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDishesResponse) ProtoMessage() {}
+
+func (x *AddDishesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDishesResponse.ProtoReflect.Descriptor instead.
+func (*AddDishesResponse) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AddDishesResponse) GetDishes() []*Dish {
+	if x != nil {
+		return x.Dishes
+	}
+	return nil
+}
+
+type RandomPickRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only pick cuisines or dishes carrying one of these tags (case-insensitive).
+	Tags []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Only pick cuisines and dishes rated at least this average.
+	MinRating float64 `protobuf:"fixed64,2,opt,name=min_rating,json=minRating,proto3" json:"min_rating,omitempty"`
+}
+
+func (x *RandomPickRequest) Reset() {
+	*x = RandomPickRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RandomPickRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RandomPickRequest) ProtoMessage() {}
+
+func (x *RandomPickRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RandomPickRequest.ProtoReflect.Descriptor instead.
+func (*RandomPickRequest) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RandomPickRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RandomPickRequest) GetMinRating() float64 {
+	if x != nil {
+		return x.MinRating
+	}
+	return 0
+}
+
+type RandomPickResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cuisine *Cuisine `protobuf:"bytes,1,opt,name=cuisine,proto3" json:"cuisine,omitempty"`
+	Dish    *Dish    `protobuf:"bytes,2,opt,name=dish,proto3" json:"dish,omitempty"`
+}
+
+func (x *RandomPickResponse) Reset() {
+	*x = RandomPickResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_picker_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RandomPickResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RandomPickResponse) ProtoMessage() {}
+
+func (x *RandomPickResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RandomPickResponse.ProtoReflect.Descriptor instead.
+func (*RandomPickResponse) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RandomPickResponse) GetCuisine() *Cuisine {
+	if x != nil {
+		return x.Cuisine
+	}
+	return nil
+}
+
+func (x *RandomPickResponse) GetDish() *Dish {
+	if x != nil {
+		return x.Dish
+	}
+	return nil
+}
+
+var File_picker_proto protoreflect.FileDescriptor
+
+var file_picker_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09,
+	0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3f, 0x0a, 0x0d, 0x52, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xb2, 0x01, 0x0a, 0x04,
+	0x44, 0x69, 0x73, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x75, 0x69, 0x73, 0x69, 0x6e,
+	0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x68,
+	0x6f, 0x75, 0x73, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x30,
+	0x0a, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x22, 0x99, 0x02, 0x0a, 0x07, 0x43, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x27, 0x0a, 0x06, 0x64, 0x69, 0x73, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73,
+	0x68, 0x52, 0x06, 0x64, 0x69, 0x73, 0x68, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x64,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12,
+	0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x30, 0x0a, 0x06, 0x72, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x69, 0x63,
+	0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x52, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x31, 0x0a, 0x07,
+	0x4e, 0x65, 0x77, 0x44, 0x69, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22,
+	0x67, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x43, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x64, 0x69, 0x73, 0x68,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x77, 0x44, 0x69, 0x73, 0x68, 0x52, 0x06, 0x64, 0x69,
+	0x73, 0x68, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x42, 0x0a, 0x12, 0x41, 0x64, 0x64, 0x43,
+	0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x0a, 0x07, 0x63, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x69, 0x73,
+	0x69, 0x6e, 0x65, 0x52, 0x07, 0x63, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x22, 0x15, 0x0a, 0x13,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x46, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x69, 0x73, 0x69,
+	0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x63,
+	0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x69, 0x73, 0x69, 0x6e,
+	0x65, 0x52, 0x08, 0x63, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x5d, 0x0a, 0x10, 0x41,
+	0x64, 0x64, 0x44, 0x69, 0x73, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x2a,
+	0x0a, 0x06, 0x64, 0x69, 0x73, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x77, 0x44, 0x69,
+	0x73, 0x68, 0x52, 0x06, 0x64, 0x69, 0x73, 0x68, 0x65, 0x73, 0x22, 0x3c, 0x0a, 0x11, 0x41, 0x64,
+	0x64, 0x44, 0x69, 0x73, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x27, 0x0a, 0x06, 0x64, 0x69, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x68,
+	0x52, 0x06, 0x64, 0x69, 0x73, 0x68, 0x65, 0x73, 0x22, 0x46, 0x0a, 0x11, 0x52, 0x61, 0x6e, 0x64,
+	0x6f, 0x6d, 0x50, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x22, 0x67, 0x0a, 0x12, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x50, 0x69, 0x63, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x63, 0x75, 0x69, 0x73, 0x69, 0x6e,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x52, 0x07, 0x63, 0x75, 0x69,
+	0x73, 0x69, 0x6e, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x64, 0x69, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x69, 0x73, 0x68, 0x52, 0x04, 0x64, 0x69, 0x73, 0x68, 0x32, 0xbe, 0x02, 0x0a, 0x0d, 0x50, 0x69,
+	0x63, 0x6b, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x0a, 0x41,
+	0x64, 0x64, 0x43, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x69, 0x63, 0x6b,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x43, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x43, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75,
+	0x69, 0x73, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x69, 0x73, 0x69, 0x6e, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x44, 0x69,
+	0x73, 0x68, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x64, 0x64, 0x44, 0x69, 0x73, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64,
+	0x64, 0x44, 0x69, 0x73, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x49, 0x0a, 0x0a, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x50, 0x69, 0x63, 0x6b, 0x12, 0x1c, 0x2e,
+	0x70, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d,
+	0x50, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x69,
+	0x63, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x50, 0x69,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x29, 0x5a, 0x27, 0x66, 0x6f,
+	0x6f, 0x64, 0x2d, 0x72, 0x6f, 0x75, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x2d, 0x61, 0x70, 0x69, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x69, 0x63,
+	0x6b, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_picker_proto_rawDescOnce sync.Once
+	file_picker_proto_rawDescData = file_picker_proto_rawDesc
+)
+
+func file_picker_proto_rawDescGZIP() []byte {
+	file_picker_proto_rawDescOnce.Do(func() {
+		file_picker_proto_rawDescData = protoimpl.X.CompressGZIP(file_picker_proto_rawDescData)
+	})
+	return file_picker_proto_rawDescData
+}
+
+var file_picker_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_picker_proto_goTypes = []interface{}{
+	(*RatingSummary)(nil),         // 0: picker.v1.RatingSummary
+	(*Dish)(nil),                  // 1: picker.v1.Dish
+	(*Cuisine)(nil),               // 2: picker.v1.Cuisine
+	(*NewDish)(nil),               // 3: picker.v1.NewDish
+	(*AddCuisineRequest)(nil),     // 4: picker.v1.AddCuisineRequest
+	(*AddCuisineResponse)(nil),    // 5: picker.v1.AddCuisineResponse
+	(*ListCuisinesRequest)(nil),   // 6: picker.v1.ListCuisinesRequest
+	(*ListCuisinesResponse)(nil),  // 7: picker.v1.ListCuisinesResponse
+	(*AddDishesRequest)(nil),      // 8: picker.v1.AddDishesRequest
+	(*AddDishesResponse)(nil),     // 9: picker.v1.AddDishesResponse
+	(*RandomPickRequest)(nil),     // 10: picker.v1.RandomPickRequest
+	(*RandomPickResponse)(nil),    // 11: picker.v1.RandomPickResponse
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+}
+var file_picker_proto_depIdxs = []int32{
+	0,  // 0: picker.v1.Dish.rating:type_name -> picker.v1.RatingSummary
+	1,  // 1: picker.v1.Cuisine.dishes:type_name -> picker.v1.Dish
+	12, // 2: picker.v1.Cuisine.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: picker.v1.Cuisine.rating:type_name -> picker.v1.RatingSummary
+	3,  // 4: picker.v1.AddCuisineRequest.dishes:type_name -> picker.v1.NewDish
+	2,  // 5: picker.v1.AddCuisineResponse.cuisine:type_name -> picker.v1.Cuisine
+	2,  // 6: picker.v1.ListCuisinesResponse.cuisines:type_name -> picker.v1.Cuisine
+	3,  // 7: picker.v1.AddDishesRequest.dishes:type_name -> picker.v1.NewDish
+	1,  // 8: picker.v1.AddDishesResponse.dishes:type_name -> picker.v1.Dish
+	2,  // 9: picker.v1.RandomPickResponse.cuisine:type_name -> picker.v1.Cuisine
+	1,  // 10: picker.v1.RandomPickResponse.dish:type_name -> picker.v1.Dish
+	4,  // 11: picker.v1.PickerService.AddCuisine:input_type -> picker.v1.AddCuisineRequest
+	6,  // 12: picker.v1.PickerService.ListCuisines:input_type -> picker.v1.ListCuisinesRequest
+	8,  // 13: picker.v1.PickerService.AddDishes:input_type -> picker.v1.AddDishesRequest
+	10, // 14: picker.v1.PickerService.RandomPick:input_type -> picker.v1.RandomPickRequest
+	5,  // 15: picker.v1.PickerService.AddCuisine:output_type -> picker.v1.AddCuisineResponse
+	7,  // 16: picker.v1.PickerService.ListCuisines:output_type -> picker.v1.ListCuisinesResponse
+	9,  // 17: picker.v1.PickerService.AddDishes:output_type -> picker.v1.AddDishesResponse
+	11, // 18: picker.v1.PickerService.RandomPick:output_type -> picker.v1.RandomPickResponse
+	15, // [15:19] is the sub-list for method output_type
+	11, // [11:15] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_picker_proto_init() }
+func file_picker_proto_init() {
+	if File_picker_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_picker_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RatingSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_picker_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Dish); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_picker_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cuisine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_picker_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewDish); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_picker_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddCuisineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_picker_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddCuisineResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_picker_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCuisinesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_picker_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCuisinesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_picker_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddDishesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_picker_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddDishesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_picker_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RandomPickRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_picker_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RandomPickResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_picker_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_picker_proto_goTypes,
+		DependencyIndexes: file_picker_proto_depIdxs,
+		MessageInfos:      file_picker_proto_msgTypes,
+	}.Build()
+	File_picker_proto = out.File
+	file_picker_proto_rawDesc = nil
+	file_picker_proto_goTypes = nil
+	file_picker_proto_depIdxs = nil
+}
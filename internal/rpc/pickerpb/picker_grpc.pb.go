@@ -0,0 +1,228 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: picker.proto
+
+package pickerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PickerService_AddCuisine_FullMethodName   = "/picker.v1.PickerService/AddCuisine"
+	PickerService_ListCuisines_FullMethodName = "/picker.v1.PickerService/ListCuisines"
+	PickerService_AddDishes_FullMethodName    = "/picker.v1.PickerService/AddDishes"
+	PickerService_RandomPick_FullMethodName   = "/picker.v1.PickerService/RandomPick"
+)
+
+// PickerServiceClient is the client API for PickerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PickerServiceClient interface {
+	// AddCuisine adds a cuisine with its dishes. Requires the editor role.
+	AddCuisine(ctx context.Context, in *AddCuisineRequest, opts ...grpc.CallOption) (*AddCuisineResponse, error)
+	// ListCuisines lists cuisines with their dishes and ratings, personalized for the caller.
+	ListCuisines(ctx context.Context, in *ListCuisinesRequest, opts ...grpc.CallOption) (*ListCuisinesResponse, error)
+	// AddDishes adds dishes to an existing cuisine and returns them. Requires the editor role.
+	AddDishes(ctx context.Context, in *AddDishesRequest, opts ...grpc.CallOption) (*AddDishesResponse, error)
+	// RandomPick picks a random cuisine and dish, weighted by preferences and ratings.
+	RandomPick(ctx context.Context, in *RandomPickRequest, opts ...grpc.CallOption) (*RandomPickResponse, error)
+}
+
+type pickerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPickerServiceClient(cc grpc.ClientConnInterface) PickerServiceClient {
+	return &pickerServiceClient{cc}
+}
+
+func (c *pickerServiceClient) AddCuisine(ctx context.Context, in *AddCuisineRequest, opts ...grpc.CallOption) (*AddCuisineResponse, error) {
+	out := new(AddCuisineResponse)
+	err := c.cc.Invoke(ctx, PickerService_AddCuisine_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pickerServiceClient) ListCuisines(ctx context.Context, in *ListCuisinesRequest, opts ...grpc.CallOption) (*ListCuisinesResponse, error) {
+	out := new(ListCuisinesResponse)
+	err := c.cc.Invoke(ctx, PickerService_ListCuisines_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pickerServiceClient) AddDishes(ctx context.Context, in *AddDishesRequest, opts ...grpc.CallOption) (*AddDishesResponse, error) {
+	out := new(AddDishesResponse)
+	err := c.cc.Invoke(ctx, PickerService_AddDishes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pickerServiceClient) RandomPick(ctx context.Context, in *RandomPickRequest, opts ...grpc.CallOption) (*RandomPickResponse, error) {
+	out := new(RandomPickResponse)
+	err := c.cc.Invoke(ctx, PickerService_RandomPick_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PickerServiceServer is the server API for PickerService service.
+// All implementations must embed UnimplementedPickerServiceServer
+// for forward compatibility
+type PickerServiceServer interface {
+	// AddCuisine adds a cuisine with its dishes. Requires the editor role.
+	AddCuisine(context.Context, *AddCuisineRequest) (*AddCuisineResponse, error)
+	// ListCuisines lists cuisines with their dishes and ratings, personalized for the caller.
+	ListCuisines(context.Context, *ListCuisinesRequest) (*ListCuisinesResponse, error)
+	// AddDishes adds dishes to an existing cuisine and returns them. Requires the editor role.
+	AddDishes(context.Context, *AddDishesRequest) (*AddDishesResponse, error)
+	// RandomPick picks a random cuisine and dish, weighted by preferences and ratings.
+	RandomPick(context.Context, *RandomPickRequest) (*RandomPickResponse, error)
+	mustEmbedUnimplementedPickerServiceServer()
+}
+
+// UnimplementedPickerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPickerServiceServer struct {
+}
+
+func (UnimplementedPickerServiceServer) AddCuisine(context.Context, *AddCuisineRequest) (*AddCuisineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCuisine not implemented")
+}
+func (UnimplementedPickerServiceServer) ListCuisines(context.Context, *ListCuisinesRequest) (*ListCuisinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCuisines not implemented")
+}
+func (UnimplementedPickerServiceServer) AddDishes(context.Context, *AddDishesRequest) (*AddDishesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDishes not implemented")
+}
+func (UnimplementedPickerServiceServer) RandomPick(context.Context, *RandomPickRequest) (*RandomPickResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RandomPick not implemented")
+}
+func (UnimplementedPickerServiceServer) mustEmbedUnimplementedPickerServiceServer() {}
+
+// UnsafePickerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PickerServiceServer will
+// result in compilation errors.
+type UnsafePickerServiceServer interface {
+	mustEmbedUnimplementedPickerServiceServer()
+}
+
+func RegisterPickerServiceServer(s grpc.ServiceRegistrar, srv PickerServiceServer) {
+	s.RegisterService(&PickerService_ServiceDesc, srv)
+}
+
+func _PickerService_AddCuisine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCuisineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PickerServiceServer).AddCuisine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PickerService_AddCuisine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PickerServiceServer).AddCuisine(ctx, req.(*AddCuisineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PickerService_ListCuisines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCuisinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PickerServiceServer).ListCuisines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PickerService_ListCuisines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PickerServiceServer).ListCuisines(ctx, req.(*ListCuisinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PickerService_AddDishes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDishesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PickerServiceServer).AddDishes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PickerService_AddDishes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PickerServiceServer).AddDishes(ctx, req.(*AddDishesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PickerService_RandomPick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RandomPickRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PickerServiceServer).RandomPick(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PickerService_RandomPick_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PickerServiceServer).RandomPick(ctx, req.(*RandomPickRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PickerService_ServiceDesc is the grpc.ServiceDesc for PickerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PickerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "picker.v1.PickerService",
+	HandlerType: (*PickerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddCuisine",
+			Handler:    _PickerService_AddCuisine_Handler,
+		},
+		{
+			MethodName: "ListCuisines",
+			Handler:    _PickerService_ListCuisines_Handler,
+		},
+		{
+			MethodName: "AddDishes",
+			Handler:    _PickerService_AddDishes_Handler,
+		},
+		{
+			MethodName: "RandomPick",
+			Handler:    _PickerService_RandomPick_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "picker.proto",
+}
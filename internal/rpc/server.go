@@ -0,0 +1,154 @@
+// Package rpc serves the picker operations of the facade over gRPC, next to the HTTP API.
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/ratelimit"
+	"food-roulette-api/internal/rpc/pickerpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"net"
+)
+
+// Server is the gRPC listener: the picker service, the standard health service and, when
+// enabled, reflection.
+type Server struct {
+	GRPC   *grpc.Server
+	Health *grpchealth.Server
+}
+
+// Options configure the listener around the picker service.
+type Options struct {
+	// TLS serves the listener over TLS; nil serves plaintext.
+	TLS *tls.Config
+	// Limiter applies the HTTP API's rate limits to every call but health checks; nil disables
+	// rate limiting.
+	Limiter *ratelimit.Limiter
+	// Reflection registers the reflection service, which answers callers without credentials.
+	Reflection bool
+}
+
+// NewServer registers the picker service over service. Bearer tokens are only accepted when
+// verifier is set, as on the HTTP API.
+func NewServer(service facade.ServiceI, verifier auth.TokenVerifier, options Options) *Server {
+	authenticator := authenticator{service: service, verifier: verifier}
+	limits := limits{limiter: options.Limiter}
+	serverOptions := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(limits.LimitIP, authenticator.Unary, limits.Take),
+	}
+	if options.TLS != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(options.TLS)))
+	}
+	s := &Server{
+		GRPC:   grpc.NewServer(serverOptions...),
+		Health: grpchealth.NewServer(),
+	}
+	pickerpb.RegisterPickerServiceServer(s.GRPC, &pickerServer{service: service})
+	healthpb.RegisterHealthServer(s.GRPC, s.Health)
+	if options.Reflection {
+		reflection.Register(s.GRPC)
+	}
+
+	s.Health.SetServingStatus(pickerpb.PickerService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	return s
+}
+
+// ListenAndServe serves on addr until Shutdown is called, which returns nil.
+func (s *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.GRPC.Serve(listener)
+}
+
+// Shutdown reports NOT_SERVING to health checks, then waits for in-flight calls to finish until
+// ctx is done, when the remaining calls are cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.Health.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		s.GRPC.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.GRPC.Stop()
+		return ctx.Err()
+	}
+}
+
+type pickerServer struct {
+	pickerpb.UnimplementedPickerServiceServer
+	service facade.ServiceI
+}
+
+func (s *pickerServer) AddCuisine(ctx context.Context, request *pickerpb.AddCuisineRequest) (*pickerpb.AddCuisineResponse, error) {
+	response := s.service.AddCuisine(ctx, models.AddCuisineRequest{
+		Name:   request.GetName(),
+		Dishes: fromNewDishes(request.GetDishes()),
+		Tags:   request.GetTags(),
+	})
+	if err := statusFromMessage(response.Message); err != nil {
+		return nil, err
+	}
+	return &pickerpb.AddCuisineResponse{Cuisine: toCuisine(response.Cuisine)}, nil
+}
+
+func (s *pickerServer) ListCuisines(ctx context.Context, _ *pickerpb.ListCuisinesRequest) (*pickerpb.ListCuisinesResponse, error) {
+	response := s.service.AllCuisines(ctx)
+	if err := statusFromMessage(response.Message); err != nil {
+		return nil, err
+	}
+	cuisines := make([]*pickerpb.Cuisine, len(response.Cuisines))
+	for i, cuisine := range response.Cuisines {
+		cuisines[i] = toCuisine(cuisine)
+	}
+	return &pickerpb.ListCuisinesResponse{Cuisines: cuisines}, nil
+}
+
+func (s *pickerServer) AddDishes(ctx context.Context, request *pickerpb.AddDishesRequest) (*pickerpb.AddDishesResponse, error) {
+	cuisineId, err := primitive.ObjectIDFromHex(request.GetCuisineId())
+	if err != nil {
+		detail := fmt.Sprintf("%v is not a valid cuisine id", request.GetCuisineId())
+		return nil, newStatus(codes.InvalidArgument, apperrors.CodeInvalidID, detail,
+			&errdetails.BadRequest_FieldViolation{Field: "cuisine_id", Description: detail})
+	}
+	response := s.service.AddDishes(ctx, models.AddDishesRequest{
+		Cuisine: cuisineId,
+		Dishes:  fromNewDishes(request.GetDishes()),
+	})
+	if err := statusFromMessage(response.Message); err != nil {
+		return nil, err
+	}
+	dishes := make([]*pickerpb.Dish, len(response.Dishes))
+	for i := range response.Dishes {
+		dishes[i] = toDish(&response.Dishes[i])
+	}
+	return &pickerpb.AddDishesResponse{Dishes: dishes}, nil
+}
+
+func (s *pickerServer) RandomPick(ctx context.Context, request *pickerpb.RandomPickRequest) (*pickerpb.RandomPickResponse, error) {
+	response := s.service.RandomPick(ctx, models.PickRequest{
+		Tags:      request.GetTags(),
+		MinRating: request.GetMinRating(),
+	})
+	if err := statusFromMessage(response.Message); err != nil {
+		return nil, err
+	}
+	return &pickerpb.RandomPickResponse{Cuisine: toCuisine(response.Cuisine), Dish: toDish(response.Dish)}, nil
+}
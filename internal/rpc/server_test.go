@@ -0,0 +1,473 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"food-roulette-api/internal/auth"
+	"food-roulette-api/internal/facade"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/ratelimit"
+	"food-roulette-api/internal/rpc/pickerpb"
+	"food-roulette-api/internal/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const (
+	readerKey = "mp_reader"
+	editorKey = "mp_editor"
+)
+
+var ok = models.Message{Status: strconv.Itoa(http.StatusOK)}
+
+// startServer serves a picker over an in-memory listener and returns a connected client.
+func startServer(t *testing.T, service facade.ServiceI) (*Server, *grpc.ClientConn) {
+	return startServerWith(t, service, Options{}, insecure.NewCredentials())
+}
+
+func startServerWith(t *testing.T, service facade.ServiceI, options Options, creds credentials.TransportCredentials) (*Server, *grpc.ClientConn) {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(service, nil, options)
+	go func() {
+		_ = server.GRPC.Serve(listener)
+	}()
+	t.Cleanup(server.GRPC.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatalf("failed to dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return server, conn
+}
+
+func mockAuthentication(mockFacade *facade.MockServiceI) {
+	mockFacade.EXPECT().Authenticate(gomock.Any(), readerKey).Return(&auth.Principal{Subject: "reader", Role: auth.RoleReader}, nil).AnyTimes()
+	mockFacade.EXPECT().Authenticate(gomock.Any(), editorKey).Return(&auth.Principal{Subject: "editor", Role: auth.RoleEditor}, nil).AnyTimes()
+	mockFacade.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(nil, facade.ErrInvalidApiKey).AnyTimes()
+}
+
+func withKey(key string, pairs ...string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), append([]string{apiKeyMetadata, key}, pairs...)...)
+}
+
+func TestServer_ListCuisines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	mockAuthentication(mockFacade)
+	_, conn := startServer(t, mockFacade)
+	client := pickerpb.NewPickerServiceClient(conn)
+
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	thai := &models.Cuisine{ID: primitive.NewObjectID(), Name: "Thai", Tags: []string{"spicy"}, CreatedAt: &createdAt,
+		Rating: &models.RatingSummary{Average: 4.5, Count: 2},
+		Dishes: []models.Dish{{ID: primitive.NewObjectID(), Name: "Pad Thai"}}}
+	mockFacade.EXPECT().AllCuisines(gomock.Any()).DoAndReturn(func(ctx context.Context) models.AllCuisinesResponse {
+		principal, _ := auth.PrincipalFromContext(ctx)
+		assert.Equal(t, "reader", principal.Subject)
+		return models.AllCuisinesResponse{Cuisines: []*models.Cuisine{thai}, Message: ok}
+	})
+
+	var header metadata.MD
+	response, err := client.ListCuisines(withKey(readerKey, requestIdMetadata, "req-123"), &pickerpb.ListCuisinesRequest{}, grpc.Header(&header))
+
+	if assert.NoError(t, err) && assert.Len(t, response.Cuisines, 1) {
+		got := response.Cuisines[0]
+		assert.Equal(t, thai.ID.Hex(), got.Id)
+		assert.Equal(t, "Thai", got.Name)
+		assert.Equal(t, []string{"spicy"}, got.Tags)
+		assert.Equal(t, createdAt, got.CreatedAt.AsTime())
+		assert.Equal(t, 4.5, got.Rating.Average)
+		assert.Equal(t, "Pad Thai", got.Dishes[0].Name)
+		assert.Empty(t, got.HouseholdId)
+	}
+	assert.Equal(t, []string{"req-123"}, header.Get(requestIdMetadata))
+}
+
+func TestServer_AddCuisine(t *testing.T) {
+	status400 := strconv.Itoa(http.StatusBadRequest)
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		mockRes        *models.CuisineResponse
+		wantCode       codes.Code
+		wantReason     string
+		wantViolations []string
+	}{
+		{
+			name:     "Editor adds a cuisine",
+			ctx:      withKey(editorKey),
+			mockRes:  &models.CuisineResponse{Cuisine: &models.Cuisine{ID: primitive.NewObjectID(), Name: "Thai"}, Message: ok},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "Missing credentials",
+			ctx:        context.Background(),
+			wantCode:   codes.Unauthenticated,
+			wantReason: "unauthorized",
+		},
+		{
+			name:       "Unknown key",
+			ctx:        withKey("mp_unknown"),
+			wantCode:   codes.Unauthenticated,
+			wantReason: "unauthorized",
+		},
+		{
+			name:       "Reader is denied",
+			ctx:        withKey(readerKey),
+			wantCode:   codes.PermissionDenied,
+			wantReason: "forbidden",
+		},
+		{
+			name: "Validation errors become field violations",
+			ctx:  withKey(editorKey),
+			mockRes: &models.CuisineResponse{Message: models.Message{Status: status400, ErrorLog: []models.ErrorLog{
+				{Status: status400, Code: "validation_failed", Field: "dishes[0].name", RootCause: "Validation error", Trace: "is required"},
+			}}},
+			wantCode:       codes.InvalidArgument,
+			wantReason:     "validation_failed",
+			wantViolations: []string{"dishes[0].name"},
+		},
+		{
+			name: "Duplicate cuisine already exists",
+			ctx:  withKey(editorKey),
+			mockRes: &models.CuisineResponse{Message: models.Message{Status: strconv.Itoa(http.StatusConflict), ErrorLog: []models.ErrorLog{
+				{Status: strconv.Itoa(http.StatusConflict), Code: "cuisine_already_exists", RootCause: "Insertion error", Trace: "cuisine Thai already exists"},
+			}}},
+			wantCode:   codes.AlreadyExists,
+			wantReason: "cuisine_already_exists",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFacade := facade.NewMockServiceI(ctrl)
+			mockAuthentication(mockFacade)
+			_, conn := startServer(t, mockFacade)
+			client := pickerpb.NewPickerServiceClient(conn)
+
+			if tt.mockRes != nil {
+				mockFacade.EXPECT().AddCuisine(gomock.Any(), models.AddCuisineRequest{
+					Name:   "Thai",
+					Dishes: []models.Dish{{Name: "Pad Thai", Tags: []string{"noodles"}}},
+					Tags:   []string{"spicy"},
+				}).Return(*tt.mockRes)
+			}
+
+			response, err := client.AddCuisine(tt.ctx, &pickerpb.AddCuisineRequest{
+				Name:   "Thai",
+				Dishes: []*pickerpb.NewDish{{Name: "Pad Thai", Tags: []string{"noodles"}}},
+				Tags:   []string{"spicy"},
+			})
+
+			st := status.Convert(err)
+			assert.Equal(t, tt.wantCode, st.Code(), st.Message())
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, "Thai", response.Cuisine.Name)
+				return
+			}
+			var reason string
+			var violations []string
+			for _, detail := range st.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					reason = detail.Reason
+				case *errdetails.BadRequest:
+					for _, violation := range detail.FieldViolations {
+						violations = append(violations, violation.Field)
+					}
+				}
+			}
+			assert.Equal(t, tt.wantReason, reason)
+			assert.Equal(t, tt.wantViolations, violations)
+		})
+	}
+}
+
+func TestServer_RandomPick_HouseholdScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	mockAuthentication(mockFacade)
+	_, conn := startServer(t, mockFacade)
+	client := pickerpb.NewPickerServiceClient(conn)
+
+	householdId := primitive.NewObjectID()
+	cuisine := &models.Cuisine{ID: primitive.NewObjectID(), Name: "Thai", HouseholdID: householdId,
+		Dishes: []models.Dish{{ID: primitive.NewObjectID(), Name: "Pad Thai", HouseholdID: householdId}}}
+	mockFacade.EXPECT().HouseholdRole(gomock.Any(), householdId.Hex()).Return(householdId, auth.RoleReader, nil)
+	mockFacade.EXPECT().HouseholdRole(gomock.Any(), "not-an-id").Return(primitive.NilObjectID, auth.Role(""), facade.ErrInvalidHousehold)
+	mockFacade.EXPECT().RandomPick(gomock.Any(), models.PickRequest{Tags: []string{"spicy"}, MinRating: 3}).
+		DoAndReturn(func(ctx context.Context, _ models.PickRequest) models.PickResponse {
+			id, scoped := tenant.HouseholdFromContext(ctx)
+			assert.True(t, scoped)
+			assert.Equal(t, householdId, id)
+			return models.PickResponse{Cuisine: cuisine, Dish: &cuisine.Dishes[0], Message: ok}
+		})
+
+	response, err := client.RandomPick(withKey(readerKey, householdMetadata, householdId.Hex()),
+		&pickerpb.RandomPickRequest{Tags: []string{"spicy"}, MinRating: 3})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Thai", response.Cuisine.Name)
+		assert.Equal(t, "Pad Thai", response.Dish.Name)
+		assert.Equal(t, householdId.Hex(), response.Dish.HouseholdId)
+	}
+
+	_, err = client.RandomPick(withKey(readerKey, householdMetadata, "not-an-id"), &pickerpb.RandomPickRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_AddDishes(t *testing.T) {
+	cuisineId := primitive.NewObjectID()
+	status404 := strconv.Itoa(http.StatusNotFound)
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		cuisineId      string
+		mockRes        *models.AllDishesResponse
+		wantCode       codes.Code
+		wantReason     string
+		wantViolations []string
+	}{
+		{
+			name:      "Editor adds dishes",
+			ctx:       withKey(editorKey),
+			cuisineId: cuisineId.Hex(),
+			mockRes: &models.AllDishesResponse{
+				Dishes:  []models.Dish{{ID: primitive.NewObjectID(), Cuisine: cuisineId, Name: "Pad Thai", Tags: []string{"noodles"}}},
+				Message: models.Message{Status: strconv.Itoa(http.StatusCreated)},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "Reader is denied",
+			ctx:        withKey(readerKey),
+			cuisineId:  cuisineId.Hex(),
+			wantCode:   codes.PermissionDenied,
+			wantReason: "forbidden",
+		},
+		{
+			name:           "Malformed cuisine id",
+			ctx:            withKey(editorKey),
+			cuisineId:      "not-an-id",
+			wantCode:       codes.InvalidArgument,
+			wantReason:     "invalid_object_id",
+			wantViolations: []string{"cuisine_id"},
+		},
+		{
+			name:      "Missing cuisine",
+			ctx:       withKey(editorKey),
+			cuisineId: cuisineId.Hex(),
+			mockRes: &models.AllDishesResponse{Message: models.Message{Status: status404, ErrorLog: []models.ErrorLog{
+				{Status: status404, Code: "cuisine_not_found", RootCause: "Not found", Trace: "cuisine not found"},
+			}}},
+			wantCode:   codes.NotFound,
+			wantReason: "cuisine_not_found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFacade := facade.NewMockServiceI(ctrl)
+			mockAuthentication(mockFacade)
+			_, conn := startServer(t, mockFacade)
+			client := pickerpb.NewPickerServiceClient(conn)
+
+			if tt.mockRes != nil {
+				mockFacade.EXPECT().AddDishes(gomock.Any(), models.AddDishesRequest{
+					Cuisine: cuisineId,
+					Dishes:  []models.Dish{{Name: "Pad Thai", Tags: []string{"noodles"}}},
+				}).Return(*tt.mockRes)
+			}
+
+			response, err := client.AddDishes(tt.ctx, &pickerpb.AddDishesRequest{
+				CuisineId: tt.cuisineId,
+				Dishes:    []*pickerpb.NewDish{{Name: "Pad Thai", Tags: []string{"noodles"}}},
+			})
+
+			st := status.Convert(err)
+			assert.Equal(t, tt.wantCode, st.Code(), st.Message())
+			if tt.wantCode == codes.OK {
+				if assert.Len(t, response.Dishes, 1) {
+					assert.Equal(t, "Pad Thai", response.Dishes[0].Name)
+					assert.Equal(t, cuisineId.Hex(), response.Dishes[0].CuisineId)
+				}
+				return
+			}
+			var reason string
+			var violations []string
+			for _, detail := range st.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					reason = detail.Reason
+				case *errdetails.BadRequest:
+					for _, violation := range detail.FieldViolations {
+						violations = append(violations, violation.Field)
+					}
+				}
+			}
+			assert.Equal(t, tt.wantReason, reason)
+			assert.Equal(t, tt.wantViolations, violations)
+		})
+	}
+}
+
+func TestServer_HealthAndReflection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	server, conn := startServerWith(t, facade.NewMockServiceI(ctrl), Options{Reflection: true}, insecure.NewCredentials())
+	healthClient := healthpb.NewHealthClient(conn)
+
+	response, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: pickerpb.PickerService_ServiceDesc.ServiceName})
+	if assert.NoError(t, err) {
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.Status)
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if assert.NoError(t, err) {
+		assert.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		}))
+		reply, err := stream.Recv()
+		if assert.NoError(t, err) {
+			var services []string
+			for _, service := range reply.GetListServicesResponse().GetService() {
+				services = append(services, service.Name)
+			}
+			assert.Contains(t, services, "picker.v1.PickerService")
+			assert.Contains(t, services, "grpc.health.v1.Health")
+		}
+	}
+
+	server.Health.Shutdown()
+	response, err = healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: pickerpb.PickerService_ServiceDesc.ServiceName})
+	if assert.NoError(t, err) {
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, response.Status)
+	}
+}
+
+func TestServer_ReflectionIsOptIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	_, conn := startServer(t, facade.NewMockServiceI(ctrl))
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if assert.NoError(t, err) {
+		_ = stream.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}})
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFacade := facade.NewMockServiceI(ctrl)
+	mockAuthentication(mockFacade)
+	mockFacade.EXPECT().AllCuisines(gomock.Any()).Return(models.AllCuisinesResponse{Message: ok}).Times(2)
+	limiter := &ratelimit.Limiter{
+		Store:  ratelimit.NewMemoryStore(),
+		PerKey: ratelimit.Limit{Rate: 0.001, Burst: 2},
+		PerIP:  ratelimit.Limit{Rate: 0.001, Burst: 1},
+	}
+	_, conn := startServerWith(t, mockFacade, Options{Limiter: limiter}, insecure.NewCredentials())
+	client := pickerpb.NewPickerServiceClient(conn)
+
+	var header metadata.MD
+	_, err := client.ListCuisines(withKey(readerKey), &pickerpb.ListCuisinesRequest{}, grpc.Header(&header))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, header.Get("ratelimit-remaining"))
+	_, err = client.ListCuisines(withKey(readerKey), &pickerpb.ListCuisinesRequest{})
+	assert.NoError(t, err)
+
+	header = nil
+	_, err = client.ListCuisines(withKey(readerKey), &pickerpb.ListCuisinesRequest{}, grpc.Header(&header))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.NotEmpty(t, header.Get("retry-after"))
+
+	// a rejected key empties the IP bucket, and the next caller from that IP is turned away
+	// before its credentials are looked up
+	_, err = client.ListCuisines(withKey("mp_unknown"), &pickerpb.ListCuisinesRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.ListCuisines(withKey("mp_unknown"), &pickerpb.ListCuisinesRequest{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	response, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.Status)
+	}
+}
+
+func TestServer_TLS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	certificate, roots := selfSigned(t)
+	_, conn := startServerWith(t, facade.NewMockServiceI(ctrl),
+		Options{TLS: &tls.Config{Certificates: []tls.Certificate{certificate}}},
+		credentials.NewTLS(&tls.Config{RootCAs: roots, ServerName: "picker.test"}))
+
+	_, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+
+	_, plaintext := startServerWith(t, facade.NewMockServiceI(ctrl),
+		Options{TLS: &tls.Config{Certificates: []tls.Certificate{certificate}}}, insecure.NewCredentials())
+	_, err = healthpb.NewHealthClient(plaintext).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+// selfSigned returns a certificate for picker.test and a pool trusting it.
+func selfSigned(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "picker.test"},
+		DNSNames:     []string{"picker.test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(parsed)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, roots
+}
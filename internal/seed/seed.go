@@ -0,0 +1,121 @@
+// Package seed bundles starter catalogs of common cuisines so a fresh database is usable right
+// away. Packs are embedded JSON files in the catalog format mealctl imports and exports.
+package seed
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"food-roulette-api/internal/models"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DefaultLocale is the pack loaded when no locale is configured.
+const DefaultLocale = "en"
+
+//go:embed packs/*.json
+var packs embed.FS
+
+// Locales lists the bundled packs, sorted.
+func Locales() []string {
+	entries, _ := packs.ReadDir("packs")
+	locales := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		locales = append(locales, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Catalog returns the starter catalog for locale, e.g. "en" or "en-US". A regional pack extends
+// the pack of its language, and a region without a pack of its own gets its language's pack, so
+// "en-AU" loads "en".
+func Catalog(locale string) (models.Catalog, error) {
+	language, region := splitLocale(locale)
+	catalog, ok, err := readPack(language)
+	if err != nil || !ok {
+		if err == nil {
+			err = fmt.Errorf("no starter catalog for locale %q; available: %v", locale, strings.Join(Locales(), ", "))
+		}
+		return models.Catalog{}, err
+	}
+	if region == "" {
+		return catalog, nil
+	}
+	regional, ok, err := readPack(language + "-" + region)
+	if err != nil || !ok {
+		return catalog, err
+	}
+	return merge(catalog, regional), nil
+}
+
+// splitLocale normalises locale to a lower-case language and an upper-case region, accepting
+// both "en-US" and "en_us".
+func splitLocale(locale string) (string, string) {
+	parts := strings.SplitN(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-", 2)
+	language := strings.ToLower(parts[0])
+	if len(parts) == 1 {
+		return language, ""
+	}
+	return language, strings.ToUpper(parts[1])
+}
+
+func readPack(name string) (models.Catalog, bool, error) {
+	var catalog models.Catalog
+	data, err := packs.ReadFile(path.Join("packs", name+".json"))
+	if err != nil {
+		return catalog, false, nil
+	}
+	if err = json.Unmarshal(data, &catalog); err != nil {
+		return catalog, false, fmt.Errorf("starter catalog %v is malformed: %v", name, err.Error())
+	}
+	return catalog, true, nil
+}
+
+// merge adds the cuisines of regional to base. Dishes and tags of a cuisine both packs name are
+// combined.
+func merge(base models.Catalog, regional models.Catalog) models.Catalog {
+	index := make(map[string]int, len(base.Cuisines))
+	for i, cuisine := range base.Cuisines {
+		index[normalize(cuisine.Name)] = i
+	}
+	for _, cuisine := range regional.Cuisines {
+		i, ok := index[normalize(cuisine.Name)]
+		if !ok {
+			index[normalize(cuisine.Name)] = len(base.Cuisines)
+			base.Cuisines = append(base.Cuisines, cuisine)
+			continue
+		}
+		existing := &base.Cuisines[i]
+		for _, tag := range cuisine.Tags {
+			if !contains(existing.Tags, tag) {
+				existing.Tags = append(existing.Tags, tag)
+			}
+		}
+		names := make([]string, len(existing.Dishes))
+		for j, dish := range existing.Dishes {
+			names[j] = normalize(dish.Name)
+		}
+		for _, dish := range cuisine.Dishes {
+			if !contains(names, normalize(dish.Name)) {
+				existing.Dishes = append(existing.Dishes, dish)
+			}
+		}
+	}
+	return base
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
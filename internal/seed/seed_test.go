@@ -0,0 +1,68 @@
+package seed
+
+import (
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCatalog_PacksAreValid(t *testing.T) {
+	assert.Equal(t, []string{"de", "en", "en-GB", "en-US", "es", "ja"}, Locales())
+	for _, locale := range Locales() {
+		catalog, err := Catalog(locale)
+		if assert.NoError(t, err, locale) {
+			assert.NotEmpty(t, catalog.Cuisines, locale)
+			assert.NoError(t, validation.Struct(catalog), locale)
+		}
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	tests := []struct {
+		name         string
+		locale       string
+		wantCuisines int
+		wantErr      string
+	}{
+		{name: "Language pack", locale: "en", wantCuisines: 12},
+		{name: "Regional pack extends its language", locale: "en-US", wantCuisines: 15},
+		{name: "Locale spelling is normalised", locale: "EN_us", wantCuisines: 15},
+		{name: "Region without a pack falls back to its language", locale: "en-AU", wantCuisines: 12},
+		{name: "Unknown language", locale: "pt-BR", wantErr: `no starter catalog for locale "pt-BR"; available: de, en, en-GB, en-US, es, ja`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalog, err := Catalog(tt.locale)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, catalog.Cuisines, tt.wantCuisines)
+		})
+	}
+}
+
+func TestCatalog_MergesSharedCuisines(t *testing.T) {
+	base, _ := Catalog("en")
+	catalog, _ := Catalog("en-GB")
+
+	baseDishes, dishes := dishNames(base, "Indian"), dishNames(catalog, "Indian")
+	assert.Len(t, dishes, len(baseDishes)+3)
+	assert.Contains(t, dishes, "Butter Chicken")
+	assert.Contains(t, dishes, "Chicken Tikka Masala")
+	assert.NotContains(t, baseDishes, "Chicken Tikka Masala", "loading a regional pack leaves the language pack alone")
+}
+
+func dishNames(catalog models.Catalog, cuisine string) []string {
+	var names []string
+	for _, c := range catalog.Cuisines {
+		if c.Name == cuisine {
+			for _, dish := range c.Dishes {
+				names = append(names, dish.Name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,203 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"regexp"
+	"strings"
+)
+
+// CatalogServiceI edits cuisines and dishes after they were created. Dishes are stored in the
+// dishes collection and copied into their cuisine's document, so every change updates both.
+//
+//go:generate mockgen -destination=mockCatalogService.go -package=mongodb . CatalogServiceI
+type CatalogServiceI interface {
+	AddDishesToCuisine(ctx context.Context, request models.AddDishesRequest) ([]models.Dish, error)
+	RenameCuisine(ctx context.Context, id primitive.ObjectID, name string) (*models.Cuisine, error)
+	DeleteCuisine(ctx context.Context, id primitive.ObjectID) (*models.Cuisine, error)
+	RenameDish(ctx context.Context, id primitive.ObjectID, name string) (*models.Dish, error)
+	DeleteDish(ctx context.Context, id primitive.ObjectID) (*models.Dish, error)
+}
+
+func (s *Service) AddDishesToCuisine(ctx context.Context, request models.AddDishesRequest) ([]models.Dish, error) {
+	cuisine, err := s.findCuisine(ctx, bson.M{"_id": request.Cuisine})
+	if err != nil {
+		return nil, cuisineNotFound(err, request.Cuisine.Hex())
+	}
+	for _, dish := range request.Dishes {
+		if existing, ok := dishNamed(cuisine.Dishes, dish.Name); ok {
+			return nil, dishExists(existing.Name, cuisine.Name)
+		}
+	}
+
+	dishes, err := s.AddAllDishes(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{"$push": bson.M{"dishes": bson.M{"$each": dishes}}}
+	if _, err = s.scoped(ctx, "cuisines").UpdateByID(ctx, request.Cuisine, update); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("added %v dishes to cuisine: %v", len(dishes), cuisine.Name)
+
+	return dishes, nil
+}
+
+func (s *Service) RenameCuisine(ctx context.Context, id primitive.ObjectID, name string) (*models.Cuisine, error) {
+	cuisines := s.scoped(ctx, "cuisines")
+	var existing models.Cuisine
+	err := cuisines.FindOne(ctx, bson.M{"name": sameName(name), "_id": bson.M{"$ne": id}}).Decode(&existing)
+	if err == nil {
+		return nil, apperrors.New(apperrors.ErrConflict, apperrors.CodeCuisineExists, fmt.Sprintf("cuisine %v already exists", existing.Name))
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	var result models.Cuisine
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	update := bson.M{"$set": bson.M{"name": name}}
+	if err = cuisines.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&result); err != nil {
+		return nil, cuisineNotFound(err, id.Hex())
+	}
+	logging.FromContext(ctx).Infof("renamed cuisine: %v to %v", id.Hex(), name)
+
+	return &result, nil
+}
+
+// DeleteCuisine removes the cuisine, every dish in it and their ratings, and returns what was
+// removed.
+func (s *Service) DeleteCuisine(ctx context.Context, id primitive.ObjectID) (*models.Cuisine, error) {
+	cuisine, err := s.findCuisine(ctx, bson.M{"_id": id})
+	if err != nil {
+		return nil, cuisineNotFound(err, id.Hex())
+	}
+	result, err := s.scoped(ctx, "cuisines").DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return nil, err
+	}
+	if result.DeletedCount == 0 {
+		return nil, cuisineNotFound(mongo.ErrNoDocuments, id.Hex())
+	}
+
+	dishIds := make([]primitive.ObjectID, len(cuisine.Dishes))
+	for i, dish := range cuisine.Dishes {
+		dishIds[i] = dish.ID
+	}
+	if _, err = s.scoped(ctx, "dishes").DeleteMany(ctx, bson.M{"_id": bson.M{"$in": dishIds}}); err != nil {
+		return nil, err
+	}
+	if _, err = s.scoped(ctx, ratingsCollection).DeleteMany(ctx, bson.M{"dish": bson.M{"$in": dishIds}}); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("deleted cuisine: %v with %v dishes", cuisine.Name, len(dishIds))
+
+	return cuisine, nil
+}
+
+func (s *Service) RenameDish(ctx context.Context, id primitive.ObjectID, name string) (*models.Dish, error) {
+	cuisine, err := s.findCuisine(ctx, bson.M{"dishes._id": id})
+	if err != nil {
+		return nil, dishNotFound(err, id.Hex())
+	}
+	if existing, ok := dishNamed(cuisine.Dishes, name); ok && existing.ID != id {
+		return nil, dishExists(existing.Name, cuisine.Name)
+	}
+
+	if _, err = s.scoped(ctx, "dishes").UpdateByID(ctx, id, bson.M{"$set": bson.M{"name": name}}); err != nil {
+		return nil, err
+	}
+	update := bson.M{"$set": bson.M{"dishes.$.name": name}}
+	if _, err = s.scoped(ctx, "cuisines").UpdateOne(ctx, bson.M{"_id": cuisine.ID, "dishes._id": id}, update); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("renamed dish: %v to %v", id.Hex(), name)
+
+	dish, _ := dishWithID(cuisine.Dishes, id)
+	dish.Name = name
+	dish.Cuisine = cuisine.ID
+	return &dish, nil
+}
+
+// DeleteDish removes the dish, its copy in the cuisine and its ratings, and returns what was
+// removed.
+func (s *Service) DeleteDish(ctx context.Context, id primitive.ObjectID) (*models.Dish, error) {
+	cuisine, err := s.findCuisine(ctx, bson.M{"dishes._id": id})
+	if err != nil {
+		return nil, dishNotFound(err, id.Hex())
+	}
+
+	if _, err = s.scoped(ctx, "dishes").DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return nil, err
+	}
+	update := bson.M{"$pull": bson.M{"dishes": bson.M{"_id": id}}}
+	if _, err = s.scoped(ctx, "cuisines").UpdateByID(ctx, cuisine.ID, update); err != nil {
+		return nil, err
+	}
+	if _, err = s.scoped(ctx, ratingsCollection).DeleteMany(ctx, bson.M{"dish": id}); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("deleted dish: %v from cuisine: %v", id.Hex(), cuisine.Name)
+
+	dish, _ := dishWithID(cuisine.Dishes, id)
+	dish.Cuisine = cuisine.ID
+	return &dish, nil
+}
+
+func (s *Service) findCuisine(ctx context.Context, filter bson.M) (*models.Cuisine, error) {
+	var cuisine models.Cuisine
+	if err := s.scoped(ctx, "cuisines").FindOne(ctx, filter).Decode(&cuisine); err != nil {
+		return nil, err
+	}
+	return &cuisine, nil
+}
+
+// dishNamed finds a dish by name the way people compare them, ignoring case and surrounding space.
+func dishNamed(dishes []models.Dish, name string) (models.Dish, bool) {
+	for _, dish := range dishes {
+		if strings.EqualFold(strings.TrimSpace(dish.Name), strings.TrimSpace(name)) {
+			return dish, true
+		}
+	}
+	return models.Dish{}, false
+}
+
+// sameName matches names equal to name the way people compare them, like dishNamed.
+func sameName(name string) primitive.Regex {
+	return primitive.Regex{Pattern: `^\s*` + regexp.QuoteMeta(strings.TrimSpace(name)) + `\s*$`, Options: "i"}
+}
+
+func dishWithID(dishes []models.Dish, id primitive.ObjectID) (models.Dish, bool) {
+	for _, dish := range dishes {
+		if dish.ID == id {
+			return dish, true
+		}
+	}
+	return models.Dish{ID: id}, false
+}
+
+func cuisineNotFound(err error, id string) error {
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	return apperrors.Wrap(err, apperrors.ErrNotFound, apperrors.CodeCuisineNotFound, fmt.Sprintf("cuisine %v not found", id))
+}
+
+func dishNotFound(err error, id string) error {
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	return apperrors.Wrap(err, apperrors.ErrNotFound, apperrors.CodeDishNotFound, fmt.Sprintf("dish %v not found", id))
+}
+
+func dishExists(dish string, cuisine string) error {
+	return apperrors.New(apperrors.ErrConflict, apperrors.CodeDishExists, fmt.Sprintf("dish %v already exists in cuisine %v", dish, cuisine))
+}
@@ -0,0 +1,30 @@
+package mongodb
+
+import (
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestSameName(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		stored string
+		want   bool
+	}{
+		{name: "Exact", query: "Thai", stored: "Thai", want: true},
+		{name: "Case and spacing differ", query: "Thai", stored: " tHAI  ", want: true},
+		{name: "Prefix only", query: "Thai", stored: "Thai Street Food", want: false},
+		{name: "Pattern characters are literal", query: "thai (northern) ", stored: "Thai (Northern)", want: true},
+		{name: "Pattern characters must be present", query: "thai (northern)", stored: "Thai Northern", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := sameName(tt.query)
+			// the server evaluates the pattern with PCRE, which agrees with RE2 on these constructs
+			matcher := regexp.MustCompile("(?" + filter.Options + ")" + filter.Pattern)
+			assert.Equal(t, tt.want, matcher.MatchString(tt.stored))
+		})
+	}
+}
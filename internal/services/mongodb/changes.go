@@ -0,0 +1,136 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const resumeTokensCollection = "resumeTokens"
+
+// changeStreamHistoryLost is the server error returned when a resume token has already left the
+// oplog, see https://www.mongodb.com/docs/manual/reference/error-codes/.
+const changeStreamHistoryLost = 286
+
+// ErrHistoryLost reports that a change stream cannot resume from its token any more; changes
+// made in between are lost and the stream has to start again from now.
+var ErrHistoryLost = errors.New("change stream history lost")
+
+// Change is one write to a watched collection.
+type Change struct {
+	// Token resumes the stream right after this change, and is unique per change.
+	Token      bson.Raw
+	Collection string
+	// Operation is "insert", "update", "replace" or "delete"; other operations end the stream.
+	Operation  string
+	DocumentID primitive.ObjectID
+	// Document is the whole document after the change, nil for deletions and for documents
+	// deleted again before the change was read.
+	Document bson.Raw
+	Time     time.Time
+}
+
+// ChangeStreamI follows the writes to a collection. It needs a replica set or sharded cluster;
+// standalone servers do not keep the oplog change streams read from.
+//
+//go:generate mockgen -destination=mockChangeStream.go -package=mongodb . ChangeStreamI
+type ChangeStreamI interface {
+	// WatchChanges calls handle with every change to collection after resumeAfter, or after now
+	// when resumeAfter is nil, until ctx is done or handle fails. The stream ends with a nil
+	// error when the collection is dropped or renamed.
+	WatchChanges(ctx context.Context, collection string, resumeAfter bson.Raw, handle func(Change) error) error
+}
+
+// ResumeTokenStoreI persists how far each change stream consumer has read.
+//
+//go:generate mockgen -destination=mockResumeTokenStore.go -package=mongodb . ResumeTokenStoreI
+type ResumeTokenStoreI interface {
+	// ResumeToken returns nil when no token was saved under name.
+	ResumeToken(ctx context.Context, name string) (bson.Raw, error)
+	SaveResumeToken(ctx context.Context, name string, token bson.Raw) error
+}
+
+type changeEvent struct {
+	OperationType string              `bson:"operationType"`
+	ClusterTime   primitive.Timestamp `bson:"clusterTime"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument bson.Raw `bson:"fullDocument"`
+}
+
+// WatchChanges reads every tenant's changes; consumers find the household on the document.
+func (s *Service) WatchChanges(ctx context.Context, collection string, resumeAfter bson.Raw, handle func(Change) error) error {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if resumeAfter != nil {
+		opts.SetResumeAfter(resumeAfter)
+	}
+	stream, err := s.Client.Database(s.Database).Collection(collection).Watch(ctx, mongo.Pipeline{}, opts)
+	if err != nil {
+		return historyLost(err)
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		var event changeEvent
+		if err = stream.Decode(&event); err != nil {
+			return err
+		}
+		switch event.OperationType {
+		case "insert", "update", "replace", "delete":
+		default:
+			// drop, rename and invalidate close the stream
+			return nil
+		}
+		change := Change{
+			Token:      stream.ResumeToken(),
+			Collection: collection,
+			Operation:  event.OperationType,
+			DocumentID: event.DocumentKey.ID,
+			Document:   event.FullDocument,
+			Time:       time.Unix(int64(event.ClusterTime.T), 0).UTC(),
+		}
+		if err = handle(change); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return historyLost(stream.Err())
+}
+
+func historyLost(err error) error {
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(changeStreamHistoryLost) {
+		return ErrHistoryLost
+	}
+	return err
+}
+
+type resumeToken struct {
+	Name      string    `bson:"_id"`
+	Token     bson.Raw  `bson:"token"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+func (s *Service) ResumeToken(ctx context.Context, name string) (bson.Raw, error) {
+	var saved resumeToken
+	err := s.Client.Database(s.Database).Collection(resumeTokensCollection).FindOne(ctx, bson.M{"_id": name}).Decode(&saved)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	return saved.Token, err
+}
+
+func (s *Service) SaveResumeToken(ctx context.Context, name string, token bson.Raw) error {
+	_, err := s.Client.Database(s.Database).Collection(resumeTokensCollection).ReplaceOne(ctx,
+		bson.M{"_id": name},
+		resumeToken{Name: name, Token: token, UpdatedAt: time.Now().UTC()},
+		options.Replace().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,28 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"food-roulette-api/internal/apperrors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// TranslateError maps driver errors onto domain errors so callers never surface driver
+// messages. Errors it does not recognise are returned unchanged.
+func TranslateError(err error) error {
+	var appErr *apperrors.Error
+	var serverSelection topology.ServerSelectionError
+	switch {
+	case err == nil, errors.As(err, &appErr):
+		return err
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return apperrors.Wrap(err, apperrors.ErrNotFound, "", "the requested resource was not found")
+	case mongo.IsDuplicateKeyError(err):
+		return apperrors.Wrap(err, apperrors.ErrConflict, "", "the resource already exists")
+	case mongo.IsNetworkError(err), mongo.IsTimeout(err), errors.As(err, &serverSelection),
+		errors.Is(err, mongo.ErrClientDisconnected), errors.Is(err, context.DeadlineExceeded):
+		return apperrors.Wrap(err, apperrors.ErrUnavailable, "", "the database is unavailable, please retry later")
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"testing"
+)
+
+func TestTranslateError(t *testing.T) {
+	conflict := apperrors.New(apperrors.ErrConflict, apperrors.CodeCuisineExists, "cuisine Thai already exists")
+	tests := []struct {
+		name     string
+		err      error
+		wantKind error
+	}{
+		{
+			name:     "No documents is not found",
+			err:      mongo.ErrNoDocuments,
+			wantKind: apperrors.ErrNotFound,
+		},
+		{
+			name: "Duplicate key is a conflict",
+			err: mongo.WriteException{WriteErrors: mongo.WriteErrors{
+				{Code: 11000, Message: "E11000 duplicate key error collection: food.cuisines index: name_1"},
+			}},
+			wantKind: apperrors.ErrConflict,
+		},
+		{
+			name:     "Disconnected client is unavailable",
+			err:      mongo.ErrClientDisconnected,
+			wantKind: apperrors.ErrUnavailable,
+		},
+		{
+			name:     "Deadline is unavailable",
+			err:      fmt.Errorf("find: %w", context.DeadlineExceeded),
+			wantKind: apperrors.ErrUnavailable,
+		},
+		{
+			name:     "Typed errors pass through",
+			err:      conflict,
+			wantKind: apperrors.ErrConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TranslateError(tt.err)
+			assert.True(t, errors.Is(got, tt.wantKind))
+			assert.Contains(t, got.Error(), tt.err.Error())
+			assert.NotContains(t, apperrors.Detail(got, apperrors.Status(got)), "E11000")
+		})
+	}
+
+	assert.Nil(t, TranslateError(nil))
+	untyped := fmt.Errorf("boom")
+	assert.Equal(t, untyped, TranslateError(untyped))
+}
@@ -0,0 +1,109 @@
+package mongodb
+
+import (
+	"context"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const householdsCollection = "households"
+
+//go:generate mockgen -destination=mockHouseholdService.go -package=mongodb . HouseholdServiceI
+type HouseholdServiceI interface {
+	AddHousehold(ctx context.Context, household models.Household) (*models.Household, error)
+	GetHousehold(ctx context.Context, id primitive.ObjectID) (*models.Household, error)
+	GetHouseholdsForMember(ctx context.Context, subject string) ([]*models.Household, error)
+	SetMember(ctx context.Context, id primitive.ObjectID, member models.Member) (*models.Household, error)
+	RemoveMember(ctx context.Context, id primitive.ObjectID, subject string) (*models.Household, error)
+}
+
+func (s *Service) AddHousehold(ctx context.Context, household models.Household) (*models.Household, error) {
+	database := s.Client.Database(s.Database)
+
+	cursor, err := database.Collection(householdsCollection).InsertOne(ctx, household)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("inserted new household: %v into database", household.Name)
+
+	if id, ok := cursor.InsertedID.(primitive.ObjectID); ok {
+		household.ID = id
+	}
+
+	return &household, nil
+}
+
+func (s *Service) GetHousehold(ctx context.Context, id primitive.ObjectID) (*models.Household, error) {
+	database := s.Client.Database(s.Database)
+	var result models.Household
+
+	err := database.Collection(householdsCollection).FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (s *Service) GetHouseholdsForMember(ctx context.Context, subject string) ([]*models.Household, error) {
+	database := s.Client.Database(s.Database)
+	var results []*models.Household
+
+	cursor, err := database.Collection(householdsCollection).Find(ctx, bson.M{"members.subject": subject})
+	if err != nil {
+		return results, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		err := cursor.Close(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("failed to close mongodb cursor; err: %v", err.Error())
+		}
+	}(cursor, ctx)
+
+	if curErr := cursor.All(ctx, &results); curErr != nil {
+		return nil, curErr
+	}
+
+	return results, nil
+}
+
+// SetMember adds member to the household, replacing the role of an existing member with the same subject.
+func (s *Service) SetMember(ctx context.Context, id primitive.ObjectID, member models.Member) (*models.Household, error) {
+	database := s.Client.Database(s.Database)
+	coll := database.Collection(householdsCollection)
+	var result models.Household
+
+	_, err := coll.UpdateByID(ctx, id, bson.M{"$pull": bson.M{"members": bson.M{"subject": member.Subject}}})
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	update := bson.M{"$push": bson.M{"members": member}}
+	err = coll.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("set household: %v member: %v to role: %v", id.Hex(), member.Subject, member.Role)
+
+	return &result, nil
+}
+
+func (s *Service) RemoveMember(ctx context.Context, id primitive.ObjectID, subject string) (*models.Household, error) {
+	database := s.Client.Database(s.Database)
+	var result models.Household
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	update := bson.M{"$pull": bson.M{"members": bson.M{"subject": subject}}}
+	err := database.Collection(householdsCollection).FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("removed member: %v from household: %v", subject, id.Hex())
+
+	return &result, nil
+}
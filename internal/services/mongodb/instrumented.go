@@ -0,0 +1,252 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"food-roulette-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Observer is notified around every storage operation. The returned func is called with the
+// operation's error once it completes; mongo.ErrNoDocuments is reported as success.
+type Observer interface {
+	Observe(ctx context.Context, operation string) (context.Context, func(err error))
+}
+
+// InstrumentedService decorates the storage interfaces with Observers, for metrics and tracing.
+type InstrumentedService struct {
+	Cuisines   ServiceI
+	Keys       KeyServiceI
+	Users      UserServiceI
+	Households HouseholdServiceI
+	Ratings    RatingServiceI
+	Catalog    CatalogServiceI
+	Webhooks   WebhookServiceI
+	Observers  []Observer
+}
+
+func (s *InstrumentedService) observe(ctx context.Context, operation string) (context.Context, func(err error)) {
+	dones := make([]func(error), len(s.Observers))
+	for i, observer := range s.Observers {
+		ctx, dones[i] = observer.Observe(ctx, operation)
+	}
+	return ctx, func(err error) {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = nil
+		}
+		for i := len(dones) - 1; i >= 0; i-- {
+			dones[i](err)
+		}
+	}
+}
+
+func (s *InstrumentedService) AddNewCuisine(ctx context.Context, request models.AddCuisineRequest) (*models.Cuisine, error) {
+	ctx, done := s.observe(ctx, "AddNewCuisine")
+	result, err := s.Cuisines.AddNewCuisine(ctx, request)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) AddAllDishes(ctx context.Context, request models.AddDishesRequest) ([]models.Dish, error) {
+	ctx, done := s.observe(ctx, "AddAllDishes")
+	result, err := s.Cuisines.AddAllDishes(ctx, request)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) GetAllCuisines(ctx context.Context) ([]*models.Cuisine, error) {
+	ctx, done := s.observe(ctx, "GetAllCuisines")
+	result, err := s.Cuisines.GetAllCuisines(ctx)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) AddApiKey(ctx context.Context, key models.ApiKey) (*models.ApiKey, error) {
+	ctx, done := s.observe(ctx, "AddApiKey")
+	result, err := s.Keys.AddApiKey(ctx, key)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) FindApiKeyByHash(ctx context.Context, hash string) (*models.ApiKey, error) {
+	ctx, done := s.observe(ctx, "FindApiKeyByHash")
+	result, err := s.Keys.FindApiKeyByHash(ctx, hash)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) GetAllApiKeys(ctx context.Context) ([]*models.ApiKey, error) {
+	ctx, done := s.observe(ctx, "GetAllApiKeys")
+	result, err := s.Keys.GetAllApiKeys(ctx)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) RevokeApiKey(ctx context.Context, id primitive.ObjectID) error {
+	ctx, done := s.observe(ctx, "RevokeApiKey")
+	err := s.Keys.RevokeApiKey(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *InstrumentedService) GetUser(ctx context.Context, subject string) (*models.User, error) {
+	ctx, done := s.observe(ctx, "GetUser")
+	result, err := s.Users.GetUser(ctx, subject)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) SetPreference(ctx context.Context, subject string, target string, id primitive.ObjectID, preference string) (*models.User, error) {
+	ctx, done := s.observe(ctx, "SetPreference")
+	result, err := s.Users.SetPreference(ctx, subject, target, id, preference)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) AddHousehold(ctx context.Context, household models.Household) (*models.Household, error) {
+	ctx, done := s.observe(ctx, "AddHousehold")
+	result, err := s.Households.AddHousehold(ctx, household)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) GetHousehold(ctx context.Context, id primitive.ObjectID) (*models.Household, error) {
+	ctx, done := s.observe(ctx, "GetHousehold")
+	result, err := s.Households.GetHousehold(ctx, id)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) GetHouseholdsForMember(ctx context.Context, subject string) ([]*models.Household, error) {
+	ctx, done := s.observe(ctx, "GetHouseholdsForMember")
+	result, err := s.Households.GetHouseholdsForMember(ctx, subject)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) SetMember(ctx context.Context, id primitive.ObjectID, member models.Member) (*models.Household, error) {
+	ctx, done := s.observe(ctx, "SetMember")
+	result, err := s.Households.SetMember(ctx, id, member)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) RemoveMember(ctx context.Context, id primitive.ObjectID, subject string) (*models.Household, error) {
+	ctx, done := s.observe(ctx, "RemoveMember")
+	result, err := s.Households.RemoveMember(ctx, id, subject)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) SetRating(ctx context.Context, rating models.Rating) (*models.Rating, error) {
+	ctx, done := s.observe(ctx, "SetRating")
+	result, err := s.Ratings.SetRating(ctx, rating)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) GetRatingsForDish(ctx context.Context, dishId primitive.ObjectID) ([]*models.Rating, error) {
+	ctx, done := s.observe(ctx, "GetRatingsForDish")
+	result, err := s.Ratings.GetRatingsForDish(ctx, dishId)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) GetRatingsForDishes(ctx context.Context, dishIds []primitive.ObjectID) ([]*models.Rating, error) {
+	ctx, done := s.observe(ctx, "GetRatingsForDishes")
+	result, err := s.Ratings.GetRatingsForDishes(ctx, dishIds)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) GetRatingSummaries(ctx context.Context) ([]models.RatingSummary, error) {
+	ctx, done := s.observe(ctx, "GetRatingSummaries")
+	result, err := s.Ratings.GetRatingSummaries(ctx)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) AddDishesToCuisine(ctx context.Context, request models.AddDishesRequest) ([]models.Dish, error) {
+	ctx, done := s.observe(ctx, "AddDishesToCuisine")
+	result, err := s.Catalog.AddDishesToCuisine(ctx, request)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) RenameCuisine(ctx context.Context, id primitive.ObjectID, name string) (*models.Cuisine, error) {
+	ctx, done := s.observe(ctx, "RenameCuisine")
+	result, err := s.Catalog.RenameCuisine(ctx, id, name)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) DeleteCuisine(ctx context.Context, id primitive.ObjectID) (*models.Cuisine, error) {
+	ctx, done := s.observe(ctx, "DeleteCuisine")
+	result, err := s.Catalog.DeleteCuisine(ctx, id)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) RenameDish(ctx context.Context, id primitive.ObjectID, name string) (*models.Dish, error) {
+	ctx, done := s.observe(ctx, "RenameDish")
+	result, err := s.Catalog.RenameDish(ctx, id, name)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) DeleteDish(ctx context.Context, id primitive.ObjectID) (*models.Dish, error) {
+	ctx, done := s.observe(ctx, "DeleteDish")
+	result, err := s.Catalog.DeleteDish(ctx, id)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) AddWebhook(ctx context.Context, webhook models.Webhook) (*models.Webhook, error) {
+	ctx, done := s.observe(ctx, "AddWebhook")
+	result, err := s.Webhooks.AddWebhook(ctx, webhook)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) GetWebhooks(ctx context.Context) ([]*models.Webhook, error) {
+	ctx, done := s.observe(ctx, "GetWebhooks")
+	result, err := s.Webhooks.GetWebhooks(ctx)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) WebhooksFor(ctx context.Context, event string) ([]*models.Webhook, error) {
+	ctx, done := s.observe(ctx, "WebhooksFor")
+	result, err := s.Webhooks.WebhooksFor(ctx, event)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) DeleteWebhook(ctx context.Context, id primitive.ObjectID) error {
+	ctx, done := s.observe(ctx, "DeleteWebhook")
+	err := s.Webhooks.DeleteWebhook(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *InstrumentedService) EnqueueDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	ctx, done := s.observe(ctx, "EnqueueDeliveries")
+	err := s.Webhooks.EnqueueDeliveries(ctx, deliveries)
+	done(err)
+	return err
+}
+
+func (s *InstrumentedService) GetDeliveries(ctx context.Context, webhookId primitive.ObjectID) ([]*models.WebhookDelivery, error) {
+	ctx, done := s.observe(ctx, "GetDeliveries")
+	result, err := s.Webhooks.GetDeliveries(ctx, webhookId)
+	done(err)
+	return result, err
+}
+
+func (s *InstrumentedService) ReplayDelivery(ctx context.Context, webhookId primitive.ObjectID, id primitive.ObjectID) (*models.WebhookDelivery, error) {
+	ctx, done := s.observe(ctx, "ReplayDelivery")
+	result, err := s.Webhooks.ReplayDelivery(ctx, webhookId, id)
+	done(err)
+	return result, err
+}
@@ -0,0 +1,48 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"food-roulette-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"testing"
+)
+
+type recordingObserver struct {
+	operations []string
+	errs       []error
+}
+
+func (o *recordingObserver) Observe(ctx context.Context, operation string) (context.Context, func(err error)) {
+	o.operations = append(o.operations, operation)
+	return ctx, func(err error) {
+		o.errs = append(o.errs, err)
+	}
+}
+
+func TestInstrumentedService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSvc := NewMockServiceI(ctrl)
+	mockKeySvc := NewMockKeyServiceI(ctrl)
+	observer := &recordingObserver{}
+	s := &InstrumentedService{
+		Cuisines:  mockSvc,
+		Keys:      mockKeySvc,
+		Observers: []Observer{observer},
+	}
+	insertErr := fmt.Errorf("insert failed")
+
+	mockSvc.EXPECT().AddNewCuisine(gomock.Any(), gomock.Any()).Return(nil, insertErr)
+	mockKeySvc.EXPECT().FindApiKeyByHash(gomock.Any(), "hash").Return(nil, mongo.ErrNoDocuments)
+
+	_, err := s.AddNewCuisine(context.Background(), models.AddCuisineRequest{Name: "thai"})
+	assert.Equal(t, insertErr, err)
+	_, err = s.FindApiKeyByHash(context.Background(), "hash")
+	assert.Equal(t, mongo.ErrNoDocuments, err)
+
+	assert.Equal(t, []string{"AddNewCuisine", "FindApiKeyByHash"}, observer.operations)
+	assert.Equal(t, []error{insertErr, nil}, observer.errs)
+}
@@ -0,0 +1,88 @@
+package mongodb
+
+import (
+	"context"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+)
+
+const apiKeysCollection = "apikeys"
+
+//go:generate mockgen -destination=mockKeyService.go -package=mongodb . KeyServiceI
+type KeyServiceI interface {
+	AddApiKey(ctx context.Context, key models.ApiKey) (*models.ApiKey, error)
+	FindApiKeyByHash(ctx context.Context, hash string) (*models.ApiKey, error)
+	GetAllApiKeys(ctx context.Context) ([]*models.ApiKey, error)
+	RevokeApiKey(ctx context.Context, id primitive.ObjectID) error
+}
+
+func (s *Service) AddApiKey(ctx context.Context, key models.ApiKey) (*models.ApiKey, error) {
+	database := s.Client.Database(s.Database)
+
+	cursor, err := database.Collection(apiKeysCollection).InsertOne(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("issued new api key: %v with role: %v", key.Name, key.Role)
+
+	if id, ok := cursor.InsertedID.(primitive.ObjectID); ok {
+		key.ID = id
+	}
+
+	return &key, nil
+}
+
+func (s *Service) FindApiKeyByHash(ctx context.Context, hash string) (*models.ApiKey, error) {
+	database := s.Client.Database(s.Database)
+	var result models.ApiKey
+
+	filter := bson.M{"hash": hash, "revokedAt": bson.M{"$exists": false}}
+	err := database.Collection(apiKeysCollection).FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (s *Service) GetAllApiKeys(ctx context.Context) ([]*models.ApiKey, error) {
+	database := s.Client.Database(s.Database)
+	var results []*models.ApiKey
+
+	cursor, err := database.Collection(apiKeysCollection).Find(ctx, bson.D{})
+	if err != nil {
+		return results, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		err := cursor.Close(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("failed to close mongodb cursor; err: %v", err.Error())
+		}
+	}(cursor, ctx)
+
+	if curErr := cursor.All(ctx, &results); curErr != nil {
+		return nil, curErr
+	}
+
+	return results, nil
+}
+
+func (s *Service) RevokeApiKey(ctx context.Context, id primitive.ObjectID) error {
+	database := s.Client.Database(s.Database)
+
+	update := bson.M{"$set": bson.M{"revokedAt": time.Now().UTC()}}
+	result, err := database.Collection(apiKeysCollection).UpdateByID(ctx, id, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	logging.FromContext(ctx).Infof("revoked api key: %v", id.Hex())
+
+	return nil
+}
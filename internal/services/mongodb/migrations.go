@@ -0,0 +1,132 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"strings"
+	"time"
+)
+
+const migrationsCollection = "migrations"
+
+// nameCollation compares strings ignoring case, so the unique cuisine name index refuses names
+// that differ from a stored one only in case, as sameName does.
+var nameCollation = &options.Collation{Locale: "en", Strength: 2}
+
+// MigratorI applies the versioned changes to the database layout that are not applied yet.
+type MigratorI interface {
+	Migrations(ctx context.Context) ([]*models.Migration, error)
+	Migrate(ctx context.Context) ([]*models.Migration, error)
+}
+
+type migration struct {
+	version     int
+	description string
+	up          func(ctx context.Context, database *mongo.Database) error
+}
+
+// migrations run in order and each is recorded in the migrations collection once applied.
+// Append new ones; never edit or renumber one that has been released.
+var migrations = []migration{
+	{version: 1, description: "index cuisines by household and name", up: createIndex("cuisines", false, householdField, "name")},
+	{version: 2, description: "index cuisines by embedded dish id", up: createIndex("cuisines", false, "dishes._id")},
+	{version: 3, description: "index api keys by hash", up: createIndex(apiKeysCollection, true, "hash")},
+	{version: 4, description: "index users by subject", up: createIndex(usersCollection, true, "subject")},
+	{version: 5, description: "index households by member", up: createIndex(householdsCollection, false, "members.subject")},
+	{version: 6, description: "index ratings by household, dish and subject", up: createIndex(ratingsCollection, true, householdField, "dish", "subject")},
+	{version: 7, description: "index webhooks by household and event", up: createIndex(webhooksCollection, false, householdField, "events")},
+	{version: 8, description: "index webhook deliveries by status and schedule", up: createIndex(deliveriesCollection, false, "status", "nextAttemptAt")},
+	{version: 9, description: "index webhook deliveries by webhook", up: createIndex(deliveriesCollection, false, "webhook", "createdAt")},
+	{version: 10, description: "make the cuisines household and name index unique", up: replaceIndex("cuisines", options.Index().SetUnique(true).SetCollation(nameCollation), householdField, "name")},
+}
+
+func createIndex(collection string, unique bool, keys ...string) func(ctx context.Context, database *mongo.Database) error {
+	return createIndexWith(collection, options.Index().SetUnique(unique), keys...)
+}
+
+// createIndexWith creates the index on keys with opts, for indexes that need more than
+// uniqueness, such as a collation.
+func createIndexWith(collection string, opts *options.IndexOptions, keys ...string) func(ctx context.Context, database *mongo.Database) error {
+	return func(ctx context.Context, database *mongo.Database) error {
+		index := bson.D{}
+		for _, key := range keys {
+			index = append(index, bson.E{Key: key, Value: 1})
+		}
+		_, err := database.Collection(collection).Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    index,
+			Options: opts,
+		})
+		return err
+	}
+}
+
+// replaceIndex drops the index on keys, if there is one, and creates it again with opts, since
+// MongoDB refuses a second index on the same keys. If existing documents violate the new index
+// the migration fails and can be retried once they are fixed.
+func replaceIndex(collection string, opts *options.IndexOptions, keys ...string) func(ctx context.Context, database *mongo.Database) error {
+	create := createIndexWith(collection, opts, keys...)
+	return func(ctx context.Context, database *mongo.Database) error {
+		_, err := database.Collection(collection).Indexes().DropOne(ctx, strings.Join(keys, "_1_")+"_1")
+		var cmdErr mongo.CommandError
+		if err != nil && !(errors.As(err, &cmdErr) && cmdErr.Name == "IndexNotFound") {
+			return err
+		}
+		return create(ctx, database)
+	}
+}
+
+// Migrations lists every known migration in order, with AppliedAt set on those already applied.
+func (s *Service) Migrations(ctx context.Context) ([]*models.Migration, error) {
+	cursor, err := s.Client.Database(s.Database).Collection(migrationsCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var applied []models.Migration
+	if err = cursor.All(ctx, &applied); err != nil {
+		return nil, err
+	}
+	appliedAt := make(map[int]*time.Time, len(applied))
+	for _, m := range applied {
+		appliedAt[m.Version] = m.AppliedAt
+	}
+
+	results := make([]*models.Migration, len(migrations))
+	for i, m := range migrations {
+		results[i] = &models.Migration{Version: m.version, Description: m.description, AppliedAt: appliedAt[m.version]}
+	}
+	return results, nil
+}
+
+// Migrate applies the pending migrations in order and returns those it applied. It stops at the
+// first failure; the migrations before it stay applied.
+func (s *Service) Migrate(ctx context.Context) ([]*models.Migration, error) {
+	statuses, err := s.Migrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	database := s.Client.Database(s.Database)
+
+	var applied []*models.Migration
+	for i, m := range migrations {
+		if statuses[i].AppliedAt != nil {
+			continue
+		}
+		if err = m.up(ctx, database); err != nil {
+			return applied, fmt.Errorf("migration %v (%v) failed: %w", m.version, m.description, err)
+		}
+		now := time.Now().UTC()
+		record := &models.Migration{Version: m.version, Description: m.description, AppliedAt: &now}
+		if _, err = database.Collection(migrationsCollection).InsertOne(ctx, record); err != nil {
+			return applied, fmt.Errorf("failed to record migration %v: %w", m.version, err)
+		}
+		logging.FromContext(ctx).Infof("applied migration %v: %v", m.version, m.description)
+		applied = append(applied, record)
+	}
+	return applied, nil
+}
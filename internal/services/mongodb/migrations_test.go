@@ -0,0 +1,38 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"testing"
+)
+
+func TestMigrations_CuisineNameIndexIgnoresCase(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("Unique with a strength 2 collation", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		for _, m := range migrations {
+			if m.version == 10 {
+				assert.NoError(t, m.up(context.Background(), mt.DB))
+			}
+		}
+
+		var command struct {
+			Indexes []struct {
+				Unique    bool `bson:"unique"`
+				Collation struct {
+					Strength int `bson:"strength"`
+				} `bson:"collation"`
+			} `bson:"indexes"`
+		}
+		mt.GetStartedEvent() // dropIndexes
+		if assert.NoError(t, bson.Unmarshal(mt.GetStartedEvent().Command, &command)) && assert.Len(t, command.Indexes, 1) {
+			assert.True(t, command.Indexes[0].Unique)
+			assert.Equal(t, 2, command.Indexes[0].Collation.Strength)
+		}
+	})
+}
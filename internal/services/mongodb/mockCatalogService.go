@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: food-roulette-api/internal/services/mongodb (interfaces: CatalogServiceI)
+
+// Package mongodb is a generated GoMock package.
+package mongodb
+
+import (
+	context "context"
+	models "food-roulette-api/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockCatalogServiceI is a mock of CatalogServiceI interface.
+type MockCatalogServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogServiceIMockRecorder
+}
+
+// MockCatalogServiceIMockRecorder is the mock recorder for MockCatalogServiceI.
+type MockCatalogServiceIMockRecorder struct {
+	mock *MockCatalogServiceI
+}
+
+// NewMockCatalogServiceI creates a new mock instance.
+func NewMockCatalogServiceI(ctrl *gomock.Controller) *MockCatalogServiceI {
+	mock := &MockCatalogServiceI{ctrl: ctrl}
+	mock.recorder = &MockCatalogServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogServiceI) EXPECT() *MockCatalogServiceIMockRecorder {
+	return m.recorder
+}
+
+// AddDishesToCuisine mocks base method.
+func (m *MockCatalogServiceI) AddDishesToCuisine(arg0 context.Context, arg1 models.AddDishesRequest) ([]models.Dish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDishesToCuisine", arg0, arg1)
+	ret0, _ := ret[0].([]models.Dish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDishesToCuisine indicates an expected call of AddDishesToCuisine.
+func (mr *MockCatalogServiceIMockRecorder) AddDishesToCuisine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDishesToCuisine", reflect.TypeOf((*MockCatalogServiceI)(nil).AddDishesToCuisine), arg0, arg1)
+}
+
+// DeleteCuisine mocks base method.
+func (m *MockCatalogServiceI) DeleteCuisine(arg0 context.Context, arg1 primitive.ObjectID) (*models.Cuisine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCuisine", arg0, arg1)
+	ret0, _ := ret[0].(*models.Cuisine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCuisine indicates an expected call of DeleteCuisine.
+func (mr *MockCatalogServiceIMockRecorder) DeleteCuisine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCuisine", reflect.TypeOf((*MockCatalogServiceI)(nil).DeleteCuisine), arg0, arg1)
+}
+
+// DeleteDish mocks base method.
+func (m *MockCatalogServiceI) DeleteDish(arg0 context.Context, arg1 primitive.ObjectID) (*models.Dish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDish", arg0, arg1)
+	ret0, _ := ret[0].(*models.Dish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDish indicates an expected call of DeleteDish.
+func (mr *MockCatalogServiceIMockRecorder) DeleteDish(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDish", reflect.TypeOf((*MockCatalogServiceI)(nil).DeleteDish), arg0, arg1)
+}
+
+// RenameCuisine mocks base method.
+func (m *MockCatalogServiceI) RenameCuisine(arg0 context.Context, arg1 primitive.ObjectID, arg2 string) (*models.Cuisine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameCuisine", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Cuisine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameCuisine indicates an expected call of RenameCuisine.
+func (mr *MockCatalogServiceIMockRecorder) RenameCuisine(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameCuisine", reflect.TypeOf((*MockCatalogServiceI)(nil).RenameCuisine), arg0, arg1, arg2)
+}
+
+// RenameDish mocks base method.
+func (m *MockCatalogServiceI) RenameDish(arg0 context.Context, arg1 primitive.ObjectID, arg2 string) (*models.Dish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameDish", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Dish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameDish indicates an expected call of RenameDish.
+func (mr *MockCatalogServiceIMockRecorder) RenameDish(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameDish", reflect.TypeOf((*MockCatalogServiceI)(nil).RenameDish), arg0, arg1, arg2)
+}
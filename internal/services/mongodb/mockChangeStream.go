@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: food-roulette-api/internal/services/mongodb (interfaces: ChangeStreamI)
+
+// Package mongodb is a generated GoMock package.
+package mongodb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	bson "go.mongodb.org/mongo-driver/bson"
+)
+
+// MockChangeStreamI is a mock of ChangeStreamI interface.
+type MockChangeStreamI struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeStreamIMockRecorder
+}
+
+// MockChangeStreamIMockRecorder is the mock recorder for MockChangeStreamI.
+type MockChangeStreamIMockRecorder struct {
+	mock *MockChangeStreamI
+}
+
+// NewMockChangeStreamI creates a new mock instance.
+func NewMockChangeStreamI(ctrl *gomock.Controller) *MockChangeStreamI {
+	mock := &MockChangeStreamI{ctrl: ctrl}
+	mock.recorder = &MockChangeStreamIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeStreamI) EXPECT() *MockChangeStreamIMockRecorder {
+	return m.recorder
+}
+
+// WatchChanges mocks base method.
+func (m *MockChangeStreamI) WatchChanges(arg0 context.Context, arg1 string, arg2 bson.Raw, arg3 func(Change) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchChanges", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchChanges indicates an expected call of WatchChanges.
+func (mr *MockChangeStreamIMockRecorder) WatchChanges(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchChanges", reflect.TypeOf((*MockChangeStreamI)(nil).WatchChanges), arg0, arg1, arg2, arg3)
+}
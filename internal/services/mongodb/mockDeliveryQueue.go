@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: food-roulette-api/internal/services/mongodb (interfaces: DeliveryQueueI)
+
+// Package mongodb is a generated GoMock package.
+package mongodb
+
+import (
+	context "context"
+	models "food-roulette-api/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockDeliveryQueueI is a mock of DeliveryQueueI interface.
+type MockDeliveryQueueI struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryQueueIMockRecorder
+}
+
+// MockDeliveryQueueIMockRecorder is the mock recorder for MockDeliveryQueueI.
+type MockDeliveryQueueIMockRecorder struct {
+	mock *MockDeliveryQueueI
+}
+
+// NewMockDeliveryQueueI creates a new mock instance.
+func NewMockDeliveryQueueI(ctrl *gomock.Controller) *MockDeliveryQueueI {
+	mock := &MockDeliveryQueueI{ctrl: ctrl}
+	mock.recorder = &MockDeliveryQueueIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryQueueI) EXPECT() *MockDeliveryQueueIMockRecorder {
+	return m.recorder
+}
+
+// ClaimDelivery mocks base method.
+func (m *MockDeliveryQueueI) ClaimDelivery(arg0 context.Context, arg1 time.Time, arg2 time.Duration) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDelivery indicates an expected call of ClaimDelivery.
+func (mr *MockDeliveryQueueIMockRecorder) ClaimDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDelivery", reflect.TypeOf((*MockDeliveryQueueI)(nil).ClaimDelivery), arg0, arg1, arg2)
+}
+
+// RecordAttempt mocks base method.
+func (m *MockDeliveryQueueI) RecordAttempt(arg0 context.Context, arg1 models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAttempt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAttempt indicates an expected call of RecordAttempt.
+func (mr *MockDeliveryQueueIMockRecorder) RecordAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttempt", reflect.TypeOf((*MockDeliveryQueueI)(nil).RecordAttempt), arg0, arg1)
+}
+
+// WebhookByID mocks base method.
+func (m *MockDeliveryQueueI) WebhookByID(arg0 context.Context, arg1 primitive.ObjectID) (*models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WebhookByID indicates an expected call of WebhookByID.
+func (mr *MockDeliveryQueueIMockRecorder) WebhookByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookByID", reflect.TypeOf((*MockDeliveryQueueI)(nil).WebhookByID), arg0, arg1)
+}
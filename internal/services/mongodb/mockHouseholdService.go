@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: food-roulette-api/internal/services/mongodb (interfaces: HouseholdServiceI)
+
+// Package mongodb is a generated GoMock package.
+package mongodb
+
+import (
+	context "context"
+	models "food-roulette-api/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockHouseholdServiceI is a mock of HouseholdServiceI interface.
+type MockHouseholdServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockHouseholdServiceIMockRecorder
+}
+
+// MockHouseholdServiceIMockRecorder is the mock recorder for MockHouseholdServiceI.
+type MockHouseholdServiceIMockRecorder struct {
+	mock *MockHouseholdServiceI
+}
+
+// NewMockHouseholdServiceI creates a new mock instance.
+func NewMockHouseholdServiceI(ctrl *gomock.Controller) *MockHouseholdServiceI {
+	mock := &MockHouseholdServiceI{ctrl: ctrl}
+	mock.recorder = &MockHouseholdServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHouseholdServiceI) EXPECT() *MockHouseholdServiceIMockRecorder {
+	return m.recorder
+}
+
+// AddHousehold mocks base method.
+func (m *MockHouseholdServiceI) AddHousehold(arg0 context.Context, arg1 models.Household) (*models.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHousehold", arg0, arg1)
+	ret0, _ := ret[0].(*models.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddHousehold indicates an expected call of AddHousehold.
+func (mr *MockHouseholdServiceIMockRecorder) AddHousehold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHousehold", reflect.TypeOf((*MockHouseholdServiceI)(nil).AddHousehold), arg0, arg1)
+}
+
+// GetHousehold mocks base method.
+func (m *MockHouseholdServiceI) GetHousehold(arg0 context.Context, arg1 primitive.ObjectID) (*models.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHousehold", arg0, arg1)
+	ret0, _ := ret[0].(*models.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHousehold indicates an expected call of GetHousehold.
+func (mr *MockHouseholdServiceIMockRecorder) GetHousehold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHousehold", reflect.TypeOf((*MockHouseholdServiceI)(nil).GetHousehold), arg0, arg1)
+}
+
+// GetHouseholdsForMember mocks base method.
+func (m *MockHouseholdServiceI) GetHouseholdsForMember(arg0 context.Context, arg1 string) ([]*models.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholdsForMember", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholdsForMember indicates an expected call of GetHouseholdsForMember.
+func (mr *MockHouseholdServiceIMockRecorder) GetHouseholdsForMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholdsForMember", reflect.TypeOf((*MockHouseholdServiceI)(nil).GetHouseholdsForMember), arg0, arg1)
+}
+
+// RemoveMember mocks base method.
+func (m *MockHouseholdServiceI) RemoveMember(arg0 context.Context, arg1 primitive.ObjectID, arg2 string) (*models.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockHouseholdServiceIMockRecorder) RemoveMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockHouseholdServiceI)(nil).RemoveMember), arg0, arg1, arg2)
+}
+
+// SetMember mocks base method.
+func (m *MockHouseholdServiceI) SetMember(arg0 context.Context, arg1 primitive.ObjectID, arg2 models.Member) (*models.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMember indicates an expected call of SetMember.
+func (mr *MockHouseholdServiceIMockRecorder) SetMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMember", reflect.TypeOf((*MockHouseholdServiceI)(nil).SetMember), arg0, arg1, arg2)
+}
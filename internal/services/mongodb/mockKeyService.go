@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: food-roulette-api/internal/services/mongodb (interfaces: KeyServiceI)
+
+// Package mongodb is a generated GoMock package.
+package mongodb
+
+import (
+	context "context"
+	models "food-roulette-api/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockKeyServiceI is a mock of KeyServiceI interface.
+type MockKeyServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyServiceIMockRecorder
+}
+
+// MockKeyServiceIMockRecorder is the mock recorder for MockKeyServiceI.
+type MockKeyServiceIMockRecorder struct {
+	mock *MockKeyServiceI
+}
+
+// NewMockKeyServiceI creates a new mock instance.
+func NewMockKeyServiceI(ctrl *gomock.Controller) *MockKeyServiceI {
+	mock := &MockKeyServiceI{ctrl: ctrl}
+	mock.recorder = &MockKeyServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyServiceI) EXPECT() *MockKeyServiceIMockRecorder {
+	return m.recorder
+}
+
+// AddApiKey mocks base method.
+func (m *MockKeyServiceI) AddApiKey(arg0 context.Context, arg1 models.ApiKey) (*models.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddApiKey", arg0, arg1)
+	ret0, _ := ret[0].(*models.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddApiKey indicates an expected call of AddApiKey.
+func (mr *MockKeyServiceIMockRecorder) AddApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddApiKey", reflect.TypeOf((*MockKeyServiceI)(nil).AddApiKey), arg0, arg1)
+}
+
+// FindApiKeyByHash mocks base method.
+func (m *MockKeyServiceI) FindApiKeyByHash(arg0 context.Context, arg1 string) (*models.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApiKeyByHash", arg0, arg1)
+	ret0, _ := ret[0].(*models.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApiKeyByHash indicates an expected call of FindApiKeyByHash.
+func (mr *MockKeyServiceIMockRecorder) FindApiKeyByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApiKeyByHash", reflect.TypeOf((*MockKeyServiceI)(nil).FindApiKeyByHash), arg0, arg1)
+}
+
+// GetAllApiKeys mocks base method.
+func (m *MockKeyServiceI) GetAllApiKeys(arg0 context.Context) ([]*models.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllApiKeys", arg0)
+	ret0, _ := ret[0].([]*models.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllApiKeys indicates an expected call of GetAllApiKeys.
+func (mr *MockKeyServiceIMockRecorder) GetAllApiKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllApiKeys", reflect.TypeOf((*MockKeyServiceI)(nil).GetAllApiKeys), arg0)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockKeyServiceI) RevokeApiKey(arg0 context.Context, arg1 primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeApiKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockKeyServiceIMockRecorder) RevokeApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockKeyServiceI)(nil).RevokeApiKey), arg0, arg1)
+}
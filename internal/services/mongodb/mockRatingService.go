@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: food-roulette-api/internal/services/mongodb (interfaces: RatingServiceI)
+
+// Package mongodb is a generated GoMock package.
+package mongodb
+
+import (
+	context "context"
+	models "food-roulette-api/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockRatingServiceI is a mock of RatingServiceI interface.
+type MockRatingServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingServiceIMockRecorder
+}
+
+// MockRatingServiceIMockRecorder is the mock recorder for MockRatingServiceI.
+type MockRatingServiceIMockRecorder struct {
+	mock *MockRatingServiceI
+}
+
+// NewMockRatingServiceI creates a new mock instance.
+func NewMockRatingServiceI(ctrl *gomock.Controller) *MockRatingServiceI {
+	mock := &MockRatingServiceI{ctrl: ctrl}
+	mock.recorder = &MockRatingServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingServiceI) EXPECT() *MockRatingServiceIMockRecorder {
+	return m.recorder
+}
+
+// GetRatingSummaries mocks base method.
+func (m *MockRatingServiceI) GetRatingSummaries(arg0 context.Context) ([]models.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingSummaries", arg0)
+	ret0, _ := ret[0].([]models.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingSummaries indicates an expected call of GetRatingSummaries.
+func (mr *MockRatingServiceIMockRecorder) GetRatingSummaries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingSummaries", reflect.TypeOf((*MockRatingServiceI)(nil).GetRatingSummaries), arg0)
+}
+
+// GetRatingsForDish mocks base method.
+func (m *MockRatingServiceI) GetRatingsForDish(arg0 context.Context, arg1 primitive.ObjectID) ([]*models.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingsForDish", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingsForDish indicates an expected call of GetRatingsForDish.
+func (mr *MockRatingServiceIMockRecorder) GetRatingsForDish(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingsForDish", reflect.TypeOf((*MockRatingServiceI)(nil).GetRatingsForDish), arg0, arg1)
+}
+
+// GetRatingsForDishes mocks base method.
+func (m *MockRatingServiceI) GetRatingsForDishes(arg0 context.Context, arg1 []primitive.ObjectID) ([]*models.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingsForDishes", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingsForDishes indicates an expected call of GetRatingsForDishes.
+func (mr *MockRatingServiceIMockRecorder) GetRatingsForDishes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingsForDishes", reflect.TypeOf((*MockRatingServiceI)(nil).GetRatingsForDishes), arg0, arg1)
+}
+
+// SetRating mocks base method.
+func (m *MockRatingServiceI) SetRating(arg0 context.Context, arg1 models.Rating) (*models.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRating", arg0, arg1)
+	ret0, _ := ret[0].(*models.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRating indicates an expected call of SetRating.
+func (mr *MockRatingServiceIMockRecorder) SetRating(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRating", reflect.TypeOf((*MockRatingServiceI)(nil).SetRating), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: food-roulette-api/internal/services/mongodb (interfaces: ResumeTokenStoreI)
+
+// Package mongodb is a generated GoMock package.
+package mongodb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	bson "go.mongodb.org/mongo-driver/bson"
+)
+
+// MockResumeTokenStoreI is a mock of ResumeTokenStoreI interface.
+type MockResumeTokenStoreI struct {
+	ctrl     *gomock.Controller
+	recorder *MockResumeTokenStoreIMockRecorder
+}
+
+// MockResumeTokenStoreIMockRecorder is the mock recorder for MockResumeTokenStoreI.
+type MockResumeTokenStoreIMockRecorder struct {
+	mock *MockResumeTokenStoreI
+}
+
+// NewMockResumeTokenStoreI creates a new mock instance.
+func NewMockResumeTokenStoreI(ctrl *gomock.Controller) *MockResumeTokenStoreI {
+	mock := &MockResumeTokenStoreI{ctrl: ctrl}
+	mock.recorder = &MockResumeTokenStoreIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResumeTokenStoreI) EXPECT() *MockResumeTokenStoreIMockRecorder {
+	return m.recorder
+}
+
+// ResumeToken mocks base method.
+func (m *MockResumeTokenStoreI) ResumeToken(arg0 context.Context, arg1 string) (bson.Raw, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeToken", arg0, arg1)
+	ret0, _ := ret[0].(bson.Raw)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeToken indicates an expected call of ResumeToken.
+func (mr *MockResumeTokenStoreIMockRecorder) ResumeToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeToken", reflect.TypeOf((*MockResumeTokenStoreI)(nil).ResumeToken), arg0, arg1)
+}
+
+// SaveResumeToken mocks base method.
+func (m *MockResumeTokenStoreI) SaveResumeToken(arg0 context.Context, arg1 string, arg2 bson.Raw) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveResumeToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveResumeToken indicates an expected call of SaveResumeToken.
+func (mr *MockResumeTokenStoreIMockRecorder) SaveResumeToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveResumeToken", reflect.TypeOf((*MockResumeTokenStoreI)(nil).SaveResumeToken), arg0, arg1, arg2)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: food-roulette-api/internal/services/mongodb (interfaces: UserServiceI)
+
+// Package mongodb is a generated GoMock package.
+package mongodb
+
+import (
+	context "context"
+	models "food-roulette-api/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockUserServiceI is a mock of UserServiceI interface.
+type MockUserServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceIMockRecorder
+}
+
+// MockUserServiceIMockRecorder is the mock recorder for MockUserServiceI.
+type MockUserServiceIMockRecorder struct {
+	mock *MockUserServiceI
+}
+
+// NewMockUserServiceI creates a new mock instance.
+func NewMockUserServiceI(ctrl *gomock.Controller) *MockUserServiceI {
+	mock := &MockUserServiceI{ctrl: ctrl}
+	mock.recorder = &MockUserServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserServiceI) EXPECT() *MockUserServiceIMockRecorder {
+	return m.recorder
+}
+
+// GetUser mocks base method.
+func (m *MockUserServiceI) GetUser(arg0 context.Context, arg1 string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserServiceIMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserServiceI)(nil).GetUser), arg0, arg1)
+}
+
+// SetPreference mocks base method.
+func (m *MockUserServiceI) SetPreference(arg0 context.Context, arg1, arg2 string, arg3 primitive.ObjectID, arg4 string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreference", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPreference indicates an expected call of SetPreference.
+func (mr *MockUserServiceIMockRecorder) SetPreference(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*MockUserServiceI)(nil).SetPreference), arg0, arg1, arg2, arg3, arg4)
+}
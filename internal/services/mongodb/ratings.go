@@ -0,0 +1,131 @@
+package mongodb
+
+import (
+	"context"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const ratingsCollection = "ratings"
+
+//go:generate mockgen -destination=mockRatingService.go -package=mongodb . RatingServiceI
+type RatingServiceI interface {
+	SetRating(ctx context.Context, rating models.Rating) (*models.Rating, error)
+	GetRatingsForDish(ctx context.Context, dishId primitive.ObjectID) ([]*models.Rating, error)
+	GetRatingsForDishes(ctx context.Context, dishIds []primitive.ObjectID) ([]*models.Rating, error)
+	GetRatingSummaries(ctx context.Context) ([]models.RatingSummary, error)
+}
+
+// SetRating adds or replaces the caller's rating of a dish. The dish must exist in the caller's
+// tenant; mongo.ErrNoDocuments is returned otherwise.
+func (s *Service) SetRating(ctx context.Context, rating models.Rating) (*models.Rating, error) {
+	var dish models.Dish
+	var result models.Rating
+
+	err := s.scoped(ctx, "dishes").FindOne(ctx, bson.M{"_id": rating.Dish}).Decode(&dish)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	update := bson.M{
+		"$set": bson.M{
+			"cuisine":   dish.Cuisine,
+			"score":     rating.Score,
+			"note":      rating.Note,
+			"updatedAt": now,
+		},
+		"$setOnInsert": bson.M{"createdAt": now},
+	}
+
+	// the scoped filter pins householdId, so an upserted rating inherits the tenant
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	filter := bson.M{"dish": rating.Dish, "subject": rating.Subject}
+	err = s.scoped(ctx, ratingsCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("rated dish: %v with score: %v for user: %v", rating.Dish.Hex(), rating.Score, rating.Subject)
+
+	return &result, nil
+}
+
+func (s *Service) GetRatingsForDish(ctx context.Context, dishId primitive.ObjectID) ([]*models.Rating, error) {
+	var results []*models.Rating
+
+	opts := options.Find().SetSort(bson.M{"updatedAt": -1})
+	cursor, err := s.scoped(ctx, ratingsCollection).Find(ctx, bson.M{"dish": dishId}, opts)
+	if err != nil {
+		return results, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		err := cursor.Close(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("failed to close mongodb cursor; err: %v", err.Error())
+		}
+	}(cursor, ctx)
+
+	if curErr := cursor.All(ctx, &results); curErr != nil {
+		return nil, curErr
+	}
+
+	return results, nil
+}
+
+// GetRatingsForDishes returns the ratings of several dishes with one query, newest first.
+func (s *Service) GetRatingsForDishes(ctx context.Context, dishIds []primitive.ObjectID) ([]*models.Rating, error) {
+	var results []*models.Rating
+
+	opts := options.Find().SetSort(bson.M{"updatedAt": -1})
+	cursor, err := s.scoped(ctx, ratingsCollection).Find(ctx, bson.M{"dish": bson.M{"$in": dishIds}}, opts)
+	if err != nil {
+		return results, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		err := cursor.Close(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("failed to close mongodb cursor; err: %v", err.Error())
+		}
+	}(cursor, ctx)
+
+	if curErr := cursor.All(ctx, &results); curErr != nil {
+		return nil, curErr
+	}
+
+	return results, nil
+}
+
+// GetRatingSummaries returns the average score and rating count of every rated dish in the tenant.
+func (s *Service) GetRatingSummaries(ctx context.Context) ([]models.RatingSummary, error) {
+	var results []models.RatingSummary
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":     "$dish",
+			"cuisine": bson.M{"$first": "$cuisine"},
+			"average": bson.M{"$avg": "$score"},
+			"count":   bson.M{"$sum": 1},
+		}}},
+	}
+	cursor, err := s.scoped(ctx, ratingsCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return results, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		err := cursor.Close(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("failed to close mongodb cursor; err: %v", err.Error())
+		}
+	}(cursor, ctx)
+
+	if curErr := cursor.All(ctx, &results); curErr != nil {
+		return nil, curErr
+	}
+
+	return results, nil
+}
@@ -0,0 +1,114 @@
+package mongodb
+
+import (
+	"context"
+	"food-roulette-api/internal/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const householdField = "householdId"
+
+// scopedCollection restricts every query on a tenant owned collection to the household in the
+// request context, and stamps that household onto inserted documents. Service methods must use
+// it instead of the raw collection for cuisines and dishes so data cannot leak across tenants.
+type scopedCollection struct {
+	coll        *mongo.Collection
+	householdId primitive.ObjectID
+	scoped      bool
+}
+
+func (s *Service) scoped(ctx context.Context, name string) scopedCollection {
+	householdId, ok := tenant.HouseholdFromContext(ctx)
+	return scopedCollection{
+		coll:        s.Client.Database(s.Database).Collection(name),
+		householdId: householdId,
+		scoped:      ok,
+	}
+}
+
+func (c scopedCollection) filter(filter bson.M) bson.M {
+	scopedFilter := bson.M{}
+	for k, v := range filter {
+		scopedFilter[k] = v
+	}
+	if c.scoped {
+		scopedFilter[householdField] = c.householdId
+	} else {
+		scopedFilter[householdField] = bson.M{"$exists": false}
+	}
+	return scopedFilter
+}
+
+func (c scopedCollection) stamp(document interface{}) (interface{}, error) {
+	if !c.scoped {
+		return document, nil
+	}
+	doc, err := toDoc(document)
+	if err != nil {
+		return nil, err
+	}
+	stamped := bson.D{}
+	for _, e := range *doc {
+		if e.Key != householdField {
+			stamped = append(stamped, e)
+		}
+	}
+	return append(stamped, bson.E{Key: householdField, Value: c.householdId}), nil
+}
+
+func (c scopedCollection) Find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	return c.coll.Find(ctx, c.filter(filter), opts...)
+}
+
+func (c scopedCollection) FindOne(ctx context.Context, filter bson.M, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	return c.coll.FindOne(ctx, c.filter(filter), opts...)
+}
+
+func (c scopedCollection) InsertOne(ctx context.Context, document interface{}) (*mongo.InsertOneResult, error) {
+	doc, err := c.stamp(document)
+	if err != nil {
+		return nil, err
+	}
+	return c.coll.InsertOne(ctx, doc)
+}
+
+func (c scopedCollection) InsertMany(ctx context.Context, documents []interface{}) (*mongo.InsertManyResult, error) {
+	docs := make([]interface{}, len(documents))
+	for i, document := range documents {
+		doc, err := c.stamp(document)
+		if err != nil {
+			return nil, err
+		}
+		docs[i] = doc
+	}
+	return c.coll.InsertMany(ctx, docs)
+}
+
+func (c scopedCollection) UpdateByID(ctx context.Context, id interface{}, update interface{}) (*mongo.UpdateResult, error) {
+	return c.coll.UpdateOne(ctx, c.filter(bson.M{"_id": id}), update)
+}
+
+func (c scopedCollection) UpdateOne(ctx context.Context, filter bson.M, update interface{}) (*mongo.UpdateResult, error) {
+	return c.coll.UpdateOne(ctx, c.filter(filter), update)
+}
+
+func (c scopedCollection) DeleteOne(ctx context.Context, filter bson.M) (*mongo.DeleteResult, error) {
+	return c.coll.DeleteOne(ctx, c.filter(filter))
+}
+
+func (c scopedCollection) DeleteMany(ctx context.Context, filter bson.M) (*mongo.DeleteResult, error) {
+	return c.coll.DeleteMany(ctx, c.filter(filter))
+}
+
+func (c scopedCollection) FindOneAndUpdate(ctx context.Context, filter bson.M, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	return c.coll.FindOneAndUpdate(ctx, c.filter(filter), update, opts...)
+}
+
+// Aggregate runs pipeline after a leading $match stage that restricts it to the tenant.
+func (c scopedCollection) Aggregate(ctx context.Context, pipeline mongo.Pipeline) (*mongo.Cursor, error) {
+	scopedPipeline := append(mongo.Pipeline{{{Key: "$match", Value: c.filter(bson.M{})}}}, pipeline...)
+	return c.coll.Aggregate(ctx, scopedPipeline)
+}
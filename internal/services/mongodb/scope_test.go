@@ -0,0 +1,60 @@
+package mongodb
+
+import (
+	"context"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+)
+
+func TestScopedCollection_Filter(t *testing.T) {
+	householdId := primitive.NewObjectID()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		filter bson.M
+		want   bson.M
+	}{
+		{
+			name:   "Household tenant",
+			ctx:    tenant.WithHousehold(context.Background(), householdId),
+			filter: bson.M{"name": "thai"},
+			want:   bson.M{"name": "thai", householdField: householdId},
+		},
+		{
+			name:   "Default tenant excludes household data",
+			ctx:    context.Background(),
+			filter: bson.M{},
+			want:   bson.M{householdField: bson.M{"$exists": false}},
+		},
+		{
+			name:   "Caller cannot override the household",
+			ctx:    tenant.WithHousehold(context.Background(), householdId),
+			filter: bson.M{householdField: primitive.NewObjectID()},
+			want:   bson.M{householdField: householdId},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := scopedCollection{}
+			c.householdId, c.scoped = tenant.HouseholdFromContext(tt.ctx)
+			assert.Equal(t, tt.want, c.filter(tt.filter))
+		})
+	}
+}
+
+func TestScopedCollection_Stamp(t *testing.T) {
+	householdId := primitive.NewObjectID()
+	c := scopedCollection{householdId: householdId, scoped: true}
+
+	doc, err := c.stamp(models.AddCuisineRequest{Name: "thai"})
+	assert.NoError(t, err)
+
+	stamped := doc.(bson.D).Map()
+	assert.Equal(t, "thai", stamped["name"])
+	assert.Equal(t, householdId, stamped[householdField])
+}
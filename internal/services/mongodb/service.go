@@ -3,12 +3,18 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/config"
+	"food-roulette-api/internal/logging"
 	"food-roulette-api/internal/models"
-	config "github.com/calebtracey/config-yaml"
-	log "github.com/sirupsen/logrus"
+	"food-roulette-api/internal/tenant"
+	"food-roulette-api/internal/tracing"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"time"
 )
 
 //go:generate mockgen -destination=mockService.go -package=mongodb . ServiceI
@@ -18,47 +24,81 @@ type ServiceI interface {
 	GetAllCuisines(ctx context.Context) ([]*models.Cuisine, error)
 }
 
+// PingerI reports whether the database is reachable.
+type PingerI interface {
+	Ping(ctx context.Context) error
+}
+
+// CloserI releases the connection pool.
+type CloserI interface {
+	Disconnect(ctx context.Context) error
+}
+
 type Service struct {
 	Database string
 	Client   *mongo.Client
 	Mapper   Mapper
 }
 
-func InitializeMongoService(appConfig *config.Config) (*Service, error) {
-	mongoConfig, err := appConfig.GetDatabaseConfig("MONGO")
+// InitializeMongoService connects to the configured deployment and checks it answers a ping.
+func InitializeMongoService(ctx context.Context, mongoConfig config.MongoConfig) (*Service, error) {
+	timeout := time.Duration(mongoConfig.ConnectTimeout) * time.Second
+	clientOptions := options.Client().ApplyURI(mongoConfig.URI()).
+		SetConnectTimeout(timeout).
+		SetMonitor(tracing.MongoCommandMonitor())
+	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to open connection with database; err: %v", err.Error())
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err = client.Ping(pingCtx, readpref.Primary()); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("unable to reach database %v:%v; err: %v", mongoConfig.Server, mongoConfig.Port, err.Error())
 	}
 	return &Service{
-		Database: mongoConfig.Database.Value,
-		Client:   mongoConfig.MongoClient,
+		Database: mongoConfig.Database,
+		Client:   client,
 	}, nil
+}
 
+func (s *Service) Ping(ctx context.Context) error {
+	return s.Client.Ping(ctx, readpref.Primary())
+}
+
+// Disconnect closes the pooled connections, waiting for operations in progress until ctx is done.
+func (s *Service) Disconnect(ctx context.Context) error {
+	return s.Client.Disconnect(ctx)
 }
 
 func (s *Service) AddNewCuisine(ctx context.Context, request models.AddCuisineRequest) (*models.Cuisine, error) {
-	dbName := s.Database
-	database := s.Client.Database(dbName)
-	cuisineColl := database.Collection("cuisines")
+	cuisineColl := s.scoped(ctx, "cuisines")
 	var response models.Cuisine
 	var dishes []models.Dish
 	var cuisineId any
 	var err error
 
-	found, err := cuisineColl.Find(ctx, bson.M{"name": request.Name})
+	found, err := cuisineColl.Find(ctx, bson.M{"name": sameName(request.Name)})
 	if err != nil {
 		return &response, err
 	}
 
 	if found.RemainingBatchLength() > 0 {
-		return &response, fmt.Errorf("%v already exists in the database", request.Name)
+		return &response, apperrors.New(apperrors.ErrConflict, apperrors.CodeCuisineExists, fmt.Sprintf("cuisine %v already exists", request.Name))
 	}
 
-	cursor, err := database.Collection("cuisines").InsertOne(ctx, request)
+	createdAt := time.Now().UTC()
+	request.CreatedAt = &createdAt
+
+	cursor, err := cuisineColl.InsertOne(ctx, request)
+	if mongo.IsDuplicateKeyError(err) {
+		// another request inserted the same name between the check above and the insert
+		return &response, apperrors.Wrap(err, apperrors.ErrConflict, apperrors.CodeCuisineExists, fmt.Sprintf("cuisine %v already exists", request.Name))
+	}
 	if err != nil {
 		return &response, err
 	}
-	log.Infof("inserted new cuisine: %v into database", request.Name)
+	logging.FromContext(ctx).Infof("inserted new cuisine: %v into database", request.Name)
 	if cursor.InsertedID != nil {
 		cuisineId = cursor.InsertedID
 	}
@@ -77,29 +117,32 @@ func (s *Service) AddNewCuisine(ctx context.Context, request models.AddCuisineRe
 		update := bson.M{
 			"$set": request,
 		}
-		cursor, collErr := database.Collection("cuisines").UpdateByID(ctx, cursor.InsertedID, update)
+		cursor, collErr := cuisineColl.UpdateByID(ctx, cursor.InsertedID, update)
 		if collErr != nil {
 			return &response, collErr
 		}
-		log.Infof("update new cuisine: %v with new dishes", request.Name)
+		logging.FromContext(ctx).Infof("update new cuisine: %v with new dishes", request.Name)
 		if cursor.UpsertedID != nil {
 			cuisineId = cursor.UpsertedID
 		}
 	}
 
+	householdId, _ := tenant.HouseholdFromContext(ctx)
 	response = models.Cuisine{
 		ID:     cuisineId.(primitive.ObjectID),
 		Name:   request.Name,
 		Dishes: request.Dishes,
 		Tags:   request.Tags,
+
+		HouseholdID: householdId,
+		CreatedBy:   request.CreatedBy,
+		CreatedAt:   request.CreatedAt,
 	}
 
 	return &response, nil
 }
 
 func (s *Service) AddAllDishes(ctx context.Context, request models.AddDishesRequest) ([]models.Dish, error) {
-	dbName := s.Database
-	database := s.Client.Database(dbName)
 	var docs []interface{}
 	var results []models.Dish
 	var err error
@@ -112,23 +155,26 @@ func (s *Service) AddAllDishes(ctx context.Context, request models.AddDishesRequ
 		docs = append(docs, doc)
 	}
 
-	cursor, err := database.Collection("dishes").InsertMany(ctx, docs)
+	cursor, err := s.scoped(ctx, "dishes").InsertMany(ctx, docs)
 	if err != nil {
 		return results, err
 	}
 
 	results = s.Mapper.MapDishesResponse(cursor.InsertedIDs, request.Dishes, request.Cuisine)
+	if householdId, ok := tenant.HouseholdFromContext(ctx); ok {
+		for i := range results {
+			results[i].HouseholdID = householdId
+		}
+	}
 
 	return results, nil
 }
 
 func (s *Service) GetAllCuisines(ctx context.Context) ([]*models.Cuisine, error) {
-	dbName := s.Database
-	database := s.Client.Database(dbName)
 	var results []*models.Cuisine
 	var err error
 
-	cursor, err := database.Collection("cuisines").Find(ctx, bson.D{})
+	cursor, err := s.scoped(ctx, "cuisines").Find(ctx, bson.M{})
 	if err != nil {
 		return results, err
 	}
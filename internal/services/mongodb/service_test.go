@@ -0,0 +1,35 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"testing"
+)
+
+func TestService_AddNewCuisine_NamesDifferingInCase(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("Conflicts with the stored name", func(mt *mtest.T) {
+		s := &Service{Database: "meals", Client: mt.Client}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "meals.cuisines", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "name", Value: "Thai"}}))
+
+		_, err := s.AddNewCuisine(context.Background(), models.AddCuisineRequest{Name: "tHAI"})
+
+		assert.True(t, errors.Is(err, apperrors.ErrConflict), err)
+		assert.Equal(t, apperrors.CodeCuisineExists, apperrors.Code(err))
+		var filter struct {
+			Name primitive.Regex `bson:"name"`
+		}
+		if assert.NoError(t, bson.Unmarshal(mt.GetStartedEvent().Command.Lookup("filter").Document(), &filter)) {
+			assert.Equal(t, sameName("tHAI"), filter.Name)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const usersCollection = "users"
+
+//go:generate mockgen -destination=mockUserService.go -package=mongodb . UserServiceI
+type UserServiceI interface {
+	GetUser(ctx context.Context, subject string) (*models.User, error)
+	SetPreference(ctx context.Context, subject string, target string, id primitive.ObjectID, preference string) (*models.User, error)
+}
+
+func (s *Service) GetUser(ctx context.Context, subject string) (*models.User, error) {
+	database := s.Client.Database(s.Database)
+	var result models.User
+
+	err := database.Collection(usersCollection).FindOne(ctx, bson.M{"subject": subject}).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SetPreference upserts the user and moves id into the favorite or disliked list for target,
+// removing it from the opposite list. PreferenceNone clears it from both.
+func (s *Service) SetPreference(ctx context.Context, subject string, target string, id primitive.ObjectID, preference string) (*models.User, error) {
+	database := s.Client.Database(s.Database)
+	var result models.User
+
+	favoriteField, dislikedField, err := preferenceFields(target)
+	if err != nil {
+		return nil, err
+	}
+
+	update := bson.M{"$set": bson.M{"updatedAt": time.Now().UTC()}}
+	switch preference {
+	case models.PreferenceFavorite:
+		update["$addToSet"] = bson.M{favoriteField: id}
+		update["$pull"] = bson.M{dislikedField: id}
+	case models.PreferenceDislike:
+		update["$addToSet"] = bson.M{dislikedField: id}
+		update["$pull"] = bson.M{favoriteField: id}
+	default:
+		update["$pull"] = bson.M{favoriteField: id, dislikedField: id}
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err = database.Collection(usersCollection).FindOneAndUpdate(ctx, bson.M{"subject": subject}, update, opts).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("set %v preference %v for user: %v", target, preference, subject)
+
+	return &result, nil
+}
+
+func preferenceFields(target string) (string, string, error) {
+	switch target {
+	case models.PreferenceTargetCuisine:
+		return "favoriteCuisines", "dislikedCuisines", nil
+	case models.PreferenceTargetDish:
+		return "favoriteDishes", "dislikedDishes", nil
+	default:
+		return "", "", fmt.Errorf("unknown preference target: %v", target)
+	}
+}
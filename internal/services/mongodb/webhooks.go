@@ -0,0 +1,213 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/logging"
+	"food-roulette-api/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	webhooksCollection   = "webhooks"
+	deliveriesCollection = "webhookDeliveries"
+	// maxDeliveryLog caps how many deliveries of one webhook are listed, newest first.
+	maxDeliveryLog = 100
+)
+
+// WebhookServiceI manages the webhooks of the caller's household and their delivery log.
+//
+//go:generate mockgen -destination=mockWebhookService.go -package=mongodb . WebhookServiceI
+type WebhookServiceI interface {
+	AddWebhook(ctx context.Context, webhook models.Webhook) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]*models.Webhook, error)
+	// WebhooksFor returns the webhooks subscribed to event.
+	WebhooksFor(ctx context.Context, event string) ([]*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id primitive.ObjectID) error
+	EnqueueDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, webhookId primitive.ObjectID) ([]*models.WebhookDelivery, error)
+	// ReplayDelivery queues a delivery to be sent again right away, whatever its status.
+	ReplayDelivery(ctx context.Context, webhookId primitive.ObjectID, id primitive.ObjectID) (*models.WebhookDelivery, error)
+}
+
+// DeliveryQueueI is worked through by the webhook dispatcher, across every household.
+//
+//go:generate mockgen -destination=mockDeliveryQueue.go -package=mongodb . DeliveryQueueI
+type DeliveryQueueI interface {
+	// ClaimDelivery returns the pending delivery that is due first and hides it from other
+	// claims for lease, or nil when none is due.
+	ClaimDelivery(ctx context.Context, now time.Time, lease time.Duration) (*models.WebhookDelivery, error)
+	// WebhookByID returns mongo.ErrNoDocuments once the webhook has been deleted.
+	WebhookByID(ctx context.Context, id primitive.ObjectID) (*models.Webhook, error)
+	// RecordAttempt stores the status, attempt count and outcome of delivery; a nil
+	// NextAttemptAt clears the schedule.
+	RecordAttempt(ctx context.Context, delivery models.WebhookDelivery) error
+}
+
+func (s *Service) AddWebhook(ctx context.Context, webhook models.Webhook) (*models.Webhook, error) {
+	cursor, err := s.scoped(ctx, webhooksCollection).InsertOne(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("registered webhook: %v for events: %v", webhook.URL, webhook.Events)
+
+	if id, ok := cursor.InsertedID.(primitive.ObjectID); ok {
+		webhook.ID = id
+	}
+	return &webhook, nil
+}
+
+func (s *Service) GetWebhooks(ctx context.Context) ([]*models.Webhook, error) {
+	return s.findWebhooks(ctx, bson.M{})
+}
+
+func (s *Service) WebhooksFor(ctx context.Context, event string) ([]*models.Webhook, error) {
+	return s.findWebhooks(ctx, bson.M{"events": event})
+}
+
+func (s *Service) findWebhooks(ctx context.Context, filter bson.M) ([]*models.Webhook, error) {
+	var results []*models.Webhook
+
+	cursor, err := s.scoped(ctx, webhooksCollection).Find(ctx, filter)
+	if err != nil {
+		return results, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		err := cursor.Close(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("failed to close mongodb cursor; err: %v", err.Error())
+		}
+	}(cursor, ctx)
+
+	if curErr := cursor.All(ctx, &results); curErr != nil {
+		return nil, curErr
+	}
+	return results, nil
+}
+
+// DeleteWebhook removes the webhook; its deliveries stay in the log and pending ones fail.
+func (s *Service) DeleteWebhook(ctx context.Context, id primitive.ObjectID) error {
+	result, err := s.scoped(ctx, webhooksCollection).DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return webhookNotFound(mongo.ErrNoDocuments, id.Hex())
+	}
+	logging.FromContext(ctx).Infof("deleted webhook: %v", id.Hex())
+	return nil
+}
+
+func (s *Service) EnqueueDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	docs := make([]interface{}, len(deliveries))
+	for i, delivery := range deliveries {
+		docs[i] = delivery
+	}
+	_, err := s.scoped(ctx, deliveriesCollection).InsertMany(ctx, docs)
+	return err
+}
+
+func (s *Service) GetDeliveries(ctx context.Context, webhookId primitive.ObjectID) ([]*models.WebhookDelivery, error) {
+	var results []*models.WebhookDelivery
+
+	if err := s.scoped(ctx, webhooksCollection).FindOne(ctx, bson.M{"_id": webhookId}).Err(); err != nil {
+		return nil, webhookNotFound(err, webhookId.Hex())
+	}
+
+	opts := options.Find().SetSort(bson.M{"createdAt": -1}).SetLimit(maxDeliveryLog)
+	cursor, err := s.scoped(ctx, deliveriesCollection).Find(ctx, bson.M{"webhook": webhookId}, opts)
+	if err != nil {
+		return results, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		err := cursor.Close(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("failed to close mongodb cursor; err: %v", err.Error())
+		}
+	}(cursor, ctx)
+
+	if curErr := cursor.All(ctx, &results); curErr != nil {
+		return nil, curErr
+	}
+	return results, nil
+}
+
+// ReplayDelivery resets the attempt count so a delivery that failed for good gets every retry
+// again.
+func (s *Service) ReplayDelivery(ctx context.Context, webhookId primitive.ObjectID, id primitive.ObjectID) (*models.WebhookDelivery, error) {
+	var result models.WebhookDelivery
+
+	update := bson.M{"$set": bson.M{"status": models.DeliveryPending, "attempts": 0, "nextAttemptAt": time.Now().UTC()}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := s.scoped(ctx, deliveriesCollection).FindOneAndUpdate(ctx, bson.M{"_id": id, "webhook": webhookId}, update, opts).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, apperrors.Wrap(err, apperrors.ErrNotFound, apperrors.CodeDeliveryNotFound, fmt.Sprintf("delivery %v of webhook %v not found", id.Hex(), webhookId.Hex()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Infof("replaying delivery: %v of webhook: %v", id.Hex(), webhookId.Hex())
+
+	return &result, nil
+}
+
+func (s *Service) ClaimDelivery(ctx context.Context, now time.Time, lease time.Duration) (*models.WebhookDelivery, error) {
+	var result models.WebhookDelivery
+
+	filter := bson.M{"status": models.DeliveryPending, "nextAttemptAt": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"nextAttemptAt": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().SetSort(bson.M{"nextAttemptAt": 1}).SetReturnDocument(options.After)
+	err := s.Client.Database(s.Database).Collection(deliveriesCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *Service) WebhookByID(ctx context.Context, id primitive.ObjectID) (*models.Webhook, error) {
+	var result models.Webhook
+
+	err := s.Client.Database(s.Database).Collection(webhooksCollection).FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *Service) RecordAttempt(ctx context.Context, delivery models.WebhookDelivery) error {
+	set := bson.M{
+		"status":         delivery.Status,
+		"attempts":       delivery.Attempts,
+		"lastAttemptAt":  delivery.LastAttemptAt,
+		"lastStatusCode": delivery.LastStatusCode,
+		"lastError":      delivery.LastError,
+	}
+	update := bson.M{"$set": set}
+	if delivery.NextAttemptAt != nil {
+		set["nextAttemptAt"] = delivery.NextAttemptAt
+	} else {
+		update["$unset"] = bson.M{"nextAttemptAt": ""}
+	}
+	if delivery.DeliveredAt != nil {
+		set["deliveredAt"] = delivery.DeliveredAt
+	}
+
+	_, err := s.Client.Database(s.Database).Collection(deliveriesCollection).UpdateByID(ctx, delivery.ID, update)
+	return err
+}
+
+func webhookNotFound(err error, id string) error {
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	return apperrors.Wrap(err, apperrors.ErrNotFound, apperrors.CodeWebhookNotFound, fmt.Sprintf("webhook %v not found", id))
+}
@@ -5,58 +5,60 @@ import (
 	"fmt"
 	log "github.com/sirupsen/logrus"
 	"net/http"
-	"os"
-	"os/signal"
-	"sync"
-	"syscall"
 	"time"
 )
 
-func ListenAndServe(addr string, handler http.Handler) error {
-	log.Infof("Listening on Port: %v", addr)
+// Timeouts bound how long a connection may spend reading a request, writing a response and
+// idling between requests.
+type Timeouts struct {
+	Read  time.Duration
+	Write time.Duration
+	Idle  time.Duration
+}
+
+// HTTPServer is an http.Server whose ListenAndServe and Shutdown fit lifecycle.Manager.Serve.
+type HTTPServer struct {
+	*http.Server
+}
 
+// NewHTTPServer serves handler on port, over HTTPS and HTTP/2 when tlsOptions is set.
+func NewHTTPServer(port string, handler http.Handler, timeouts Timeouts, tlsOptions *TLS) *HTTPServer {
 	srv := &http.Server{
-		Addr:         fmt.Sprintf(":%v", addr),
+		Addr:         fmt.Sprintf(":%v", port),
 		Handler:      handler,
-		WriteTimeout: 15 * time.Second,
-		ReadTimeout:  15 * time.Second,
+		WriteTimeout: timeouts.Write,
+		ReadTimeout:  timeouts.Read,
+		IdleTimeout:  timeouts.Idle,
 	}
+	if tlsOptions != nil {
+		srv.TLSConfig = tlsOptions.Config()
+	}
+	return &HTTPServer{Server: srv}
+}
 
-	signals := make(chan os.Signal, 1)
-	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
-
-	wgServer := sync.WaitGroup{}
-	wgServer.Add(2)
-
-	var serverError error
-
-	go func() {
-		defer wgServer.Done()
-		killSignal := <-signals
-		switch killSignal {
-		case os.Interrupt:
-			log.Infoln("SIGINT recieved (Control-C ?)")
-		case syscall.SIGTERM:
-			log.Infoln("SIGTERM recieved (Heroku shutdown?)")
-		case nil:
-			return
-		}
-		log.Infoln("graceful shutdown...")
-		err := srv.Shutdown(context.Background())
-		if err != nil {
-			log.Error(err.Error())
-		}
-		log.Infoln("graceful shutdown complete")
-	}()
-
-	go func() {
-		defer wgServer.Done()
-		if err := srv.ListenAndServe(); err != nil {
-			serverError = err
-		}
-		signals <- nil
-	}()
+// ListenAndServe blocks until the server fails or Shutdown is called, which returns nil.
+func (s *HTTPServer) ListenAndServe() error {
+	log.Infof("Listening on Port: %v", s.Addr)
+	var err error
+	if s.TLSConfig != nil {
+		// the certificate comes from TLSConfig.GetCertificate
+		err = s.Server.ListenAndServeTLS("", "")
+	} else {
+		err = s.Server.ListenAndServe()
+	}
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
 
-	wgServer.Wait()
-	return serverError
+// Shutdown closes the listener and waits for in-flight requests until ctx is done, then closes
+// the connections that are still open. Long-lived responses should register with
+// RegisterOnShutdown to finish early.
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	err := s.Server.Shutdown(ctx)
+	if err != nil {
+		_ = s.Server.Close()
+	}
+	return err
 }
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"food-roulette-api/internal/health"
+	"food-roulette-api/internal/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestHTTPServer_DrainsBeforeShutdown(t *testing.T) {
+	port := freePort(t)
+	readiness := health.NewReadiness(time.Second, 200*time.Millisecond)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	srv := NewHTTPServer(port, handler, Timeouts{Read: time.Second, Write: time.Second}, nil)
+	manager := &lifecycle.Manager{Readiness: readiness, DrainTimeout: time.Second}
+	manager.Serve("http", srv.ListenAndServe, srv.Shutdown)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- manager.Run()
+	}()
+
+	url := "http://127.0.0.1:" + port
+	assert.Eventually(t, func() bool {
+		res, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		_ = res.Body.Close()
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Eventually(t, readiness.Draining, time.Second, 5*time.Millisecond)
+	// requests are still served while the load balancer notices the replica is not ready
+	res, err := http.Get(url)
+	if assert.NoError(t, err) {
+		_ = res.Body.Close()
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	}
+
+	select {
+	case err = <-serverErr:
+		assert.NoError(t, err, "a signalled shutdown is clean")
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// TLS serves the listener over HTTPS with certificates from Certificates.
+type TLS struct {
+	Certificates *CertReloader
+}
+
+// Config serves the current certificate to every new handshake, offering HTTP/2 first.
+func (t *TLS) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: t.Certificates.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+}
+
+// NewRedirectServer serves permanent redirects from plain HTTP on port to HTTPS on httpsPort.
+func NewRedirectServer(port string, httpsPort string, timeouts Timeouts) *HTTPServer {
+	return NewHTTPServer(port, redirectHandler(httpsPort), timeouts, nil)
+}
+
+// redirectHandler sends every request to the same host and path on the HTTPS port.
+func redirectHandler(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+		target := "https://" + host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusPermanentRedirect)
+	})
+}
+
+// CertReloader holds the serving certificate and replaces it when its files change or the
+// process receives SIGHUP. Only new handshakes see the new certificate; open connections
+// keep theirs.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	modified time.Time
+}
+
+// NewCertReloader loads the key pair once so a bad configuration fails at startup.
+func NewCertReloader(certFile string, keyFile string) (*CertReloader, error) {
+	reloader := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if err := reloader.Reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+func (c *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cert, nil
+}
+
+// Reload reads the key pair again. On failure the current certificate stays in use.
+func (c *CertReloader) Reload() error {
+	modified, err := c.lastModified()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate %v and key %v: %v", c.certFile, c.keyFile, err.Error())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cert = &cert
+	c.modified = modified
+	return nil
+}
+
+// Watch reloads in the background on SIGHUP and, when interval is positive, whenever either
+// file's modification time changes, until ctx is done. SIGHUP is handled once Watch returns.
+func (c *CertReloader) Watch(ctx context.Context, interval time.Duration) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+
+	var poll <-chan time.Time
+	var ticker *time.Ticker
+	if interval > 0 {
+		ticker = time.NewTicker(interval)
+		poll = ticker.C
+	}
+
+	go func() {
+		defer signal.Stop(hangups)
+		if ticker != nil {
+			defer ticker.Stop()
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hangups:
+				log.Infoln("SIGHUP received, reloading TLS certificate")
+				c.reload()
+			case <-poll:
+				if c.changed() {
+					log.Infoln("TLS certificate changed on disk, reloading")
+					c.reload()
+				}
+			}
+		}
+	}()
+}
+
+func (c *CertReloader) changed() bool {
+	modified, err := c.lastModified()
+	if err != nil {
+		log.Errorf("failed to check TLS certificate: %v", err.Error())
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !modified.Equal(c.modified)
+}
+
+func (c *CertReloader) reload() {
+	if err := c.Reload(); err != nil {
+		log.Errorf("keeping the current TLS certificate: %v", err.Error())
+		return
+	}
+	log.Infoln("TLS certificate reloaded")
+}
+
+// lastModified is the later modification time of the certificate and key files.
+func (c *CertReloader) lastModified() (time.Time, error) {
+	var latest time.Time
+	for _, path := range []string{c.certFile, c.keyFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to read %v: %v", path, err.Error())
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"food-roulette-api/internal/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// writeSelfSigned writes a fresh self-signed certificate for 127.0.0.1 and returns it.
+func writeSelfSigned(t *testing.T, certFile string, keyFile string, commonName string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return cert
+}
+
+func freePort(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+}
+
+// servedCommonName opens a new connection and returns the subject of the certificate presented.
+func servedCommonName(port string) string {
+	conn, err := tls.Dial("tcp", "127.0.0.1:"+port, &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return ""
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func TestHTTPServer_TLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	first := writeSelfSigned(t, certFile, keyFile, "first")
+
+	certificates, err := NewCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	certificates.Watch(ctx, 20*time.Millisecond)
+
+	port, redirectPort := freePort(t), freePort(t)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	})
+	timeouts := Timeouts{Read: time.Second, Write: time.Second}
+	srv := NewHTTPServer(port, handler, timeouts, &TLS{Certificates: certificates})
+	redirect := NewRedirectServer(redirectPort, port, timeouts)
+	manager := &lifecycle.Manager{DrainTimeout: time.Second}
+	manager.Serve("http", srv.ListenAndServe, srv.Shutdown)
+	manager.Serve("http redirect", redirect.ListenAndServe, redirect.Shutdown)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- manager.Run()
+	}()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(first)
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{RootCAs: roots},
+		ForceAttemptHTTP2: true,
+	}}
+	url := "https://127.0.0.1:" + port + "/api/v1/cuisines"
+	assert.Eventually(t, func() bool {
+		res, err := client.Get(url)
+		if err != nil {
+			return false
+		}
+		_ = res.Body.Close()
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+
+	res, err := client.Get(url)
+	if assert.NoError(t, err) {
+		_ = res.Body.Close()
+		assert.Equal(t, 2, res.ProtoMajor, "HTTP/2 is negotiated over TLS")
+	}
+
+	// plain HTTP is redirected to the same path over HTTPS
+	noFollow := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	res, err = noFollow.Get("http://127.0.0.1:" + redirectPort + "/api/v1/cuisines?tag=spicy")
+	if assert.NoError(t, err) {
+		_ = res.Body.Close()
+		assert.Equal(t, http.StatusPermanentRedirect, res.StatusCode)
+		assert.Equal(t, "https://127.0.0.1:"+port+"/api/v1/cuisines?tag=spicy", res.Header.Get("Location"))
+	}
+
+	// a changed file is picked up by polling; the open HTTP/2 connection keeps working
+	writeSelfSigned(t, certFile, keyFile, "second")
+	later := time.Now().Add(time.Minute)
+	_ = os.Chtimes(certFile, later, later)
+	_ = os.Chtimes(keyFile, later, later)
+	assert.Eventually(t, func() bool { return servedCommonName(port) == "second" }, 2*time.Second, 10*time.Millisecond)
+	res, err = client.Get(url)
+	if assert.NoError(t, err) {
+		_ = res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	}
+
+	// SIGHUP reloads even when the files look unchanged to polling
+	staged := t.TempDir()
+	stagedCert, stagedKey := filepath.Join(staged, "server.crt"), filepath.Join(staged, "server.key")
+	writeSelfSigned(t, stagedCert, stagedKey, "third")
+	for staged, path := range map[string]string{stagedCert: certFile, stagedKey: keyFile} {
+		_ = os.Chtimes(staged, later, later)
+		if err = os.Rename(staged, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.Equal(t, "second", servedCommonName(port))
+	if err = syscall.Kill(syscall.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	assert.Eventually(t, func() bool { return servedCommonName(port) == "third" }, 2*time.Second, 10*time.Millisecond)
+
+	// a broken pair is rejected and the current certificate stays in use
+	assert.NoError(t, os.WriteFile(keyFile, []byte("not a key"), 0o600))
+	assert.Error(t, certificates.Reload())
+	assert.Equal(t, "third", servedCommonName(port))
+
+	if err = syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err = <-serverErr:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
+
+func TestNewCertReloader_MissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewCertReloader(filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,22 @@
+package tenant
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Header selects the household a request operates on. Requests without it use the shared
+// default tenant, which holds data created before households existed.
+const Header = "X-Household-ID"
+
+type tenantKey struct{}
+
+func WithHousehold(ctx context.Context, householdId primitive.ObjectID) context.Context {
+	return context.WithValue(ctx, tenantKey{}, householdId)
+}
+
+// HouseholdFromContext returns the household selected for ctx; ok is false for the default tenant.
+func HouseholdFromContext(ctx context.Context) (primitive.ObjectID, bool) {
+	householdId, ok := ctx.Value(tenantKey{}).(primitive.ObjectID)
+	return householdId, ok && !householdId.IsZero()
+}
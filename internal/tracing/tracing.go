@@ -0,0 +1,128 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/config"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+	"sync"
+)
+
+const instrumentationName = "food-roulette-api"
+
+// Setup installs the global tracer provider and W3C trace context propagator. The returned func
+// flushes and stops the exporter; it is a no-op when tracing is disabled.
+func Setup(ctx context.Context, tracingConfig config.TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch tracingConfig.Exporter {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case "otlp":
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(tracingConfig.Endpoint)}
+		if tracingConfig.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %v", tracingConfig.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := tracingConfig.ServiceName
+	if serviceName == "" {
+		serviceName = instrumentationName
+	}
+	sampleRatio := tracingConfig.SampleRatio
+	if sampleRatio <= 0 {
+		sampleRatio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// MongoCommandMonitor starts a client span for every command the driver sends, as a child of
+// the span in the command's context, so no query goes untraced whichever code path issued it.
+func MongoCommandMonitor() *event.CommandMonitor {
+	// the driver reports each command's start and end separately; a request id is only unique
+	// per connection
+	var spans sync.Map
+	key := func(connectionID string, requestID int64) string {
+		return fmt.Sprintf("%v/%v", connectionID, requestID)
+	}
+	finish := func(connectionID string, requestID int64, err error) {
+		if span, ok := spans.LoadAndDelete(key(connectionID, requestID)); ok {
+			End(span.(trace.Span), err)
+		}
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			name := "mongodb." + evt.CommandName
+			attributes := []attribute.KeyValue{
+				semconv.DBSystemMongoDB,
+				semconv.DBNameKey.String(evt.DatabaseName),
+				semconv.DBOperationKey.String(evt.CommandName),
+			}
+			if collection, ok := evt.Command.Lookup(evt.CommandName).StringValueOK(); ok {
+				name = "mongodb." + collection + "." + evt.CommandName
+				attributes = append(attributes, attribute.String("db.mongodb.collection", collection))
+			}
+			_, span := Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+			spans.Store(key(evt.ConnectionID, evt.RequestID), span)
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			finish(evt.ConnectionID, evt.RequestID, nil)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			finish(evt.ConnectionID, evt.RequestID, errors.New(evt.Failure))
+		},
+	}
+}
+
+// StorageObserver starts a span around every storage operation of mongodb.InstrumentedService.
+type StorageObserver struct{}
+
+func (StorageObserver) Observe(ctx context.Context, operation string) (context.Context, func(err error)) {
+	ctx, span := Tracer().Start(ctx, "storage."+operation)
+	return ctx, func(err error) {
+		End(span, err)
+	}
+}
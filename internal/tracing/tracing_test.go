@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+)
+
+func TestMongoCommandMonitor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	ctx, parent := Tracer().Start(context.Background(), "storage.GetAllCuisines")
+	monitor := MongoCommandMonitor()
+	find, _ := bson.Marshal(bson.D{{Key: "find", Value: "cuisines"}})
+	ping, _ := bson.Marshal(bson.D{{Key: "ping", Value: 1}})
+
+	// the same request id on another connection is a different command
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: find, DatabaseName: "meals", CommandName: "find", RequestID: 7, ConnectionID: "db:27017[-1]"})
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: ping, DatabaseName: "admin", CommandName: "ping", RequestID: 7, ConnectionID: "db:27017[-2]"})
+	monitor.Failed(ctx, &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "ping", RequestID: 7, ConnectionID: "db:27017[-2]"},
+		Failure:              "connection reset",
+	})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find", RequestID: 7, ConnectionID: "db:27017[-1]"},
+	})
+	parent.End()
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 3) {
+		return
+	}
+	pingSpan, findSpan := spans[0], spans[1]
+
+	assert.Equal(t, "mongodb.ping", pingSpan.Name())
+	assert.Equal(t, codes.Error, pingSpan.Status().Code)
+	assert.Equal(t, "connection reset", pingSpan.Status().Description)
+
+	assert.Equal(t, "mongodb.cuisines.find", findSpan.Name())
+	assert.Equal(t, codes.Unset, findSpan.Status().Code)
+	assert.Equal(t, parent.SpanContext().SpanID(), findSpan.Parent().SpanID())
+	assert.Contains(t, findSpan.Attributes(), attribute.String("db.mongodb.collection", "cuisines"))
+	assert.Contains(t, findSpan.Attributes(), attribute.String("db.name", "meals"))
+}
@@ -0,0 +1,171 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Rules are declared with `validate` struct tags on the request models. Besides the built in
+// validator rules the following tags are available:
+//
+//	name        letters, digits, spaces and ' & . , ( ) -, starting with a letter or digit
+//	tag         lowercase words joined by single hyphens, e.g. "gluten-free"
+//	objectid    a 24 character hex Mongo ObjectID
+//	uniquenames a slice of structs whose Name fields are unique ignoring case and spacing
+const (
+	maxTagLength = 32
+)
+
+var (
+	namePattern     = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N} '&.,()\-]*$`)
+	tagPattern      = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	objectIdPattern = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+)
+
+var validate = newValidator()
+
+// FieldError is a single violation. Field is the JSON path of the offending value,
+// e.g. dishes[1].name.
+type FieldError struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+// Errors collects every violation found in a request. It matches apperrors.ErrValidation.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e Errors) Is(target error) bool {
+	return target == apperrors.ErrValidation
+}
+
+// Struct validates v against its `validate` tags and returns every violation, or nil.
+func Struct(v interface{}) error {
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	violations := make(Errors, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		violations = append(violations, FieldError{
+			Field:   fieldPath(fieldErr.Namespace()),
+			Rule:    fieldErr.Tag(),
+			Message: message(fieldErr),
+		})
+	}
+	return violations
+}
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	mustRegister(v, "name", func(fl validator.FieldLevel) bool {
+		return namePattern.MatchString(fl.Field().String())
+	})
+	mustRegister(v, "tag", func(fl validator.FieldLevel) bool {
+		tag := fl.Field().String()
+		return len(tag) <= maxTagLength && tagPattern.MatchString(tag)
+	})
+	mustRegister(v, "objectid", func(fl validator.FieldLevel) bool {
+		return objectIdPattern.MatchString(fl.Field().String())
+	})
+	mustRegister(v, "uniquenames", uniqueNames)
+	return v
+}
+
+func mustRegister(v *validator.Validate, tag string, fn validator.Func) {
+	if err := v.RegisterValidation(tag, fn); err != nil {
+		panic(err)
+	}
+}
+
+func uniqueNames(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.Slice {
+		return false
+	}
+	seen := make(map[string]bool, field.Len())
+	for i := 0; i < field.Len(); i++ {
+		item := reflect.Indirect(field.Index(i))
+		if item.Kind() != reflect.Struct {
+			return false
+		}
+		name := item.FieldByName("Name")
+		if !name.IsValid() || name.Kind() != reflect.String {
+			return false
+		}
+		key := strings.ToLower(strings.Join(strings.Fields(name.String()), " "))
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+	}
+	return true
+}
+
+// fieldPath drops the struct name from a validator namespace: AddCuisineRequest.dishes[0].name
+// becomes dishes[0].name.
+func fieldPath(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func message(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	kind := fieldErr.Kind()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min", "max", "len":
+		bound := map[string]string{"min": "at least", "max": "at most", "len": "exactly"}[fieldErr.Tag()]
+		switch kind {
+		case reflect.String:
+			return fmt.Sprintf("must be %v %v characters long", bound, param)
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("must contain %v %v items", bound, param)
+		}
+		return fmt.Sprintf("must be %v %v", bound, param)
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "unique":
+		return "must not contain duplicates"
+	case "uniquenames":
+		return "must not contain two entries with the same name"
+	case "name":
+		return "may only contain letters, digits, spaces and ' & . , ( ) -"
+	case "tag":
+		return fmt.Sprintf("must be lowercase words joined by hyphens and at most %v characters", maxTagLength)
+	case "objectid":
+		return "must be a 24 character hex id"
+	}
+	return fmt.Sprintf("failed the %v rule", fieldErr.Tag())
+}
@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"errors"
+	"food-roulette-api/internal/apperrors"
+	"food-roulette-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestStruct(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    interface{}
+		wantFields []string
+	}{
+		{
+			name: "Valid cuisine",
+			request: models.AddCuisineRequest{
+				Name:   "Crème Brûlée & Co.",
+				Tags:   []string{"french", "gluten-free"},
+				Dishes: []models.Dish{{Name: "Soufflé"}, {Name: "Crêpes", Tags: []string{"sweet"}}},
+			},
+		},
+		{
+			name:       "Missing name",
+			request:    models.AddCuisineRequest{},
+			wantFields: []string{"name"},
+		},
+		{
+			name: "Name charset and tag format",
+			request: models.AddCuisineRequest{
+				Name: "<script>",
+				Tags: []string{"Spicy Food", "ok", "-bad"},
+			},
+			wantFields: []string{"name", "tags[0]", "tags[2]"},
+		},
+		{
+			name: "Too many tags",
+			request: models.AddCuisineRequest{
+				Name: "Thai",
+				Tags: strings.Fields("a b c d e f g h i j k"),
+			},
+			wantFields: []string{"tags"},
+		},
+		{
+			name: "Duplicate dishes ignore case and spacing",
+			request: models.AddCuisineRequest{
+				Name:   "Thai",
+				Dishes: []models.Dish{{Name: "Pad Thai"}, {Name: "pad  thai"}},
+			},
+			wantFields: []string{"dishes"},
+		},
+		{
+			name: "Nested dish violations carry their index",
+			request: models.AddCuisineRequest{
+				Name:   "Thai",
+				Dishes: []models.Dish{{Name: "Pad Thai"}, {Name: "", Tags: []string{"Hot"}}},
+			},
+			wantFields: []string{"dishes[1].name", "dishes[1].tags[0]"},
+		},
+		{
+			name:       "ObjectID format and enums",
+			request:    models.SetPreferenceRequest{Target: "plan", ID: "123", Preference: "love"},
+			wantFields: []string{"target", "id", "preference"},
+		},
+		{
+			name:       "Rating bounds",
+			request:    models.RateDishRequest{Score: 6, Note: strings.Repeat("x", 501)},
+			wantFields: []string{"score", "note"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.request)
+			if len(tt.wantFields) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var violations Errors
+			if !assert.True(t, errors.As(err, &violations)) {
+				return
+			}
+			var fields []string
+			for _, violation := range violations {
+				fields = append(fields, violation.Field)
+				assert.NotEmpty(t, violation.Message)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+			assert.True(t, errors.Is(err, apperrors.ErrValidation))
+			assert.Equal(t, 400, apperrors.Status(err))
+		})
+	}
+}
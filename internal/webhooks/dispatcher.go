@@ -0,0 +1,237 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxDrain caps how much of a response is read so its connection can be reused.
+const maxDrain = 4096
+
+var defaultClient = NewClient(DefaultTimeout)
+
+// Dispatcher defaults, used for zero fields.
+const (
+	DefaultMaxAttempts  = 8
+	DefaultBaseDelay    = 10 * time.Second
+	DefaultMaxDelay     = time.Hour
+	DefaultPollInterval = time.Second
+	DefaultLease        = time.Minute
+	DefaultWorkers      = 4
+	DefaultTimeout      = 10 * time.Second
+)
+
+// Dispatcher sends the queued deliveries. A delivery answered with a 2xx status is delivered;
+// any other outcome is retried with exponential backoff until MaxAttempts, and then failed.
+// Several dispatchers, in this process or others, can share one queue: a claimed delivery is
+// hidden from the others for Lease.
+type Dispatcher struct {
+	Queue mongodb.DeliveryQueueI
+	// Client defaults to NewClient(DefaultTimeout); other clients must guard against requests
+	// to internal addresses in the same way.
+	Client *http.Client
+	// MaxAttempts counts the first attempt.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// PollInterval is the pause once the queue has nothing due.
+	PollInterval time.Duration
+	// Lease must be longer than Client's timeout, or a slow delivery is sent twice.
+	Lease   time.Duration
+	Workers int
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// Run sends deliveries until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	workers := d.Workers
+	if workers == 0 {
+		workers = DefaultWorkers
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (d *Dispatcher) work(ctx context.Context) {
+	pollInterval := d.PollInterval
+	if pollInterval == 0 {
+		pollInterval = DefaultPollInterval
+	}
+	for {
+		sent, err := d.dispatchNext(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Errorf("webhooks: dispatching failed, retrying in %v: %v", pollInterval, err.Error())
+		}
+		if sent && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// dispatchNext attempts the delivery that is due first, and reports whether there was one.
+func (d *Dispatcher) dispatchNext(ctx context.Context) (bool, error) {
+	lease := d.Lease
+	if lease == 0 {
+		lease = DefaultLease
+	}
+	delivery, err := d.Queue.ClaimDelivery(ctx, d.clock(), lease)
+	if err != nil || delivery == nil {
+		return false, err
+	}
+
+	webhook, err := d.Queue.WebhookByID(ctx, delivery.Webhook)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		now := d.clock()
+		delivery.Status = models.DeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastAttemptAt = &now
+		delivery.LastStatusCode = 0
+		delivery.LastError = "webhook was deleted"
+		return true, d.Queue.RecordAttempt(ctx, *delivery)
+	}
+	if err != nil {
+		return true, err
+	}
+
+	statusCode, sendErr := d.send(ctx, webhook, delivery)
+	if ctx.Err() != nil {
+		// shutting down; the lease runs out and the delivery is attempted again
+		return true, nil
+	}
+	return true, d.Queue.RecordAttempt(ctx, d.outcome(*delivery, statusCode, sendErr))
+}
+
+// outcome updates delivery with the result of an attempt.
+func (d *Dispatcher) outcome(delivery models.WebhookDelivery, statusCode int, err error) models.WebhookDelivery {
+	maxAttempts := d.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	baseDelay, maxDelay := d.BaseDelay, d.MaxDelay
+	if baseDelay == 0 {
+		baseDelay = DefaultBaseDelay
+	}
+	if maxDelay == 0 {
+		maxDelay = DefaultMaxDelay
+	}
+
+	now := d.clock()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+	delivery.NextAttemptAt = nil
+	switch {
+	case err == nil:
+		delivery.Status = models.DeliveryDelivered
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= maxAttempts:
+		delivery.Status = models.DeliveryFailed
+		delivery.LastError = err.Error()
+	default:
+		next := now.Add(Backoff(delivery.Attempts, baseDelay, maxDelay))
+		delivery.Status = models.DeliveryPending
+		delivery.NextAttemptAt = &next
+		delivery.LastError = err.Error()
+	}
+	return delivery
+}
+
+// send POSTs the signed payload and returns the response status; the error is nil only for 2xx.
+// Only the status of a failed response is recorded: its body is the receiver's, and is never
+// shown back to the household that registered the webhook.
+func (d *Dispatcher) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	if !strings.HasPrefix(webhook.URL, "https://") {
+		return 0, errors.New("webhook url must be an https url")
+	}
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "food-roulette-webhooks/1")
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, delivery.ID.Hex())
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, d.clock(), body))
+
+	client := d.Client
+	if client == nil {
+		client = defaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, sendError(err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxDrain))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, nil
+	}
+	return resp.StatusCode, fmt.Errorf("receiver answered %v", resp.Status)
+}
+
+// sendError describes a failed request without the details of the network it failed on.
+func sendError(err error) error {
+	switch {
+	case errors.Is(err, errForbiddenAddress):
+		return errForbiddenAddress
+	case errors.Is(err, context.DeadlineExceeded) || os.IsTimeout(err):
+		return errors.New("receiver timed out")
+	default:
+		return errors.New("receiver could not be reached")
+	}
+}
+
+// Backoff returns the delay before the retry that follows attempt, counted from 1: base doubled
+// for every attempt after the first, and never more than max.
+func Backoff(attempt int, base time.Duration, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+func (d *Dispatcher) clock() time.Time {
+	if d.now != nil {
+		return d.now()
+	}
+	return time.Now().UTC()
+}
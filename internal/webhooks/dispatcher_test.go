@@ -0,0 +1,189 @@
+package webhooks
+
+import (
+	"context"
+	"food-roulette-api/internal/models"
+	"food-roulette-api/internal/services/mongodb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDispatcher_DispatchNext(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	webhookID := primitive.NewObjectID()
+	deliveryID := primitive.NewObjectID()
+	payload := `{"id":"evt_1","type":"pick.made","occurredAt":"2024-03-01T12:00:00Z"}`
+
+	tests := []struct {
+		name string
+		// status is what the receiver answers; 0 closes the receiver before the attempt
+		status        int
+		attempts      int
+		webhookErr    error
+		wantStatus    string
+		wantAttempts  int
+		wantNext      *time.Time
+		wantError     string
+		wantDelivered bool
+	}{
+		{
+			name:          "Delivered on a 2xx",
+			status:        http.StatusNoContent,
+			wantStatus:    models.DeliveryDelivered,
+			wantAttempts:  1,
+			wantDelivered: true,
+		},
+		{
+			name:         "A 5xx is retried after the base delay",
+			status:       http.StatusServiceUnavailable,
+			wantStatus:   models.DeliveryPending,
+			wantAttempts: 1,
+			wantNext:     timePtr(now.Add(10 * time.Second)),
+			wantError:    "receiver answered 503 Service Unavailable",
+		},
+		{
+			name:         "Retries back off exponentially",
+			status:       http.StatusInternalServerError,
+			attempts:     3,
+			wantStatus:   models.DeliveryPending,
+			wantAttempts: 4,
+			wantNext:     timePtr(now.Add(80 * time.Second)),
+			wantError:    "receiver answered 500 Internal Server Error",
+		},
+		{
+			name:         "Fails for good after the last attempt",
+			status:       http.StatusGone,
+			attempts:     4,
+			wantStatus:   models.DeliveryFailed,
+			wantAttempts: 5,
+			wantError:    "receiver answered 410 Gone",
+		},
+		{
+			name:         "An unreachable receiver is retried",
+			wantStatus:   models.DeliveryPending,
+			wantAttempts: 1,
+			wantNext:     timePtr(now.Add(10 * time.Second)),
+			wantError:    "receiver could not be reached",
+		},
+		{
+			name:       "Deliveries of a deleted webhook fail",
+			webhookErr: mongo.ErrNoDocuments,
+			wantStatus: models.DeliveryFailed,
+			wantError:  "webhook was deleted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var received int
+			receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received++
+				body, _ := ioutil.ReadAll(r.Body)
+				assert.Equal(t, payload, string(body))
+				assert.NoError(t, Verify("whsec_test", r.Header.Get(SignatureHeader), body, now, time.Minute))
+				assert.Equal(t, "pick.made", r.Header.Get(EventHeader))
+				assert.Equal(t, deliveryID.Hex(), r.Header.Get(DeliveryHeader))
+				w.WriteHeader(tt.status)
+				if tt.status >= 300 {
+					// the body is the receiver's and must not reach the delivery log
+					_, _ = w.Write([]byte("internal details\n"))
+				}
+			}))
+			defer receiver.Close()
+			if tt.status == 0 {
+				receiver.Close()
+			}
+
+			queue := mongodb.NewMockDeliveryQueueI(ctrl)
+			queue.EXPECT().ClaimDelivery(gomock.Any(), now, time.Minute).Return(&models.WebhookDelivery{
+				ID:       deliveryID,
+				Webhook:  webhookID,
+				Event:    models.EventPickMade,
+				Payload:  payload,
+				Status:   models.DeliveryPending,
+				Attempts: tt.attempts,
+			}, nil)
+			if tt.webhookErr != nil {
+				queue.EXPECT().WebhookByID(gomock.Any(), webhookID).Return(nil, tt.webhookErr)
+			} else {
+				queue.EXPECT().WebhookByID(gomock.Any(), webhookID).Return(&models.Webhook{ID: webhookID, URL: receiver.URL, Secret: "whsec_test"}, nil)
+			}
+			var recorded models.WebhookDelivery
+			queue.EXPECT().RecordAttempt(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, delivery models.WebhookDelivery) error {
+				recorded = delivery
+				return nil
+			})
+
+			d := &Dispatcher{Queue: queue, Client: receiver.Client(), MaxAttempts: 5, now: func() time.Time { return now }}
+			sent, err := d.dispatchNext(context.Background())
+			assert.NoError(t, err)
+			assert.True(t, sent)
+
+			assert.Equal(t, tt.wantStatus, recorded.Status)
+			assert.Equal(t, tt.wantAttempts, recorded.Attempts)
+			assert.Equal(t, tt.wantNext, recorded.NextAttemptAt)
+			assert.Equal(t, tt.status, recorded.LastStatusCode)
+			if tt.wantError != "" {
+				assert.Equal(t, tt.wantError, recorded.LastError)
+			}
+			if tt.wantDelivered {
+				assert.Equal(t, &now, recorded.DeliveredAt)
+				assert.Empty(t, recorded.LastError)
+			} else {
+				assert.Nil(t, recorded.DeliveredAt)
+			}
+			if tt.status != 0 && tt.webhookErr == nil {
+				assert.Equal(t, 1, received)
+			}
+		})
+	}
+}
+
+func TestDispatcher_DispatchNext_NothingDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	queue := mongodb.NewMockDeliveryQueueI(ctrl)
+	queue.EXPECT().ClaimDelivery(gomock.Any(), gomock.Any(), DefaultLease).Return(nil, nil)
+
+	d := &Dispatcher{Queue: queue}
+	sent, err := d.dispatchNext(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, sent)
+}
+
+func TestDispatcher_Send_RequiresHTTPS(t *testing.T) {
+	d := &Dispatcher{}
+	_, err := d.send(context.Background(), &models.Webhook{URL: "http://hooks.example.com/lunch"}, &models.WebhookDelivery{})
+	assert.EqualError(t, err, "webhook url must be an https url")
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 10 * time.Second},
+		{attempt: 2, want: 20 * time.Second},
+		{attempt: 4, want: 80 * time.Second},
+		{attempt: 9, want: 2560 * time.Second},
+		{attempt: 10, want: time.Hour},
+		{attempt: 200, want: time.Hour},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Backoff(tt.attempt, 10*time.Second, time.Hour), "attempt %v", tt.attempt)
+	}
+}
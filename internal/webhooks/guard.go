@@ -0,0 +1,120 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"food-roulette-api/internal/apperrors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// Webhooks are registered by household editors but sent from inside the network, so receivers
+// must be public HTTPS endpoints: addresses that reach this host, the private network or cloud
+// metadata services are refused when a webhook is registered and again when it is dialed, as a
+// name may resolve differently by then.
+
+var errForbiddenAddress = errors.New("webhook receivers must have a public address")
+
+// blockedNetworks are ranges that are not public but that net.IP has no predicate for.
+var blockedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",     // "this" network
+	"100.64.0.0/10", // carrier-grade NAT
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+	"64:ff9b::/96",  // NAT64, which can reach private IPv4 addresses
+)
+
+// resolver resolves receiver host names at registration; tests replace it.
+var resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+} = net.DefaultResolver
+
+// CheckURL returns a validation error unless rawURL is an https URL whose host only resolves to
+// public addresses.
+func CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return apperrors.New(apperrors.ErrValidation, apperrors.CodeWebhookURL, "webhook url must be an https url")
+	}
+	if u.User != nil {
+		return apperrors.New(apperrors.ErrValidation, apperrors.CodeWebhookURL, "webhook url must not contain credentials")
+	}
+
+	host := u.Hostname()
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := resolver.LookupIPAddr(ctx, host)
+		if err != nil || len(addrs) == 0 {
+			return apperrors.Wrap(err, apperrors.ErrValidation, apperrors.CodeWebhookURL, fmt.Sprintf("webhook host %v could not be resolved", host))
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+	for _, ip := range ips {
+		if !publicIP(ip) {
+			return apperrors.New(apperrors.ErrValidation, apperrors.CodeWebhookURL, fmt.Sprintf("webhook host %v does not have a public address", host))
+		}
+	}
+	return nil
+}
+
+// NewClient returns the client deliveries are sent with. It only dials public addresses,
+// checked after resolution, and does not follow redirects, which could point anywhere.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: dialControl}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// dialControl runs with the resolved address of every connection, so a name rebound to a
+// private address after registration is still refused.
+func dialControl(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+		return fmt.Errorf("%w, not %v", errForbiddenAddress, host)
+	}
+	return nil
+}
+
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
@@ -0,0 +1,16 @@
+package webhooks
+
+import (
+	"time"
+)
+
+// Payload is the JSON body of every delivery.
+type Payload struct {
+	// ID is the event's ID; receivers use it to drop duplicates, since retries and replays resend
+	// the same payload.
+	ID          string      `json:"id"`
+	Type        string      `json:"type"`
+	OccurredAt  time.Time   `json:"occurredAt"`
+	HouseholdID string      `json:"householdId,omitempty"`
+	Data        interface{} `json:"data,omitempty"`
+}
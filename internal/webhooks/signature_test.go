@@ -0,0 +1,59 @@
+package webhooks
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	sent := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"id":"1","type":"pick.made"}`)
+	header := Sign("whsec_test", sent, body)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr bool
+	}{
+		{name: "Valid signature", secret: "whsec_test", header: header, body: body, now: sent.Add(time.Minute)},
+		{name: "Any of several signatures may match", secret: "whsec_test", header: header + ",v1=00ff", body: body, now: sent},
+		{name: "Tampered body", secret: "whsec_test", header: header, body: []byte(`{"id":"2"}`), now: sent, wantErr: true},
+		{name: "Wrong secret", secret: "whsec_other", header: header, body: body, now: sent, wantErr: true},
+		{name: "Too old", secret: "whsec_test", header: header, body: body, now: sent.Add(10 * time.Minute), wantErr: true},
+		{name: "Missing timestamp", secret: "whsec_test", header: header[strings.Index(header, ",")+1:], body: body, now: sent, wantErr: true},
+		{name: "Missing signature", secret: "whsec_test", header: header[:strings.Index(header, ",")], body: body, now: sent, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header, tt.body, tt.now, 5*time.Minute)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidSignature), "got %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// the HMAC-SHA256 of "1700000000.{}" keyed with "secret"
+	got := Sign("secret", time.Unix(1700000000, 0), []byte("{}"))
+	assert.Equal(t, "t=1700000000,v1=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", got)
+}
+
+func TestNewSecret(t *testing.T) {
+	first, err := NewSecret()
+	assert.NoError(t, err)
+	second, err := NewSecret()
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first, "whsec_"))
+	assert.Len(t, first, len("whsec_")+43)
+	assert.NotEqual(t, first, second)
+}